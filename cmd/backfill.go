@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+)
+
+// journalHeaderPattern matches the "## <date> to <date>" header of a journal entry
+var journalHeaderPattern = regexp.MustCompile(`(?m)^## ([A-Z][a-z]+ \d{1,2}, \d{4}) to ([A-Z][a-z]+ \d{1,2}, \d{4})$`)
+
+// journalRange is the date range of a journal entry or of a week to backfill
+type journalRange struct {
+	start, end time.Time
+}
+
+// overlaps reports whether r shares at least one day with any of the ranges
+func (r journalRange) overlaps(ranges []journalRange) bool {
+	for _, other := range ranges {
+		if !r.start.After(other.end) && !other.start.After(r.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJournalRanges returns the date ranges of the journal's entry headers,
+// skipping headers whose dates don't parse
+func parseJournalRanges(content string) []journalRange {
+	var ranges []journalRange
+	for _, match := range journalHeaderPattern.FindAllStringSubmatch(content, -1) {
+		start, err := time.Parse(journalDateLayout, match[1])
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(journalDateLayout, match[2])
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, journalRange{start: start, end: end})
+	}
+	return ranges
+}
+
+// missingJournalWeeks returns, oldest first, the complete weeks from the one
+// after the latest journal entry through last week that no entry overlaps.
+// With no entries it returns the last defaultWeeks weeks. The current week is
+// left to the regular highlight run, since it is not over yet.
+func missingJournalWeeks(entries []journalRange, now time.Time, weekStart time.Weekday, defaultWeeks int) []journalRange {
+	// Journal dates carry no time zone, so compare calendar dates in UTC
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	lastWeekStart := dateparse.GetNamedPeriodsAt(today, weekStart)["last-week"].StartDate
+
+	first := lastWeekStart.AddDate(0, 0, -7*(defaultWeeks-1))
+	if len(entries) > 0 {
+		latest := entries[0].end
+		for _, entry := range entries[1:] {
+			if entry.end.After(latest) {
+				latest = entry.end
+			}
+		}
+		day := latest.AddDate(0, 0, 1)
+		first = day.AddDate(0, 0, -((int(day.Weekday()) - int(weekStart) + 7) % 7))
+	}
+
+	var weeks []journalRange
+	for start := first; !start.After(lastWeekStart); start = start.AddDate(0, 0, 7) {
+		week := journalRange{start: start, end: start.AddDate(0, 0, 6)}
+		if !week.overlaps(entries) {
+			weeks = append(weeks, week)
+		}
+	}
+	return weeks
+}
+
+// backfillJournal generates a highlight and journal entry for each week
+// missing from the journal, oldest first, so the newest ends up on top
+func backfillJournal(opts *highlightOptions, defaultWeeks int) error {
+	log := opts.log
+	weekStart, err := dateparse.ParseWeekStart(viper.GetString("date.week_start"))
+	if err != nil {
+		return err
+	}
+	log.Infof("→ Reading journal %s...\n", opts.journal)
+	content, err := opts.journal.read()
+	if err != nil {
+		return err
+	}
+
+	entries := parseJournalRanges(content)
+	weeks := missingJournalWeeks(entries, time.Now(), weekStart, defaultWeeks)
+	if len(weeks) == 0 {
+		log.Printf("✓ Journal is up to date; no missing weeks to backfill\n")
+		return nil
+	}
+	if len(entries) == 0 {
+		log.Printf("No dated entries in the journal; backfilling the last %d weeks\n", len(weeks))
+	} else {
+		log.Printf("Backfilling %d missing week(s) since %s\n", len(weeks), dateparse.FormatForDisplay(weeks[0].start))
+	}
+
+	for i, week := range weeks {
+		log.Printf("\n[%d/%d] %s to %s\n", i+1, len(weeks), dateparse.FormatForDisplay(week.start), dateparse.FormatForDisplay(week.end))
+		err := generateHighlight(opts, dateparse.FormatForAPI(week.start), dateparse.FormatForAPI(week.end))
+		if err != nil {
+			return fmt.Errorf("backfilling %s to %s: %w", dateparse.FormatISO(week.start), dateparse.FormatISO(week.end), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMissingJournalWeeks(t *testing.T) {
+	// Wednesday, January 29, 2025
+	now := time.Date(2025, 1, 29, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		journal   string
+		weekStart time.Weekday
+		want      []string
+	}{
+		{
+			name:      "gap after the latest entry",
+			journal:   "## January 6, 2025 to January 12, 2025\n- Newer\n\n---\n\n## December 30, 2024 to January 5, 2025\n- Older\n",
+			weekStart: time.Monday,
+			want:      []string{"2025-01-13..2025-01-19", "2025-01-20..2025-01-26"},
+		},
+		{
+			name:      "mid-week entry leaves its week alone",
+			journal:   "## January 8, 2025 to January 15, 2025\n- Rolling 7 days\n",
+			weekStart: time.Monday,
+			want:      []string{"2025-01-20..2025-01-26"},
+		},
+		{
+			name:      "up to date",
+			journal:   "## January 20, 2025 to January 26, 2025\n- Last week\n",
+			weekStart: time.Monday,
+		},
+		{
+			name:      "no entries uses the default weeks",
+			journal:   "# My journal\n",
+			weekStart: time.Sunday,
+			want:      []string{"2025-01-12..2025-01-18", "2025-01-19..2025-01-25"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, week := range missingJournalWeeks(parseJournalRanges(tt.journal), now, tt.weekStart, 2) {
+				got = append(got, week.start.Format("2006-01-02")+".."+week.end.Format("2006-01-02"))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("missingJournalWeeks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
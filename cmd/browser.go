@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+// startBrowser launches a command without waiting for it, replaced in tests
+var startBrowser = func(name string, args ...string) error {
+	return exec.Command(name, args...).Start()
+}
+
+// browserCommand returns the command that opens path in the default browser on goos
+func browserCommand(goos, path string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{path}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", path}
+	default:
+		return "xdg-open", []string{path}
+	}
+}
+
+// browserAvailable reports whether a browser can be opened: not in CI, on an
+// interactive terminal, and on Linux and the BSDs with a graphical session
+func browserAvailable(goos string, getenv func(string) string, interactive bool) bool {
+	if getenv("CI") != "" || !interactive {
+		return false
+	}
+	if goos == "darwin" || goos == "windows" {
+		return true
+	}
+	return getenv("DISPLAY") != "" || getenv("WAYLAND_DISPLAY") != ""
+}
+
+// writeHighlightOutput prints the formatted highlight, or writes it to
+// --output-file. With --open an HTML report is opened in the browser, going
+// through a temp file if no output file was given.
+func writeHighlightOutput(formatted string, format output.Format, log logger.Logger) error {
+	path := viper.GetString("highlight.output_file")
+	open := viper.GetBool("highlight.open") && format == output.FormatHTML
+	if open && path == "" {
+		file, err := os.CreateTemp("", "perfdive-highlight-*.html")
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		path = file.Name()
+		_ = file.Close()
+	}
+
+	if path == "" {
+		fmt.Print(formatted)
+		if !strings.HasSuffix(formatted, "\n") {
+			fmt.Println()
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(formatted), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	log.Printf("✓ Wrote highlight to %s\n", path)
+
+	if !open {
+		return nil
+	}
+	if !browserAvailable(runtime.GOOS, os.Getenv, color.IsTerminal(os.Stdin) && color.IsTerminal(os.Stderr)) {
+		log.Infof("ℹ Not opening a browser in a non-interactive session (--open)\n")
+		return nil
+	}
+	name, args := browserCommand(runtime.GOOS, path)
+	if err := startBrowser(name, args...); err != nil {
+		log.Printf("Warning: failed to open %s in a browser: %v\n", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+func TestBrowserAvailable(t *testing.T) {
+	tests := []struct {
+		name        string
+		goos        string
+		env         map[string]string
+		interactive bool
+		want        bool
+	}{
+		{name: "linux desktop", goos: "linux", env: map[string]string{"DISPLAY": ":0"}, interactive: true, want: true},
+		{name: "wayland", goos: "freebsd", env: map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, interactive: true, want: true},
+		{name: "headless linux", goos: "linux", interactive: true},
+		{name: "macOS", goos: "darwin", interactive: true, want: true},
+		{name: "CI", goos: "darwin", env: map[string]string{"CI": "true"}, interactive: true},
+		{name: "piped", goos: "windows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := browserAvailable(tt.goos, getenv, tt.interactive); got != tt.want {
+				t.Errorf("browserAvailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHighlightOutputToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	viper.Set("highlight.output_file", path)
+	viper.Set("highlight.open", true)
+	t.Cleanup(func() {
+		viper.Set("highlight.output_file", "")
+		viper.Set("highlight.open", false)
+	})
+	var opened []string
+	original := startBrowser
+	t.Cleanup(func() { startBrowser = original })
+	startBrowser = func(name string, args ...string) error {
+		opened = append([]string{name}, args...)
+		return nil
+	}
+
+	if err := writeHighlightOutput("<html></html>\n", output.FormatHTML, logger.Nop()); err != nil {
+		t.Fatalf("writeHighlightOutput() error = %v", err)
+	}
+	if got, err := os.ReadFile(path); err != nil || string(got) != "<html></html>\n" {
+		t.Errorf("report file = %q, %v", got, err)
+	}
+	// go test has no interactive terminal, so --open must be a no-op
+	if opened != nil {
+		t.Errorf("opened %v in a non-interactive session", opened)
+	}
+	if name, args := browserCommand("windows", path); name != "rundll32" || args[1] != path {
+		t.Errorf("browserCommand(windows) = %s %v", name, args)
+	}
+}
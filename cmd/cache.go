@@ -10,6 +10,7 @@ import (
 
 	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
 )
 
 var cacheCmd = &cobra.Command{
@@ -46,6 +47,23 @@ func init() {
 	cacheCmd.AddCommand(cacheCleanCmd)
 }
 
+// reportStaleCache lists the cache entries served although older than
+// --max-age, so a report isn't mistaken for live data
+func reportStaleCache(log logger.Logger, maxAge time.Duration, stale ...[]string) {
+	var all []string
+	for _, s := range stale {
+		all = append(all, s...)
+	}
+	if len(all) == 0 {
+		return
+	}
+	log.Printf("⚠ %d cached entries were older than --max-age %s:\n", len(all), maxAge)
+	for _, entry := range all {
+		log.Printf("  - %s\n", entry)
+	}
+	log.Printf("  Rerun with --no-cache to fetch them fresh (or highlight --clear-cache for the GitHub activity)\n")
+}
+
 func runCacheStats(cmd *cobra.Command, args []string) {
 	fmt.Println("Cache Statistics")
 	fmt.Println("================")
@@ -71,6 +89,9 @@ func runCacheStats(cmd *cobra.Command, args []string) {
 		fmt.Printf("  Activity entries:  %d (TTL: 1 hour)\n", ghStats["activity"])
 		fmt.Printf("  PR entries:        %d (TTL: 24 hours)\n", ghStats["prs"])
 		fmt.Printf("  Issue entries:     %d (TTL: 24 hours)\n", ghStats["issues"])
+		fmt.Printf("  Unavailable refs:  %d (404/403, TTL: 1 hour)\n", ghStats["negative"])
+		fmt.Printf("  User lookups:      %d (email to username, TTL: 7 days)\n", ghStats["users"])
+		fmt.Printf("  Hit ratio:         %s\n", formatHitRatio(ghStats["hits"], ghStats["misses"]))
 
 		// Get detailed info from metadata
 		ghMetadata := ghCache.GetDetailedStats()
@@ -92,6 +113,7 @@ func runCacheStats(cmd *cobra.Command, args []string) {
 		jiraStats := jiraCache.GetCacheStats()
 		fmt.Printf("  Total entries:     %v\n", jiraStats["total"])
 		fmt.Printf("  TTL:               %v\n", jiraStats["ttl"])
+		fmt.Printf("  Hit ratio:         %s\n", formatHitRatio(jiraStats["hits"].(int), jiraStats["misses"].(int)))
 
 		// Get detailed info
 		jiraMetadata := jiraCache.GetDetailedStats()
@@ -255,3 +277,11 @@ func getCacheDirStats(path string) (int64, int, error) {
 
 	return size, count, err
 }
+
+// formatHitRatio formats cache lookups as a hit percentage with the counts
+func formatHitRatio(hits, misses int) string {
+	if hits+misses == 0 {
+		return "N/A (no lookups yet)"
+	}
+	return fmt.Sprintf("%.1f%% (%d hits, %d misses)", 100*float64(hits)/float64(hits+misses), hits, misses)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestReportStaleCache(t *testing.T) {
+	var buf bytes.Buffer
+	reportStaleCache(logger.New(&buf, 0), time.Hour, []string{"Jira issue CNF-1, cached 3h20m ago"}, nil, []string{"PR o/r#2, cached 2h ago"})
+
+	want := "⚠ 2 cached entries were older than --max-age 1h0m0s:\n" +
+		"  - Jira issue CNF-1, cached 3h20m ago\n" +
+		"  - PR o/r#2, cached 2h ago\n" +
+		"  Rerun with --no-cache to fetch them fresh (or highlight --clear-cache for the GitHub activity)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("reportStaleCache() wrote\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	reportStaleCache(logger.New(&buf, 0), time.Hour, nil, nil)
+	if buf.Len() != 0 {
+		t.Errorf("reportStaleCache() with nothing stale wrote %q, want nothing", buf.String())
+	}
+}
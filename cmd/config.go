@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the perfdive configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for unknown keys and missing values",
+	Long: `Load the config file (~/.perfdive.yaml or --config) and check it:
+
+  - keys perfdive doesn't know are reported, with the closest valid key,
+    e.g. github.gist (did you mean github.gist_url?) or ollama_url (did you
+    mean ollama.url?), since a misspelled key is silently ignored
+  - the values each command requires are checked, including those set by
+    flags, environment variables and the secrets provider
+
+Exits non-zero when a problem is found, so it can run in CI.`,
+	Args: cobra.NoArgs,
+	Run:  runConfigValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// configKeys are the config keys perfdive reads
+var configKeys = []string{
+	"api.diff_size_limit", "api.issue_comments_limit", "api.patch_size_limit", "api.review_comments_limit",
+	"ascii",
+	"cache.max_age", "cache.refresh_expired_only",
+	"date.week_start",
+	"email", "end_date", "start_date",
+	"github.activity", "github.activity_types", "github.api_version", "github.bot_logins",
+	"github.circuit_breaker_threshold", "github.commits", "github.email_map", "github.gist_url",
+	"github.include_bot_prs", "github.include_draft_prs", "github.max_wait", "github.org",
+	"github.redact_diff_repos", "github.redact_diffs", "github.repo_request_budget", "github.timeout", "github.token", "github.username",
+	"group_by_repo",
+	"highlight.accomplishment_categories", "highlight.explain_scoring", "highlight.frontmatter_tags", "highlight.frontmatter_title",
+	"highlight.markdown_frontmatter", "highlight.open", "highlight.output_file",
+	"http.ca_cert",
+	"jira.auth_type", "jira.comments", "jira.group_by", "jira.project_key_pattern", "jira.resolution",
+	"jira.role", "jira.timeline", "jira.token", "jira.token_cmd", "jira.url", "jira.username",
+	"journal.file", "journal.group_by_month",
+	"leaderboard.weights.jira_resolved", "leaderboard.weights.pr_merged", "leaderboard.weights.pr_opened", "leaderboard.weights.review",
+	"max_issues", "max_prs", "max_references",
+	"metrics.file", "metrics.path", "metrics.record",
+	"no_cache", "no_color", "no_llm", "offline", "preview_prompt", "profile", "quiet", "rate_limit_delay", "verbose",
+	"ollama.model", "ollama.model_params", "ollama.options", "ollama.persona", "ollama.summary_length", "ollama.timeout",
+	"ollama.url", "ollama.urls",
+	"output.format", "output.section_order", "output.sections",
+	"redact.enabled", "redact.patterns",
+	"secrets.dir", "secrets.provider", "shipped_only", "split_by",
+}
+
+// ineffectiveConfigKeys are keys perfdive once accepted but doesn't read,
+// with why setting them has no effect
+var ineffectiveConfigKeys = map[string]string{
+	"cache.activity_ttl_hours": "the GitHub activity cache TTL is fixed at 1 hour; expired entries are refetched as a run needs them; use --refresh-expired-only to refetch them up front or --max-age to flag older ones",
+	"cache.issue_ttl_hours":    "the Jira and GitHub issue cache TTLs are fixed at 24 hours; expired entries are refetched as a run needs them; use --refresh-expired-only to refetch them up front or --max-age to flag older ones",
+}
+
+// configMapKeys hold maps with user-chosen keys, which aren't checked
+var configMapKeys = []string{"github.email_map", "ollama.options"}
+
+// requiredConfig lists the values each command needs to run
+var requiredConfig = []struct {
+	commands string
+	keys     []string
+}{
+	{commands: "perfdive, highlight, team, leaderboard, tui", keys: []string{"jira.url", "jira.username", "jira.token"}},
+	{commands: "perfdive, highlight", keys: []string{"ollama.url"}},
+}
+
+// configProblem is an unknown key in the config file and the closest valid
+// key, or a known key that has no effect and why
+type configProblem struct {
+	key         string
+	suggestion  string
+	ineffective string
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		fmt.Fprintf(os.Stderr, "Error: no config file found; create ~/.perfdive.yaml or pass --config\n")
+		os.Exit(1)
+	}
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if problems := validateConfig(os.Stdout, path, file.AllKeys(), configValueSet); problems > 0 {
+		os.Exit(1)
+	}
+}
+
+// validateConfig reports the unknown keys among the config file's keys and
+// the required values missing for each command, returning the problem count
+func validateConfig(w io.Writer, path string, keys []string, isSet func(key string) (bool, string)) int {
+	fmt.Fprintf(w, "Validating %s\n\n", path)
+
+	unknown := unknownConfigKeys(keys)
+	for _, problem := range unknown {
+		if problem.ineffective != "" {
+			fmt.Fprintf(w, color.Symbols("✗ %s has no effect: %s\n"), problem.key, problem.ineffective)
+		} else if problem.suggestion != "" {
+			fmt.Fprintf(w, color.Symbols("✗ Unknown key %s (did you mean %s?)\n"), problem.key, problem.suggestion)
+		} else {
+			fmt.Fprintf(w, color.Symbols("✗ Unknown key %s\n"), problem.key)
+		}
+	}
+	if len(unknown) == 0 {
+		fmt.Fprintf(w, color.Symbols("✓ All %d keys are known\n"), len(keys))
+	}
+
+	missing := 0
+	fmt.Fprintf(w, "\nRequired values:\n")
+	for _, required := range requiredConfig {
+		for _, key := range required.keys {
+			if ok, source := isSet(key); ok {
+				fmt.Fprintf(w, color.Symbols("✓ %s (%s): %s\n"), key, required.commands, source)
+			} else {
+				fmt.Fprintf(w, color.Symbols("✗ %s is missing (needed by %s)\n"), key, required.commands)
+				missing++
+			}
+		}
+	}
+	if viper.GetString("github.gist_url") != "" {
+		if ok, _ := isSet("github.token"); !ok {
+			fmt.Fprint(w, color.Symbols("✗ github.token is missing (needed by highlight to update github.gist_url)\n"))
+			missing++
+		}
+	}
+
+	problems := len(unknown) + missing
+	if problems == 0 {
+		fmt.Fprint(w, color.Symbols("\n✓ Config is valid\n"))
+	} else {
+		fmt.Fprintf(w, "\n%d problem(s) found\n", problems)
+	}
+	return problems
+}
+
+// configValueSet reports whether the effective config (file, flags,
+// environment and secrets) sets key, and from where. Tokens from
+// jira.token_cmd or gh aren't fetched, only noted.
+func configValueSet(key string) (bool, string) {
+	switch key {
+	case "jira.token":
+		switch {
+		case viper.GetString("jira.token") != "":
+			return true, "set"
+		case os.Getenv("JIRA_TOKEN") != "":
+			return true, "JIRA_TOKEN"
+		case viper.GetString("jira.token_cmd") != "":
+			return true, "from jira.token_cmd (not run)"
+		}
+		return false, ""
+	case "github.token":
+		switch {
+		case viper.GetString("github.token") != "":
+			return true, "set"
+		case os.Getenv("GITHUB_TOKEN") != "":
+			return true, "GITHUB_TOKEN"
+		}
+		if _, err := lookPath("gh"); err == nil {
+			return true, "from gh auth token (not run)"
+		}
+		return false, ""
+	}
+	if value := viper.GetString(key); value != "" {
+		return true, value
+	}
+	return false, ""
+}
+
+// unknownConfigKeys returns the keys that aren't config keys, sorted, with
+// the closest valid key for each, or why a key that perfdive doesn't read has
+// no effect. Keys under profiles.<name> are checked like top-level keys.
+func unknownConfigKeys(keys []string) []configProblem {
+	known := make(map[string]bool, len(configKeys))
+	for _, key := range configKeys {
+		known[key] = true
+	}
+
+	var problems []configProblem
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		rest := key
+		if after, ok := strings.CutPrefix(key, "profiles."); ok {
+			_, rest, _ = strings.Cut(after, ".")
+		}
+		if known[rest] || inConfigMap(rest) {
+			continue
+		}
+		if reason, ok := ineffectiveConfigKeys[rest]; ok {
+			problems = append(problems, configProblem{key: key, ineffective: reason})
+			continue
+		}
+		suggestion := closestConfigKey(rest)
+		if suggestion != "" && rest != key {
+			suggestion = strings.TrimSuffix(key, rest) + suggestion
+		}
+		problems = append(problems, configProblem{key: key, suggestion: suggestion})
+	}
+	sort.Slice(problems, func(i, j int) bool { return problems[i].key < problems[j].key })
+	return problems
+}
+
+// inConfigMap reports whether key is an entry of a map-valued config key
+func inConfigMap(key string) bool {
+	for _, mapKey := range configMapKeys {
+		if strings.HasPrefix(key, mapKey+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// closestConfigKey returns the config key nearest to key by edit distance, or
+// "" if none is close enough to be a likely misspelling
+func closestConfigKey(key string) string {
+	best, bestDistance := "", len(key)/2+1
+	for _, candidate := range configKeys {
+		if distance := levenshtein(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestUnknownConfigKeys(t *testing.T) {
+	keys := []string{
+		"jira.url",
+		"github.gist",
+		"ollama_url",
+		"github.email_map.jane.doe@example.com",
+		"ollama.options.temperature",
+		"profiles.clienta.jira.usrname",
+		"profiles.clienta.github.token",
+		"completely.unrelated.setting",
+		"cache.activity_ttl_hours",
+		"profiles.clienta.cache.issue_ttl_hours",
+	}
+	want := []configProblem{
+		{key: "cache.activity_ttl_hours", ineffective: ineffectiveConfigKeys["cache.activity_ttl_hours"]},
+		{key: "completely.unrelated.setting"},
+		{key: "github.gist", suggestion: "github.gist_url"},
+		{key: "ollama_url", suggestion: "ollama.url"},
+		{key: "profiles.clienta.cache.issue_ttl_hours", ineffective: ineffectiveConfigKeys["cache.issue_ttl_hours"]},
+		{key: "profiles.clienta.jira.usrname", suggestion: "profiles.clienta.jira.username"},
+	}
+
+	got := unknownConfigKeys(keys)
+	if len(got) != len(want) {
+		t.Fatalf("unknownConfigKeys() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problem %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestConfigKeysCoverViperKeys fails when a key bound or read through viper
+// in this package is missing from configKeys, so config validate would
+// report it as unknown
+func TestConfigKeysCoverViperKeys(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	viperKey := regexp.MustCompile(`viper\.(?:BindPFlag|Get\w*|IsSet|SetDefault)\("([^"]+)"`)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		for _, match := range viperKey.FindAllSubmatch(source, -1) {
+			key := string(match[1])
+			if problems := unknownConfigKeys([]string{key}); len(problems) > 0 {
+				t.Errorf("%s uses config key %q, which is missing from configKeys", file, key)
+			}
+		}
+	}
+}
+
+func TestValidateConfigReportsMissingValues(t *testing.T) {
+	set := map[string]bool{"jira.url": true, "jira.token": true, "ollama.url": true}
+	isSet := func(key string) (bool, string) { return set[key], "set" }
+
+	var out strings.Builder
+	if problems := validateConfig(&out, "config.yaml", []string{"jira.url", "github.gist", "cache.activity_ttl_hours"}, isSet); problems != 3 {
+		t.Errorf("validateConfig() = %d problems, want 3", problems)
+	}
+	for _, want := range []string{
+		"✗ Unknown key github.gist (did you mean github.gist_url?)\n",
+		"✗ cache.activity_ttl_hours has no effect: the GitHub activity cache TTL is fixed at 1 hour; expired entries are refetched as a run needs them; use --refresh-expired-only to refetch them up front or --max-age to flag older ones\n",
+		"✗ jira.username is missing (needed by perfdive, highlight, team, leaderboard, tui)\n",
+		"✓ ollama.url (perfdive, highlight): set\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	config := map[string]interface{}{
+		"jira": map[string]interface{}{"url": "https://jira.example.com", "username": "dev@example.com"},
+		"profiles": map[string]interface{}{
+			"clienta": map[string]interface{}{"jira": map[string]interface{}{"url": "https://jira.clienta.com"}},
+		},
+	}
+	v := viper.New()
+	if err := v.MergeConfigMap(config); err != nil {
+		t.Fatalf("MergeConfigMap() error = %v", err)
+	}
+
+	if err := applyProfile(v, "missing"); err == nil || !strings.Contains(err.Error(), "profile 'missing' not found") {
+		t.Errorf("applyProfile(missing) error = %v, want profile not found", err)
+	}
+	if got := v.GetString("jira.url"); got != "https://jira.example.com" {
+		t.Errorf("jira.url = %q after a failed profile, want the top-level value", got)
+	}
+
+	if err := applyProfile(v, "clienta"); err != nil {
+		t.Fatalf("applyProfile(clienta) error = %v", err)
+	}
+	if got := v.GetString("jira.url"); got != "https://jira.clienta.com" {
+		t.Errorf("jira.url = %q, want the profile's value", got)
+	}
+	if got := v.GetString("jira.username"); got != "dev@example.com" {
+		t.Errorf("jira.username = %q, want the top-level value the profile doesn't set", got)
+	}
+}
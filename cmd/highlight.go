@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,10 +11,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
 	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
 )
 
 var highlightCmd = &cobra.Command{
@@ -29,11 +35,18 @@ Example:
   perfdive highlight bpalm@redhat.com --period this-month
   perfdive highlight bpalm@redhat.com --period q4-2024
   perfdive highlight bpalm@redhat.com --list 5
+  perfdive highlight bpalm@redhat.com --output json
+  perfdive highlight bpalm@redhat.com --output csv --csv-detail
+  perfdive highlight bpalm@redhat.com --output json > last-week.json
+  perfdive highlight bpalm@redhat.com --baseline last-week.json
+  perfdive highlight bpalm@redhat.com --months 6 --by-month --output csv
+  perfdive highlight bpalm@redhat.com -vv
 
 Supported date formats for --since:
   - MM-DD-YYYY (e.g., 01-15-2025)
   - YYYY-MM-DD (e.g., 2025-01-15)
   - Relative: "today", "yesterday", "last monday", "2 weeks ago"
+  - ISO week: 2025-W03 (that week's Monday)
 
 Supported periods for --period:
   - this-week, last-week
@@ -41,8 +54,21 @@ Supported periods for --period:
   - this-quarter, last-quarter
   - this-year, last-year
   - q1-2024, q2-2024, q3-2024, q4-2024 (quarterly by year)
+  - this-iso-week, last-iso-week, 2025-W03 (ISO 8601 weeks, Monday-Sunday)
 
-Note: If github.gist_url is configured, highlights will be automatically appended to your journal.`,
+Use --by-month with --output csv or json to export per-month counts (PRs
+created/merged, Jira created/resolved) for charting. --months N selects the
+last N calendar months, including the current one. No AI summary is generated
+and the journal is not updated in this mode.
+
+Use --baseline with a file saved from an earlier --output json run to list
+the PRs and Jira issues that are new, newly merged or newly resolved since
+then. Records are matched by owner/repo#number and Jira key.
+
+Note: If github.gist_url is configured, highlights will be automatically appended to your journal.
+Use --journal-file (or journal.file in the config) to keep it in a local markdown file instead.
+Add --journal-detail to include a collapsible list of the PRs and Jira issues,
+or --backfill to add an entry for each week missing since the latest one.`,
 	Args: cobra.ExactArgs(1),
 	Run:  runHighlight,
 }
@@ -53,10 +79,30 @@ func init() {
 	// Add highlight-specific flags
 	highlightCmd.Flags().IntP("days", "d", 7, "Number of days to look back (default 7)")
 	highlightCmd.Flags().String("since", "", "Start date (supports MM-DD-YYYY, YYYY-MM-DD, or relative like 'last monday', '2 weeks ago')")
-	highlightCmd.Flags().String("period", "", "Named period (this-week, last-month, this-quarter, q4-2024, etc.)")
-	highlightCmd.Flags().BoolP("verbose", "v", false, "Show detailed progress information")
+	highlightCmd.Flags().String("since-tag", "", "Start at the commit date of a release tag, given as owner/name@tag (e.g. redhat-best-practices-for-k8s/certsuite@v5.4.0)")
+	highlightCmd.Flags().String("period", "", "Named period (this-week, last-month, this-quarter, q4-2024, 2025-W03, etc.)")
 	highlightCmd.Flags().Bool("clear-cache", false, "Clear GitHub activity cache before running")
 	highlightCmd.Flags().IntP("list", "l", 0, "List top N accomplishments instead of just the biggest (e.g., --list 5)")
+	highlightCmd.Flags().StringP("output", "f", "text", "Output format (text, json, markdown, html, csv, table)")
+	highlightCmd.Flags().Bool("csv-detail", false, "With --output csv, emit one row per Jira issue and PR instead of a summary row")
+	highlightCmd.Flags().Bool("by-month", false, "With --output csv or json, emit per-month activity counts for charting")
+	highlightCmd.Flags().Int("months", 0, "Look back over the last N calendar months, including the current one")
+	highlightCmd.Flags().Bool("journal-detail", false, "Add a collapsible list of the period's PRs and Jira issues, with links, to the journal entry")
+	highlightCmd.Flags().String("journal-file", "", "Keep the journal in this local markdown file instead of github.gist_url")
+	highlightCmd.Flags().Bool("group-periods-in-journal-by-month", false, "Nest journal entries under \"# <Month> <Year>\" headings, newest month first")
+	highlightCmd.Flags().String("baseline", "", "Prior highlight --output json file; annotate the output with what is new since it")
+	highlightCmd.Flags().Bool("backfill", false, "Generate journal entries for each week missing since the latest entry in the journal")
+	highlightCmd.Flags().Int("backfill-weeks", 4, "With --backfill, the number of weeks to fill when the journal has no dated entries")
+	highlightCmd.Flags().Bool("explain-scoring", false, "Have the model rank its top 3 candidates for the biggest accomplishment, shown with -v")
+	highlightCmd.Flags().String("output-file", "", "Write the highlight to this file instead of stdout")
+	highlightCmd.Flags().Bool("open", false, "With --output html, open the report in the default browser (written to a temp file without --output-file)")
+	highlightCmd.Flags().Bool("markdown-frontmatter", false, "With --output markdown, prepend YAML front matter (title, date, tags) for Hugo, Jekyll or Zola")
+	_ = viper.BindPFlag("highlight.explain_scoring", highlightCmd.Flags().Lookup("explain-scoring"))
+	_ = viper.BindPFlag("highlight.output_file", highlightCmd.Flags().Lookup("output-file"))
+	_ = viper.BindPFlag("highlight.open", highlightCmd.Flags().Lookup("open"))
+	_ = viper.BindPFlag("highlight.markdown_frontmatter", highlightCmd.Flags().Lookup("markdown-frontmatter"))
+	_ = viper.BindPFlag("journal.file", highlightCmd.Flags().Lookup("journal-file"))
+	_ = viper.BindPFlag("journal.group_by_month", highlightCmd.Flags().Lookup("group-periods-in-journal-by-month"))
 }
 
 func runHighlight(cmd *cobra.Command, args []string) {
@@ -64,9 +110,18 @@ func runHighlight(cmd *cobra.Command, args []string) {
 	days, _ := cmd.Flags().GetInt("days")
 	since, _ := cmd.Flags().GetString("since")
 	period, _ := cmd.Flags().GetString("period")
-	verbose, _ := cmd.Flags().GetBool("verbose")
+	sinceTag, _ := cmd.Flags().GetString("since-tag")
+	log := newLogger(viper.GetInt("verbose"))
 	clearCache, _ := cmd.Flags().GetBool("clear-cache")
 	listCount, _ := cmd.Flags().GetInt("list")
+	outputFormat, _ := cmd.Flags().GetString("output")
+	csvDetail, _ := cmd.Flags().GetBool("csv-detail")
+	byMonth, _ := cmd.Flags().GetBool("by-month")
+	months, _ := cmd.Flags().GetInt("months")
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	journalDetail, _ := cmd.Flags().GetBool("journal-detail")
+	backfill, _ := cmd.Flags().GetBool("backfill")
+	backfillWeeks, _ := cmd.Flags().GetInt("backfill-weeks")
 
 	// Input validation: email format
 	if !strings.Contains(email, "@") {
@@ -86,56 +141,123 @@ func runHighlight(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Input validation: API timeouts and model options
+	if _, _, err := apiTimeouts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := ollamaOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := piiRedactor(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Input validation: output format
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if csvDetail && format != output.FormatCSV {
+		fmt.Fprintf(os.Stderr, "Error: --csv-detail requires --output csv\n")
+		os.Exit(1)
+	}
+	if byMonth && format != output.FormatCSV && format != output.FormatJSON {
+		fmt.Fprintf(os.Stderr, "Error: --by-month requires --output csv or json\n")
+		os.Exit(1)
+	}
+	if byMonth && csvDetail {
+		fmt.Fprintf(os.Stderr, "Error: --by-month and --csv-detail are mutually exclusive\n")
+		os.Exit(1)
+	}
+	if months < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --months must be a non-negative number\n")
+		os.Exit(1)
+	}
+	if months > 0 && (since != "" || period != "") {
+		fmt.Fprintf(os.Stderr, "Error: --months cannot be combined with --since or --period\n")
+		os.Exit(1)
+	}
+	if sinceTag != "" {
+		if _, _, _, err := ghclient.ParseRepoTag(sinceTag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if since != "" || period != "" || months > 0 || backfill {
+			fmt.Fprintf(os.Stderr, "Error: --since-tag cannot be combined with --since, --period, --months or --backfill\n")
+			os.Exit(1)
+		}
+	}
+	if viper.GetBool("preview_prompt") && (byMonth || backfill) {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt cannot be combined with --by-month or --backfill\n")
+		os.Exit(1)
+	}
+	if baselinePath != "" && (byMonth || format == output.FormatCSV || format == output.FormatTabular) {
+		fmt.Fprintf(os.Stderr, "Error: --baseline cannot be combined with --by-month, --output csv or --output table\n")
+		os.Exit(1)
+	}
+	if backfill && (since != "" || period != "" || months > 0 || byMonth || baselinePath != "") {
+		fmt.Fprintf(os.Stderr, "Error: --backfill picks its own weekly ranges and cannot be combined with --since, --period, --months, --by-month or --baseline\n")
+		os.Exit(1)
+	}
+	if viper.GetBool("highlight.open") && format != output.FormatHTML {
+		fmt.Fprintf(os.Stderr, "Error: --open requires --output html\n")
+		os.Exit(1)
+	}
+	if viper.GetBool("highlight.markdown_frontmatter") && (format != output.FormatMarkdown || byMonth || csvDetail) {
+		fmt.Fprintf(os.Stderr, "Error: --markdown-frontmatter requires --output markdown\n")
+		os.Exit(1)
+	}
+	frontMatter, err := highlightFrontMatter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if (viper.GetString("highlight.output_file") != "" || viper.GetBool("highlight.open")) && (byMonth || backfill) {
+		fmt.Fprintf(os.Stderr, "Error: --output-file and --open cannot be combined with --by-month or --backfill\n")
+		os.Exit(1)
+	}
+	if backfillWeeks <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --backfill-weeks must be a positive number\n")
+		os.Exit(1)
+	}
+	var baseline *output.Baseline
+	if baselinePath != "" {
+		if baseline, err = output.LoadBaseline(baselinePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Clear cache if requested
 	if clearCache {
 		cache, err := ghclient.NewCache()
 		if err == nil {
 			if err := cache.Clear(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to clear cache: %v\n", err)
+				log.Printf("Warning: failed to clear cache: %v\n", err)
 			} else {
-				if verbose {
-					fmt.Println("✓ Cache cleared")
-				}
+				log.Infof("✓ Cache cleared\n")
 			}
 		}
 	}
 
 	// Calculate date range based on flags
-	var startDate, endDate time.Time
-
-	if period != "" {
-		// Use named period
-		var err error
-		startDate, endDate, err = dateparse.ParseNamedPeriod(period)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-		if verbose {
-			fmt.Printf("Using period '%s': %s to %s\n", period,
-				dateparse.FormatForDisplay(startDate),
-				dateparse.FormatForDisplay(endDate))
-		}
-	} else if since != "" {
-		// Use --since flag with flexible parsing
-		var err error
-		startDate, err = dateparse.ParseDateOrRelative(since)
+	if months > 0 {
+		since = dateparse.FormatISO(pastMonthsStart(time.Now(), months))
+	}
+	if sinceTag != "" {
+		tagDate, err := sinceTagDate(sinceTag, log)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		endDate = time.Now()
-		if verbose {
-			fmt.Printf("Date range: %s to today\n", dateparse.FormatForDisplay(startDate))
-		}
-	} else {
-		// Use --days flag (default behavior)
-		endDate = time.Now()
-		startDate = endDate.AddDate(0, 0, -days)
+		since = dateparse.FormatISO(tagDate.In(time.Local))
 	}
-
-	// Validate date range
-	if err := dateparse.ValidateDateRange(startDate, endDate); err != nil {
+	startDate, endDate, err := resolveDateRange(days, since, period, log)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -146,14 +268,28 @@ func runHighlight(cmd *cobra.Command, args []string) {
 	// Get configuration values
 	jiraURL := viper.GetString("jira.url")
 	jiraUsername := viper.GetString("jira.username")
-	jiraToken := viper.GetString("jira.token")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	ollamaURL := viper.GetString("ollama.url")
-	githubToken := viper.GetString("github.token")
+	githubToken := resolveGitHubToken()
 	githubUsername := viper.GetString("github.username")
 	gistURL := viper.GetString("github.gist_url")
+	journalPath := viper.GetString("journal.file")
+	if journalPath != "" && gistURL != "" {
+		log.Infof("ℹ Using journal file %s instead of github.gist_url\n", journalPath)
+		gistURL = ""
+	}
 	
-	// Validate required configuration
-	if jiraURL == "" || jiraUsername == "" || jiraToken == "" {
+	// Validate required configuration; offline runs don't talk to Jira
+	offline := viper.GetBool("offline")
+	if offline && gistURL != "" {
+		log.Printf("ℹ Offline: not updating the gist journal %s (use --journal-file for a local journal)\n", gistURL)
+		gistURL = ""
+	}
+	if !offline && (jiraURL == "" || jiraUsername == "" || jiraToken == "") {
 		fmt.Fprintf(os.Stderr, "Error: Jira credentials required. Set via config file or flags.\n")
 		os.Exit(1)
 	}
@@ -163,51 +299,350 @@ func runHighlight(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(os.Stderr, "Error: github.gist_url is configured but github.token is missing. Both are required for journaling.\n")
 		os.Exit(1)
 	}
+	if viper.GetBool("preview_prompt") && ollamaURL == "" {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt requires ollama.url; without it no prompt is built\n")
+		os.Exit(1)
+	}
+	// Catch a malformed gist URL before fetching, not after the summary is generated
+	journal, err := newJournalStore(journalPath, gistURL, githubToken, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if journalDetail && journal == nil {
+		log.Printf("Warning: --journal-detail has no effect without github.gist_url or --journal-file\n")
+	}
+
+	opts := &highlightOptions{
+		email:               email,
+		jiraURL:             jiraURL,
+		jiraUsername:        jiraUsername,
+		jiraToken:           jiraToken,
+		ollamaURL:           ollamaURL,
+		githubToken:         githubToken,
+		githubUsername:      githubUsername,
+		journal:             journal,
+		journalDetail:       journalDetail,
+		groupJournalByMonth: viper.GetBool("journal.group_by_month"),
+		log:                 log,
+		listCount:           listCount,
+		format:              format,
+		csvDetail:           csvDetail,
+		baseline:            baseline,
+		frontMatter:         frontMatter,
+	}
 
-	err := generateHighlight(email, startDateStr, endDateStr, jiraURL, jiraUsername, jiraToken, ollamaURL, githubToken, githubUsername, gistURL, verbose, listCount)
+	if backfill {
+		if journal == nil {
+			fmt.Fprintf(os.Stderr, "Error: --backfill requires github.gist_url or --journal-file\n")
+			os.Exit(1)
+		}
+		err = backfillJournal(opts, backfillWeeks)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if byMonth {
+		// Counts only: no Ollama summary and no journal entry
+		err = generateMonthlyHighlight(opts, startDateStr, endDateStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	err = generateHighlight(opts, startDateStr, endDateStr)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func generateHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraToken, ollamaURL, githubToken, githubUsername, gistURL string, verbose bool, listCount int) error {
+// resolveDateRange computes the highlight date range from the --days, --since and --period flags
+func resolveDateRange(days int, since, period string, log logger.Logger) (startDate, endDate time.Time, err error) {
+	if period != "" {
+		// Use named period, honoring the configured week start
+		weekStart, err := dateparse.ParseWeekStart(viper.GetString("date.week_start"))
+		if err != nil {
+			return startDate, endDate, err
+		}
+		startDate, endDate, err = dateparse.ParseNamedPeriodWithWeekStart(period, weekStart)
+		if err != nil {
+			return startDate, endDate, err
+		}
+		log.Infof("Using period '%s': %s to %s\n", period,
+			dateparse.FormatForDisplay(startDate),
+			dateparse.FormatForDisplay(endDate))
+	} else if since != "" {
+		// Use --since flag with flexible parsing
+		startDate, err = dateparse.ParseDateOrRelative(since)
+		if err != nil {
+			return startDate, endDate, err
+		}
+		endDate = time.Now()
+		log.Infof("Date range: %s to today\n", dateparse.FormatForDisplay(startDate))
+	} else {
+		// Use --days flag (default behavior)
+		endDate = time.Now()
+		startDate = endDate.AddDate(0, 0, -days)
+	}
+
+	// Validate date range
+	if err := dateparse.ValidateDateRange(startDate, endDate); err != nil {
+		return startDate, endDate, err
+	}
+
+	return startDate, endDate, nil
+}
+
+// sinceTagDate resolves --since-tag owner/name@tag to the date of the commit
+// the tag points at
+func sinceTagDate(spec string, log logger.Logger) (time.Time, error) {
+	owner, name, tag, err := ghclient.ParseRepoTag(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	transport, err := httpTransport()
+	if err != nil {
+		return time.Time{}, err
+	}
+	githubTimeout, _, err := apiTimeouts()
+	if err != nil {
+		return time.Time{}, err
+	}
+	client := ghclient.NewClient(githubConfig(resolveGitHubToken(), log, transport, githubTimeout, nil))
+
+	date, err := client.TagDate(owner, name, tag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	log.Infof("Tag %s of %s/%s was committed on %s\n", tag, owner, name, dateparse.FormatForDisplay(date))
+	return date, nil
+}
+
+// parseDateRange parses a start and end date in any format dateparse accepts;
+// an empty end date means today
+func parseDateRange(startDate, endDate string) (start, end time.Time, err error) {
+	start, err = dateparse.ParseDateOrRelative(startDate)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid start date: %w", err)
+	}
+	end, err = dateparse.ParseEndDate(endDate)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid end date: %w", err)
+	}
+	return start, end, nil
+}
+
+// pastMonthsStart returns the first day of the calendar month n-1 months before now
+func pastMonthsStart(now time.Time, n int) time.Time {
+	return time.Date(now.Year(), now.Month()-time.Month(n-1), 1, 0, 0, 0, 0, now.Location())
+}
+
+// generateMonthlyHighlight prints per-month activity counts for the date range
+func generateMonthlyHighlight(opts *highlightOptions, startDate, endDate string) error {
+	// No Ollama URL: the time series only needs counts, not AI summaries
+	data, err := collectHighlight(opts.email, startDate, endDate, opts.jiraURL, opts.jiraUsername, opts.jiraToken, "", opts.githubToken, opts.githubUsername, opts.log, 0)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := output.FormatMonthly(output.MonthlyData{
+		Email:     opts.email,
+		StartDate: data.StartDate,
+		EndDate:   data.EndDate,
+		Months:    metrics.Monthly(data.StartDate, data.EndDate, data.PullRequests, data.Issues),
+	}, opts.format)
+	if err != nil {
+		return fmt.Errorf("failed to format monthly time series: %w", err)
+	}
+
+	fmt.Print(formatted)
+	if !strings.HasSuffix(formatted, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+// highlightOptions holds the settings of a highlight run, resolved from flags
+// and config once by runHighlight and shared by every range it covers, e.g.
+// each --backfill week
+type highlightOptions struct {
+	email               string
+	jiraURL             string
+	jiraUsername        string
+	jiraToken           string
+	ollamaURL           string
+	githubToken         string
+	githubUsername      string
+	journal             journalStore // nil when not journaling
+	journalDetail       bool
+	groupJournalByMonth bool
+	log                 logger.Logger
+	listCount           int
+	format              output.Format
+	csvDetail           bool
+	baseline            *output.Baseline    // nil without --baseline
+	frontMatter         *output.FrontMatter // nil without --markdown-frontmatter
+}
+
+func generateHighlight(opts *highlightOptions, startDate, endDate string) error {
+	log := opts.log
+	data, err := collectHighlight(opts.email, startDate, endDate, opts.jiraURL, opts.jiraUsername, opts.jiraToken, opts.ollamaURL, opts.githubToken, opts.githubUsername, log, opts.listCount)
+	if errors.Is(err, errPromptPreviewed) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	recordRun(metrics.NewRun(opts.email, data.StartDate, data.EndDate, data.PullRequests, data.Issues), log)
+
+	// The journal always gets the text form, including the why
+	journalEntry, err := output.FormatHighlight(data, output.FormatText)
+	if err != nil {
+		return fmt.Errorf("failed to format journal entry: %w", err)
+	}
+	if opts.journalDetail {
+		journalEntry += output.FormatJournalDetail(data)
+	}
+
+	// On the console the why is only part of the text summary when journaling
+	consoleData := data
+	if opts.format == output.FormatText && opts.journal == nil {
+		consoleData.Why = ""
+	}
+	// The baseline comparison and front matter are only for this run's output, not the journal
+	if opts.baseline != nil {
+		diff := opts.baseline.Diff(data)
+		consoleData.SinceBaseline = &diff
+	}
+	consoleData.FrontMatter = opts.frontMatter
+	var formatted string
+	if opts.csvDetail {
+		formatted = output.FormatHighlightCSVDetail(consoleData)
+	} else {
+		formatted, err = output.FormatHighlight(consoleData, opts.format)
+		if err != nil {
+			return fmt.Errorf("failed to format highlight: %w", err)
+		}
+	}
+
+	// Print output to console
+	log.Infof("\n%s\n", strings.Repeat("=", 60))
+	log.Infof("HIGHLIGHT SUMMARY\n")
+	log.Infof("%s\n", strings.Repeat("=", 60))
+	if err := writeHighlightOutput(formatted, opts.format, log); err != nil {
+		return err
+	}
+
+	// Append to the journal if a gist or journal file is configured
+	if opts.journal != nil {
+		log.Infof("\n→ Updating journal %s...\n", opts.journal)
+		changed, err := appendToJournal(opts.journal, startDate, endDate, journalEntry, opts.groupJournalByMonth, log)
+		if err != nil {
+			return fmt.Errorf("failed to update journal: %w", err)
+		}
+		if changed {
+			log.Printf("✓ Journal updated: %s\n\n", opts.journal)
+		} else {
+			log.Printf("✓ Journal unchanged (no changes): %s\n\n", opts.journal)
+		}
+	}
+	
+	return nil
+}
+
+// highlightFrontMatter returns the --markdown-frontmatter front matter, with
+// the title template and tags from highlight.frontmatter_title and
+// highlight.frontmatter_tags, or nil when the flag is off
+func highlightFrontMatter() (*output.FrontMatter, error) {
+	if !viper.GetBool("highlight.markdown_frontmatter") {
+		return nil, nil
+	}
+	return output.NewFrontMatter(viper.GetString("highlight.frontmatter_title"), viper.GetStringSlice("highlight.frontmatter_tags"))
+}
+
+// createdSince reports whether an issue created at the created timestamp was
+// created on or after the start date. The start date is a calendar day, so it
+// begins at midnight in loc rather than UTC midnight, and an issue created at
+// exactly that instant counts. Timestamps that don't parse count as not created.
+func createdSince(created string, start time.Time, loc *time.Location) bool {
+	startOfDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	t, ok := jira.ParseTime(created)
+	return ok && !t.Before(startOfDay)
+}
+
+// errPromptPreviewed ends a --preview-prompt highlight once the prompt is printed
+var errPromptPreviewed = errors.New("prompt previewed")
+
+// collectHighlight fetches Jira and GitHub activity for one user and asks Ollama for their accomplishments
+func collectHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraToken, ollamaURL, githubToken, githubUsername string, log logger.Logger, listCount int) (output.HighlightData, error) {
+	verbose := log.Level() >= constants.VerbosityProgress
+
 	// Calculate days for output
-	start, _ := time.Parse("01-02-2006", startDate)
-	end, _ := time.Parse("01-02-2006", endDate)
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return output.HighlightData{}, err
+	}
 	days := int(end.Sub(start).Hours() / 24)
 	
-	if verbose {
-		fmt.Printf("Generating highlight for %s (%s to %s)\n", email, startDate, endDate)
-		fmt.Printf("Date range: %d days\n\n", days)
-	}
+	log.Infof("Generating highlight for %s (%s to %s)\n", email, startDate, endDate)
+	log.Infof("Date range: %d days\n\n", days)
 	
 	// Create clients
-	if verbose {
-		fmt.Println("→ Creating Jira client...")
+	log.Infof("→ Creating Jira client...\n")
+	jiraRole, err := jira.ParseRole(viper.GetString("jira.role"))
+	if err != nil {
+		return output.HighlightData{}, err
+	}
+	jiraAuth, err := jiraAuthType(jiraURL)
+	if err != nil {
+		return output.HighlightData{}, err
+	}
+	transport, err := httpTransport()
+	if err != nil {
+		return output.HighlightData{}, err
+	}
+	redactor, err := piiRedactor()
+	if err != nil {
+		return output.HighlightData{}, err
 	}
 	jiraClient, err := jira.NewClient(jira.Config{
-		URL:      jiraURL,
-		Username: jiraUsername,
-		Token:    jiraToken,
+		URL:       jiraURL,
+		Username:  jiraUsername,
+		Token:     jiraToken,
+		Logger:    log,
+		Role:      jiraRole,
+		AuthType:  jiraAuth,
+		Transport: transport,
+		Redactor:  redactor,
+		Offline:   viper.GetBool("offline"),
+		NoCache:   viper.GetBool("no_cache"),
+		MaxAge:    viper.GetDuration("cache.max_age"),
+
+		RefreshExpiredOnly: viper.GetBool("cache.refresh_expired_only"),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create Jira client: %w", err)
-	}
-	if verbose {
-		fmt.Printf("  ✓ Connected to %s\n", jiraURL)
+		return output.HighlightData{}, fmt.Errorf("failed to create Jira client: %w", err)
 	}
+	runStats.trackJira(jiraClient)
+	log.Infof("  ✓ Connected to %s\n", jiraURL)
 
-	if verbose {
-		fmt.Println("→ Creating GitHub client...")
+	log.Infof("→ Creating GitHub client...\n")
+	githubTimeout, ollamaTimeout, err := apiTimeouts()
+	if err != nil {
+		return output.HighlightData{}, err
 	}
-	githubClient := ghclient.NewClient(ghclient.Config{Token: githubToken})
-	if verbose {
-		if githubToken != "" {
-			fmt.Println("  ✓ GitHub token configured")
-		} else {
-			fmt.Println("  ℹ No GitHub token (public repo access only)")
-		}
+	githubClient := ghclient.NewClient(githubConfig(githubToken, log, transport, githubTimeout, redactor))
+	runStats.track(githubClient, nil)
+	if githubToken != "" {
+		log.Infof("  ✓ GitHub token configured\n")
+	} else {
+		log.Infof("  ℹ No GitHub token (public repo access only)\n")
 	}
 
 	// Fetch data in parallel
@@ -226,18 +661,14 @@ func generateHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraTok
 	githubChan := make(chan githubResult, 1)
 
 	// Fetch Jira data
-	if verbose {
-		fmt.Printf("\n→ Fetching Jira issues for %s...\n", email)
-	}
+	log.Infof("\n→ Fetching Jira issues %s %s...\n", jiraRole.Description(), email)
 	go func() {
 		issues, err := jiraClient.GetUserIssuesInDateRangeWithContext(email, startDate, endDate, false, false)
 		jiraChan <- jiraResult{issues: issues, err: err}
 	}()
 
 	// Fetch GitHub data
-	if verbose {
-		fmt.Println("→ Fetching GitHub activity...")
-	}
+	log.Infof("→ Fetching GitHub activity...\n")
 	go func() {
 		if githubToken == "" {
 			githubChan <- githubResult{err: fmt.Errorf("no GitHub token provided")}
@@ -251,7 +682,7 @@ func generateHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraTok
 		if githubUsername != "" {
 			username = githubUsername
 		} else {
-			username, err = githubClient.SearchUserByEmail(email)
+			username, err = githubClient.ResolveUsername(email)
 			if err != nil {
 				githubChan <- githubResult{err: err}
 				return
@@ -259,10 +690,8 @@ func generateHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraTok
 		}
 
 		// Convert date format for GitHub API
-		start, _ := time.Parse("01-02-2006", startDate)
-		end, _ := time.Parse("01-02-2006", endDate)
-		startDateFormatted := start.Format("2006-01-02")
-		endDateFormatted := end.Format("2006-01-02")
+		startDateFormatted := dateparse.FormatISO(start)
+		endDateFormatted := dateparse.FormatISO(end)
 
 		activity, err = githubClient.FetchComprehensiveUserActivityWithCache(username, startDateFormatted, endDateFormatted, verbose)
 		githubChan <- githubResult{activity: activity, username: username, err: err}
@@ -270,175 +699,203 @@ func generateHighlight(email, startDate, endDate, jiraURL, jiraUsername, jiraTok
 
 	// Wait for results
 	jiraRes := <-jiraChan
-	if verbose {
-		if jiraRes.err == nil {
-			fmt.Printf("  ✓ Found %d Jira issues\n", len(jiraRes.issues))
-		} else {
-			fmt.Printf("  ✗ Error: %v\n", jiraRes.err)
-		}
+	if jiraRes.err == nil {
+		log.Infof("  ✓ Found %d Jira issues\n", len(jiraRes.issues))
+	} else {
+		log.Infof("  ✗ Error: %v\n", jiraRes.err)
 	}
 	
 	githubRes := <-githubChan
-	if verbose {
-		if githubRes.err == nil && githubRes.activity != nil {
-			fmt.Printf("  ✓ Found GitHub user '%s' with %d PRs, %d issues\n", 
-				githubRes.username, 
-				len(githubRes.activity.PullRequests),
-				len(githubRes.activity.Issues))
-		} else if githubRes.err != nil {
-			fmt.Printf("  ℹ GitHub activity not available: %v\n", githubRes.err)
-		}
+	if githubRes.err == nil && githubRes.activity != nil {
+		log.Infof("  ✓ Found GitHub user '%s' with %d PRs, %d issues\n",
+			githubRes.username,
+			len(githubRes.activity.PullRequests),
+			len(githubRes.activity.Issues))
+	} else if githubRes.err != nil {
+		log.Infof("  ℹ GitHub activity not available: %v\n", githubRes.err)
+	}
+	if githubClient.RateLimitWaitExceeded() {
+		warnPartialGitHubData(log)
 	}
+	if err := githubClient.CircuitOpen(); err != nil {
+		warnGitHubCircuitOpen(log, err)
+	}
+	reportOfflineMisses(log, jiraClient.OfflineMisses(), githubClient.OfflineMisses())
+	reportStaleCache(log, viper.GetDuration("cache.max_age"), jiraClient.StaleCacheEntries(), githubClient.StaleCacheEntries())
 
 	if jiraRes.err != nil {
-		return fmt.Errorf("failed to fetch Jira data: %w", jiraRes.err)
+		return output.HighlightData{}, fmt.Errorf("failed to fetch Jira data: %w", jiraRes.err)
+	}
+
+	// Build highlight data
+	data := output.HighlightData{
+		Email:           email,
+		StartDate:       start,
+		EndDate:         end,
+		Days:            days,
+		JiraURL:         jiraURL,
+		ListCount:       listCount,
+		GitHubAvailable: githubRes.err == nil && githubRes.activity != nil,
+		Issues:          jiraRes.issues,
+	}
+
+	// Say why GitHub stats are missing, so it isn't mistaken for "0 PRs"
+	switch {
+	case data.GitHubAvailable:
+	case githubToken == "":
+		data.GitHubSkipped = "no token configured (set github.token or GITHUB_TOKEN)"
+	case githubRes.err != nil:
+		data.GitHubSkipped = fmt.Sprintf("GitHub activity unavailable: %v", githubRes.err)
 	}
 
-	// Build highlight output
-	var output strings.Builder
-	output.WriteString("\n")
-	
 	// GitHub stats
-	if githubRes.err == nil && githubRes.activity != nil {
+	if data.GitHubAvailable {
 		activity := githubRes.activity
-		
-		// Count PR stats
-		created := len(activity.PullRequests)
-		merged := 0
-		open := 0
-		
+		data.GitHubUsername = githubRes.username
+		data.PullRequests = activity.PullRequests
+		data.PRsCreated = len(activity.PullRequests)
+		data.Commits = len(activity.Commits)
+
 		for _, pr := range activity.PullRequests {
-			switch pr.State {
+			switch pr.Status() {
 			case "open":
-				open++
-			case "closed":
-				merged++
+				data.PRsOpen++
+			case "merged":
+				data.PRsMerged++
+			default:
+				data.PRsClosedUnmerged++
 			}
 		}
-		
-		line := fmt.Sprintf("- Created %d PRs in the last %d days (%d merged, %d open)\n", created, days, merged, open)
-		output.WriteString(line)
 	}
 
-	// Jira stats
-	if len(jiraRes.issues) > 0 {
-		// Count created vs updated
-		created := 0
-		updated := 0
-		
-		startTime, _ := time.Parse("01-02-2006", startDate)
-		
-		for _, issue := range jiraRes.issues {
-			createdTime, err := time.Parse("2006-01-02T15:04:05.999-0700", issue.Created)
-			if err != nil {
-				// Try alternate format
-				createdTime, _ = time.Parse(time.RFC3339, issue.Created)
-			}
-			
-			if createdTime.After(startTime) {
-				created++
-			} else {
-				updated++
-			}
+	// Jira stats: count created vs updated
+	for _, issue := range jiraRes.issues {
+		if createdSince(issue.Created, start, time.Local) {
+			data.JiraCreated++
+		} else {
+			data.JiraUpdated++
+		}
+		if issue.Resolved != "" {
+			data.JiraResolved++
 		}
-		
-		line := fmt.Sprintf("- Created %d Jira stories and updated Jira %d times\n", created, updated)
-		output.WriteString(line)
-	} else {
-		output.WriteString("- Created 0 Jira stories and updated Jira 0 times\n")
 	}
 
 	// AI-generated accomplishment(s)
-	if ollamaURL != "" {
+	if ollamaURL == "" {
+		data.AISkipped = "no Ollama URL configured (set ollama.url)"
+	} else {
 		model := viper.GetString("ollama.model")
 		if model == "" {
 			model = "llama3.2:latest"
 		}
-		if verbose {
-			fmt.Printf("\n→ Generating AI summary using Ollama...\n")
-			fmt.Printf("  Model: %s\n", model)
-			fmt.Printf("  Endpoint: %s\n", ollamaURL)
+		log.Infof("\n→ Generating AI summary using Ollama...\n")
+		log.Infof("  Model: %s\n", model)
+		if hosts := ollamaHosts(); len(hosts) > 0 {
+			log.Infof("  Endpoints: %s\n", strings.Join(hosts, ", "))
+		} else {
+			log.Infof("  Endpoint: %s\n", ollamaURL)
+		}
+		modelOptions, err := ollamaOptions()
+		if err != nil {
+			return output.HighlightData{}, err
 		}
-		ollamaClient := ollama.NewClient(ollama.Config{URL: ollamaURL})
-		
+		ollamaClient := ollama.NewClient(ollama.Config{URL: ollamaURL, URLs: ollamaHosts(), Logger: log, Transport: transport, Timeout: ollamaTimeout, Options: modelOptions, Redactor: redactor})
+		if viper.GetBool("preview_prompt") {
+			var prompt ollama.Prompt
+			if listCount > 0 {
+				prompt = ollamaClient.SimplePrompt(fmt.Sprintf("top %d accomplishments", listCount), accomplishmentsListPrompt(jiraRes.issues, githubRes.activity, listCount, accomplishmentCategories()))
+			} else {
+				prompt = ollamaClient.SimplePrompt("biggest accomplishment", accomplishmentPrompt(jiraRes.issues, githubRes.activity, viper.GetBool("highlight.explain_scoring")))
+			}
+			ollama.WritePrompts(os.Stdout, []ollama.Prompt{prompt})
+			return data, errPromptPreviewed
+		}
+		runStats.track(nil, ollamaClient)
+
 		if listCount > 0 {
 			// Generate list of top N accomplishments
-			accomplishments, err := generateAccomplishmentsList(ollamaClient, jiraRes.issues, githubRes.activity, email, verbose, model, listCount)
+			categories := accomplishmentCategories()
+			accomplishments, err := generateAccomplishmentsList(ollamaClient, jiraRes.issues, githubRes.activity, email, verbose, model, listCount, categories)
 			if err == nil {
-				if verbose {
-					fmt.Printf("  ✓ AI summary generated (top %d accomplishments)\n", listCount)
-				}
-				fmt.Fprintf(&output, "- Top %d accomplishments:\n", listCount)
-				for i, acc := range accomplishments {
-					fmt.Fprintf(&output, "  %d. %s\n", i+1, acc)
-				}
+				log.Infof("  ✓ AI summary generated (top %d accomplishments)\n", listCount)
+				data.Accomplishments = accomplishments
+				data.AccomplishmentCategories = categories
 			} else {
-				if verbose {
-					fmt.Printf("  ✗ Failed to generate AI summary: %v\n", err)
-				}
-				fmt.Fprintf(&output, "- Top %d accomplishments: (Unable to generate: %v)\n", listCount, err)
+				log.Infof("  ✗ Failed to generate AI summary: %v\n", err)
+				data.AccomplishmentError = err.Error()
 			}
 		} else {
 			// Generate single biggest accomplishment
-			accomplishment, why, err := generateAccomplishmentSummary(ollamaClient, jiraRes.issues, githubRes.activity, email, verbose, model)
+			explainScoring := viper.GetBool("highlight.explain_scoring")
+			accomplishment, why, candidates, err := generateAccomplishmentSummary(ollamaClient, jiraRes.issues, githubRes.activity, email, verbose, model, explainScoring)
 			if err == nil {
-				if verbose {
-					fmt.Println("  ✓ AI summary generated")
-					if why != "" {
-						fmt.Printf("\n  💡 Why this is the biggest accomplishment:\n")
-						fmt.Printf("     %s\n", why)
+				log.Infof("  ✓ AI summary generated\n")
+				if explainScoring {
+					if len(candidates) > 0 {
+						log.Infof("\n  🏆 Candidates considered:\n")
+						for i, candidate := range candidates {
+							log.Infof("     %d. %s\n", i+1, candidate)
+						}
+					} else {
+						log.Infof("  (the model did not return a candidate ranking)\n")
 					}
 				}
-				line := fmt.Sprintf("- Biggest accomplishment: %s\n", accomplishment)
-				output.WriteString(line)
-				
-				// Add the why to journal entries (when gist_url is configured)
-				if gistURL != "" && why != "" {
-					fmt.Fprintf(&output, "  - Why: %s\n", why)
+				if why != "" {
+					log.Infof("\n  💡 Why this is the biggest accomplishment:\n")
+					log.Infof("     %s\n", why)
 				}
+				data.BiggestAccomplishment = accomplishment
+				data.Why = why
 			} else {
-				if verbose {
-					fmt.Printf("  ✗ Failed to generate AI summary: %v\n", err)
-				}
-				line := fmt.Sprintf("- Biggest accomplishment: (Unable to generate: %v)\n", err)
-				output.WriteString(line)
+				log.Infof("  ✗ Failed to generate AI summary: %v\n", err)
+				data.AccomplishmentError = err.Error()
 			}
 		}
 	}
-	
-	output.WriteString("\n")
-	
-	// Print output to console
-	if verbose {
-		fmt.Println("\n" + strings.Repeat("=", 60))
-		fmt.Println("HIGHLIGHT SUMMARY")
-		fmt.Println(strings.Repeat("=", 60))
+
+	runStats.fetched(len(data.Issues), len(data.PullRequests))
+	return data, nil
+}
+
+// generateAccomplishmentSummary asks the model for the biggest accomplishment
+// and why it matters. With explainScoring the model first ranks its top 3
+// candidates, which are returned with their one-line justifications.
+func generateAccomplishmentSummary(client *ollama.Client, issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, email string, verbose bool, model string, explainScoring bool) (string, string, []string, error) {
+	// Use the exported CallOllama method for simple prompts
+	response, err := client.CallOllama(model, accomplishmentPrompt(issues, activity, explainScoring))
+	if err != nil {
+		return "", "", nil, err
 	}
-	fmt.Print(output.String())
 	
-	// Append to journal if gist_url is configured
-	if gistURL != "" && githubToken != "" {
-		if verbose {
-			fmt.Printf("\n→ Updating GitHub Gist journal...\n")
-		}
-		err := appendToJournal(githubClient, gistURL, startDate, endDate, output.String(), verbose)
-		if err != nil {
-			return fmt.Errorf("failed to update journal: %w", err)
-		}
-		fmt.Printf("✓ Journal updated: %s\n\n", gistURL)
+	// Parse out the accomplishment and why
+	accomplishment, why := parseAccomplishmentResponse(response)
+	if !explainScoring {
+		return accomplishment, why, nil, nil
 	}
-	
-	return nil
+	return accomplishment, why, parseCandidates(response), nil
 }
 
-func generateAccomplishmentSummary(client *ollama.Client, issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, email string, verbose bool, model string) (string, string, error) {
+// accomplishmentPrompt builds the prompt asking for the biggest accomplishment
+func accomplishmentPrompt(issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, explainScoring bool) string {
 	var prompt string
 	
 	// Always ask for the why, but only display it in verbose mode or journal
 	prompt = "You are analyzing work activity for a Red Hat engineer to identify the biggest accomplishment.\n\n"
-	prompt += "Step 1: Review the work below and identify the single most significant accomplishment.\n"
-	prompt += "Step 2: Explain why THAT EXACT accomplishment matters for Red Hat, its partners, customers, and the open source community. Your explanation must directly reference and explain the specific work you identified.\n\n"
+	if explainScoring {
+		prompt += "Step 1: Review the work below and rank the 3 strongest candidates for the biggest accomplishment, each with a one-line justification of its rank.\n"
+		prompt += "Step 2: Select the top-ranked candidate as the single most significant accomplishment.\n"
+		prompt += "Step 3: Explain why THAT EXACT accomplishment matters for Red Hat, its partners, customers, and the open source community. Your explanation must directly reference and explain the specific work you identified.\n\n"
+	} else {
+		prompt += "Step 1: Review the work below and identify the single most significant accomplishment.\n"
+		prompt += "Step 2: Explain why THAT EXACT accomplishment matters for Red Hat, its partners, customers, and the open source community. Your explanation must directly reference and explain the specific work you identified.\n\n"
+	}
 	prompt += "Format your response EXACTLY as:\n"
+	if explainScoring {
+		prompt += "CANDIDATES:\n"
+		prompt += "1. [candidate] - [one-line justification]\n"
+		prompt += "2. [candidate] - [one-line justification]\n"
+		prompt += "3. [candidate] - [one-line justification]\n"
+	}
 	prompt += "ACCOMPLISHMENT: [one concise sentence, max 15 words]\n"
 	prompt += "WHY: [Reference the exact accomplishment] is significant because [explain its specific impact]. Consider the impact on: Red Hat's business objectives, partner integrations, customer deployments, and/or open source community contributions. [Add 1-2 more sentences about the concrete benefits].\n\n"
 	prompt += "Example of GOOD format:\n"
@@ -465,31 +922,45 @@ func generateAccomplishmentSummary(client *ollama.Client, issues []jira.Issue, a
 			if i >= 5 {
 				break // Limit to top 5
 			}
-			prompt += fmt.Sprintf("- PR: %s [%s]\n", pr.Title, pr.State)
+			prompt += fmt.Sprintf("- PR: %s [%s]\n", pr.Title, pr.Status())
 		}
 		prompt += "\n"
 	}
-	
-	// Use the exported CallOllama method for simple prompts
-	response, err := client.CallOllama(model, prompt)
+	if activity != nil && len(activity.PullRequests) == 0 && len(activity.Commits) > 0 {
+		prompt += "GITHUB WORK (commits):\n"
+		for i, commit := range activity.Commits {
+			if i >= 5 {
+				break // Limit to top 5
+			}
+			prompt += fmt.Sprintf("- Commit: %s (%s)\n", commit.Subject(), commit.Repository.FullName)
+		}
+		prompt += "\n"
+	}
+
+	return prompt
+}
+
+// generateAccomplishmentsList asks the model for the top count
+// accomplishments, each tagged with one of categories
+func generateAccomplishmentsList(client *ollama.Client, issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, email string, verbose bool, model string, count int, categories []string) ([]output.Accomplishment, error) {
+	// JSON mode keeps the text and category of each accomplishment apart
+	response, err := client.CallOllamaJSON(model, accomplishmentsListPrompt(issues, activity, count, categories))
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	
-	// Parse out the accomplishment and why
-	accomplishment, why := parseAccomplishmentResponse(response)
-	return accomplishment, why, nil
+	return parseCategorizedAccomplishments(response, count, categories), nil
 }
 
-func generateAccomplishmentsList(client *ollama.Client, issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, email string, verbose bool, model string, count int) ([]string, error) {
+// accomplishmentsListPrompt builds the prompt asking for the top count
+// accomplishments as JSON, each tagged with one of categories
+func accomplishmentsListPrompt(issues []jira.Issue, activity *ghclient.ComprehensiveUserActivity, count int, categories []string) string {
 	var prompt string
 	
 	prompt = fmt.Sprintf("You are analyzing work activity for a Red Hat engineer to identify the top %d accomplishments.\n\n", count)
-	prompt += fmt.Sprintf("Review the work below and list the %d most significant accomplishments in priority order (most important first).\n\n", count)
-	prompt += "Format your response as a numbered list with concise descriptions (max 15 words each):\n"
-	prompt += "1. [first accomplishment]\n"
-	prompt += "2. [second accomplishment]\n"
-	prompt += fmt.Sprintf("%d. [last accomplishment]\n\n", count)
+	prompt += fmt.Sprintf("Review the work below and list the %d most significant accomplishments in priority order (most important first).\n", count)
+	prompt += fmt.Sprintf("Tag each accomplishment with exactly one of these categories: %s.\n\n", strings.Join(categories, ", "))
+	prompt += "Respond with JSON only, in this form, with concise descriptions (max 15 words each):\n"
+	prompt += fmt.Sprintf(`{"accomplishments": [{"text": "[first accomplishment]", "category": "%s"}, {"text": "[second accomplishment]", "category": "%s"}]}`+"\n\n", categories[0], categories[len(categories)-1])
 	prompt += "Focus on technical achievements, feature implementations, bug fixes, and contributions that have measurable impact.\n\n"
 	
 	// Add Jira context
@@ -511,20 +982,58 @@ func generateAccomplishmentsList(client *ollama.Client, issues []jira.Issue, act
 			if i >= 10 {
 				break // Limit to top 10
 			}
-			prompt += fmt.Sprintf("- PR: %s [%s]\n", pr.Title, pr.State)
+			prompt += fmt.Sprintf("- PR: %s [%s]\n", pr.Title, pr.Status())
 		}
 		prompt += "\n"
 	}
-	
-	// Use the exported CallOllama method
-	response, err := client.CallOllama(model, prompt)
-	if err != nil {
-		return nil, err
+	if activity != nil && len(activity.PullRequests) == 0 && len(activity.Commits) > 0 {
+		prompt += "GITHUB WORK (commits):\n"
+		for i, commit := range activity.Commits {
+			if i >= 10 {
+				break // Limit to top 10
+			}
+			prompt += fmt.Sprintf("- Commit: %s (%s)\n", commit.Subject(), commit.Repository.FullName)
+		}
+		prompt += "\n"
 	}
-	
-	// Parse the numbered list
-	accomplishments := parseAccomplishmentsList(response, count)
-	return accomplishments, nil
+
+	return prompt
+}
+
+// parseCategorizedAccomplishments returns up to expectedCount accomplishments
+// from the model's JSON response, with each category matched to categories.
+// A response that isn't the requested JSON (or a bare array of it) is read
+// as a numbered list instead, leaving the accomplishments untagged.
+func parseCategorizedAccomplishments(response string, expectedCount int, categories []string) []output.Accomplishment {
+	var parsed struct {
+		Accomplishments []output.Accomplishment `json:"accomplishments"`
+	}
+	response = strings.TrimSpace(response)
+	if err := json.Unmarshal([]byte(response), &parsed); err != nil {
+		_ = json.Unmarshal([]byte(response), &parsed.Accomplishments)
+	}
+
+	var accomplishments []output.Accomplishment
+	for _, acc := range parsed.Accomplishments {
+		if text := strings.TrimSpace(acc.Text); text != "" {
+			accomplishments = append(accomplishments, output.Accomplishment{Text: text, Category: output.MatchCategory(acc.Category, categories)})
+		}
+	}
+	if len(accomplishments) == 0 {
+		for _, text := range parseAccomplishmentsList(response, expectedCount) {
+			accomplishments = append(accomplishments, output.Accomplishment{Text: text})
+		}
+	}
+	return accomplishments[:min(expectedCount, len(accomplishments))]
+}
+
+// accomplishmentCategories returns the categories accomplishments are tagged
+// with: highlight.accomplishment_categories, or the default set
+func accomplishmentCategories() []string {
+	if categories := viper.GetStringSlice("highlight.accomplishment_categories"); len(categories) > 0 {
+		return categories
+	}
+	return output.DefaultAccomplishmentCategories
 }
 
 func parseAccomplishmentsList(response string, expectedCount int) []string {
@@ -537,26 +1046,8 @@ func parseAccomplishmentsList(response string, expectedCount int) []string {
 			continue
 		}
 		
-		// Look for numbered lines: "1. ", "2. ", etc.
-		// Also handle variations like "1) " or "1 - "
-		if len(line) > 3 {
-			// Check if line starts with a number followed by period, paren, or dash
-			if (line[0] >= '0' && line[0] <= '9') {
-				if line[1] == '.' || line[1] == ')' || (line[1] == ' ' && line[2] == '-') {
-					// Extract the accomplishment text after the number
-					text := ""
-					switch line[1] {
-					case '.', ')':
-						text = strings.TrimSpace(line[2:])
-					case ' ':
-						text = strings.TrimSpace(line[3:])
-					}
-					
-					if text != "" {
-						accomplishments = append(accomplishments, text)
-					}
-				}
-			}
+		if text, ok := numberedItem(line); ok {
+			accomplishments = append(accomplishments, text)
 		}
 	}
 	
@@ -583,6 +1074,51 @@ func parseAccomplishmentsList(response string, expectedCount int) []string {
 	return accomplishments
 }
 
+// numberedItem returns the text of a numbered list line such as "1. ",
+// "1) " or "1 - ", and whether the line is one
+func numberedItem(line string) (string, bool) {
+	if len(line) <= 3 || line[0] < '0' || line[0] > '9' {
+		return "", false
+	}
+	text := ""
+	switch {
+	case line[1] == '.' || line[1] == ')':
+		text = strings.TrimSpace(line[2:])
+	case line[1] == ' ' && line[2] == '-':
+		text = strings.TrimSpace(line[3:])
+	}
+	return text, text != ""
+}
+
+// parseCandidates returns the numbered lines of the CANDIDATES: section of an
+// --explain-scoring response, which may come before or after the
+// ACCOMPLISHMENT: and WHY: lines
+func parseCandidates(response string) []string {
+	var candidates []string
+	inSection := false
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case isCandidatesHeader(line):
+			inSection = true
+		case strings.HasPrefix(line, "ACCOMPLISHMENT:"), strings.HasPrefix(line, "WHY:"):
+			inSection = false
+		case inSection:
+			if text, ok := numberedItem(line); ok {
+				candidates = append(candidates, text)
+			}
+		}
+	}
+	return candidates
+}
+
+// isCandidatesHeader reports whether line starts the CANDIDATES: section,
+// allowing for markdown bold
+func isCandidatesHeader(line string) bool {
+	line = strings.TrimLeft(strings.TrimSpace(line), "*")
+	return strings.HasPrefix(strings.ToUpper(line), "CANDIDATES:")
+}
+
 func parseAccomplishmentResponse(response string) (accomplishment string, why string) {
 	lines := strings.Split(response, "\n")
 	
@@ -599,6 +1135,10 @@ func parseAccomplishmentResponse(response string) (accomplishment string, why st
 			whyLines := []string{strings.TrimSpace(strings.TrimPrefix(line, "WHY:"))}
 			// Gather remaining lines as part of the explanation
 			for j := i + 1; j < len(lines); j++ {
+				// A candidate ranking after the WHY is not part of it
+				if isCandidatesHeader(lines[j]) {
+					break
+				}
 				if strings.TrimSpace(lines[j]) != "" {
 					whyLines = append(whyLines, strings.TrimSpace(lines[j]))
 				}
@@ -608,128 +1148,15 @@ func parseAccomplishmentResponse(response string) (accomplishment string, why st
 		}
 	}
 	
-	// Fallback if parsing fails
+	// Fallback if parsing fails: the top-ranked candidate, if any, else
+	// the whole response
 	if accomplishment == "" {
-		accomplishment = strings.TrimSpace(response)
-	}
-	
-	return accomplishment, why
-}
-
-// removeExistingEntry removes an existing journal entry for a given date header
-func removeExistingEntry(content, dateHeader string) string {
-	// Find the start of the entry
-	startIdx := strings.Index(content, dateHeader)
-	if startIdx == -1 {
-		return content // Entry not found, return unchanged
-	}
-	
-	// Find the next entry (look for next "## " or end of content)
-	// We need to find where this entry ends
-	endIdx := len(content)
-	
-	// Look for the next date header after this one
-	nextHeaderIdx := strings.Index(content[startIdx+len(dateHeader):], "\n## ")
-	if nextHeaderIdx != -1 {
-		// Found next entry, calculate actual position
-		endIdx = startIdx + len(dateHeader) + nextHeaderIdx + 1 // +1 to include the newline
-	}
-	
-	// Remove the entry (from start to end, including the separator)
-	// Also remove trailing "---" separator if present
-	section := content[startIdx:endIdx]
-	if strings.Contains(section, "\n---\n") {
-		// Find and include the separator in the removal
-		separatorIdx := strings.Index(content[startIdx:endIdx], "\n---\n")
-		if separatorIdx != -1 {
-			endIdx = startIdx + separatorIdx + 5 // +5 for "\n---\n"
-		}
-	}
-	
-	// Reconstruct content without the old entry
-	return content[:startIdx] + content[endIdx:]
-}
-
-func appendToJournal(client *ghclient.Client, gistURL, startDate, endDate, content string, verbose bool) error {
-	// Extract gist ID from URL
-	gistID, err := ghclient.ExtractGistIDFromURL(gistURL)
-	if err != nil {
-		return fmt.Errorf("invalid gist URL: %w", err)
-	}
-	
-	if verbose {
-		fmt.Printf("  → Fetching gist %s...\n", gistID)
-	}
-
-	// Fetch existing gist
-	gist, err := client.GetGist(gistID)
-	if err != nil {
-		return fmt.Errorf("failed to fetch gist: %w", err)
-	}
-	
-	if verbose {
-		fmt.Printf("  ✓ Gist found with %d file(s)\n", len(gist.Files))
-	}
-
-	// Find the journal file (or use the first file if there's only one)
-	var filename string
-	var existingContent string
-	
-	if len(gist.Files) == 0 {
-		return fmt.Errorf("gist has no files")
-	}
-	
-	// Use first file, or look for one named "journal" or similar
-	for name, file := range gist.Files {
-		filename = name
-		existingContent = file.Content
-		if strings.Contains(strings.ToLower(name), "journal") {
-			break // Prefer files with "journal" in the name
+		if candidates := parseCandidates(response); len(candidates) > 0 {
+			accomplishment = candidates[0]
+		} else {
+			accomplishment = strings.TrimSpace(response)
 		}
 	}
-
-	// Create date header
-	start, _ := time.Parse("01-02-2006", startDate)
-	end, _ := time.Parse("01-02-2006", endDate)
-	dateHeader := fmt.Sprintf("## %s to %s\n", start.Format("January 2, 2006"), end.Format("January 2, 2006"))
 	
-	// Check if entry for this date range already exists and remove it
-	if strings.Contains(existingContent, dateHeader) {
-		if verbose {
-			fmt.Println("  ℹ Entry for this date range already exists, replacing with updated version...")
-		}
-		existingContent = removeExistingEntry(existingContent, dateHeader)
-	} else {
-		if verbose {
-			fmt.Printf("  → Appending new entry to '%s'...\n", filename)
-		}
-	}
-
-	// Prepare new content (prepend so newest entries are at the top)
-	var newContent strings.Builder
-	newContent.WriteString(dateHeader)
-	newContent.WriteString(content)
-	newContent.WriteString("\n---\n\n")
-	newContent.WriteString(existingContent)
-
-	// Update gist
-	update := ghclient.GistUpdate{
-		Files: map[string]ghclient.GistFile{
-			filename: {
-				Content: newContent.String(),
-			},
-		},
-	}
-
-	_, err = client.UpdateGist(gistID, update)
-	if err != nil {
-		return fmt.Errorf("failed to update gist: %w", err)
-	}
-	
-	if verbose {
-		fmt.Println("  ✓ Gist updated successfully")
-	}
-
-	return nil
+	return accomplishment, why
 }
-
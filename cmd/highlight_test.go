@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+func TestParseAccomplishmentResponseWithCandidates(t *testing.T) {
+	tests := []struct {
+		name               string
+		response           string
+		wantAccomplishment string
+		wantWhy            string
+		wantCandidates     []string
+	}{
+		{
+			name:               "ranking first",
+			response:           "CANDIDATES:\n1. Shipped OAuth - unblocks partners\n2) Fixed CI - saves time\n3 - Docs - helps users\n\nACCOMPLISHMENT: Shipped OAuth\nWHY: It unblocks partners.",
+			wantAccomplishment: "Shipped OAuth",
+			wantWhy:            "It unblocks partners.",
+			wantCandidates:     []string{"Shipped OAuth - unblocks partners", "Fixed CI - saves time", "Docs - helps users"},
+		},
+		{
+			name:               "ranking after why",
+			response:           "ACCOMPLISHMENT: Shipped OAuth\nWHY: It unblocks partners.\n**CANDIDATES:**\n1. Shipped OAuth - unblocks partners",
+			wantAccomplishment: "Shipped OAuth",
+			wantWhy:            "It unblocks partners.",
+			wantCandidates:     []string{"Shipped OAuth - unblocks partners"},
+		},
+		{
+			name:               "missing accomplishment falls back to top candidate",
+			response:           "CANDIDATES:\n1. Shipped OAuth - unblocks partners\n2. Fixed CI - saves time",
+			wantAccomplishment: "Shipped OAuth - unblocks partners",
+			wantCandidates:     []string{"Shipped OAuth - unblocks partners", "Fixed CI - saves time"},
+		},
+		{
+			name:               "no ranking",
+			response:           "ACCOMPLISHMENT: Shipped OAuth\nWHY: It unblocks partners.",
+			wantAccomplishment: "Shipped OAuth",
+			wantWhy:            "It unblocks partners.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accomplishment, why := parseAccomplishmentResponse(tt.response)
+			if accomplishment != tt.wantAccomplishment || why != tt.wantWhy {
+				t.Errorf("parseAccomplishmentResponse() = %q, %q; want %q, %q", accomplishment, why, tt.wantAccomplishment, tt.wantWhy)
+			}
+			if got := parseCandidates(tt.response); strings.Join(got, "|") != strings.Join(tt.wantCandidates, "|") {
+				t.Errorf("parseCandidates() = %q, want %q", got, tt.wantCandidates)
+			}
+		})
+	}
+}
+
+func TestParseCategorizedAccomplishments(t *testing.T) {
+	categories := []string{"Feature", "Bug Fix"}
+	tests := []struct {
+		name     string
+		response string
+		want     []output.Accomplishment
+	}{
+		{
+			name:     "json object",
+			response: `{"accomplishments": [{"text": "Shipped OAuth", "category": "feature"}, {"text": "Fixed the leak", "category": "Bug Fix"}, {"text": "Extra", "category": "Feature"}]}`,
+			want:     []output.Accomplishment{{Text: "Shipped OAuth", Category: "Feature"}, {Text: "Fixed the leak", Category: "Bug Fix"}},
+		},
+		{
+			name:     "bare array with an unknown category",
+			response: `[{"text": "Tuned the cache", "category": "Performance"}]`,
+			want:     []output.Accomplishment{{Text: "Tuned the cache", Category: "Other"}},
+		},
+		{
+			name:     "numbered list fallback",
+			response: "1. Shipped OAuth\n2. Fixed the leak\n",
+			want:     []output.Accomplishment{{Text: "Shipped OAuth"}, {Text: "Fixed the leak"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCategorizedAccomplishments(tt.response, 2, categories)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseCategorizedAccomplishments() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("accomplishment %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCreatedSince(t *testing.T) {
+	// The range starts on January 6, 2025, parsed from "01-06-2025" as UTC midnight
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		created string
+		loc     *time.Location
+		want    bool
+	}{
+		{name: "exactly at the boundary", created: "2025-01-06T00:00:00.000+0000", loc: time.UTC, want: true},
+		{name: "RFC 3339 at the boundary", created: "2025-01-06T00:00:00Z", loc: time.UTC, want: true},
+		{name: "just before the boundary", created: "2025-01-05T23:59:59.999+0000", loc: time.UTC},
+		{name: "local midnight in another offset", created: "2025-01-06T00:00:00-05:00", loc: newYork, want: true},
+		{name: "evening before, local time", created: "2025-01-05T21:00:00.000-0500", loc: newYork},
+		{name: "after UTC midnight but before local midnight", created: "2025-01-06T03:00:00Z", loc: newYork},
+		{name: "unparseable", created: "yesterday", loc: time.UTC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createdSince(tt.created, start, tt.loc); got != tt.want {
+				t.Errorf("createdSince(%q) = %v, want %v", tt.created, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+// journalStore holds the journal document that highlight entries are
+// prepended to: a GitHub Gist or a local markdown file
+type journalStore interface {
+	// read returns the journal's current content
+	read() (string, error)
+	// write replaces the journal's content
+	write(content string) error
+	// String names the journal in progress messages
+	String() string
+}
+
+// errJournalChanged is returned by a journal's write when the journal was
+// changed by someone else since it was read
+var errJournalChanged = errors.New("journal was changed by another update since it was read")
+
+// journalWriteAttempts is how many times appendToJournal reads the journal and
+// applies the entry when the journal keeps changing before its write
+const journalWriteAttempts = 3
+
+// gistJournal keeps the journal in a file of a GitHub Gist
+type gistJournal struct {
+	client   *ghclient.Client
+	url      string
+	gistID   string
+	filename string // Set by read; the file write updates
+	revision string // Set by read; write fails if the gist has moved on
+	log      logger.Logger
+}
+
+func (g *gistJournal) read() (string, error) {
+	g.log.Infof("  → Fetching gist %s...\n", g.gistID)
+	gist, err := g.client.GetGist(g.gistID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch gist: %w", err)
+	}
+	g.log.Infof("  ✓ Gist found with %d file(s)\n", len(gist.Files))
+
+	filename, content, err := journalFile(gist)
+	if err != nil {
+		return "", err
+	}
+	g.filename = filename
+	g.revision = gist.Revision()
+	return content, nil
+}
+
+// write updates the gist, unless it changed since read: the Gist API has no
+// conditional update, so the revision is checked just before the update,
+// which narrows the window for a concurrent run's entry to be overwritten
+func (g *gistJournal) write(content string) error {
+	if g.filename == "" {
+		return fmt.Errorf("gist journal must be read before it is written")
+	}
+	current, err := g.client.GetGist(g.gistID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch gist: %w", err)
+	}
+	if current.Revision() != g.revision {
+		return errJournalChanged
+	}
+	update := ghclient.GistUpdate{
+		Files: map[string]ghclient.GistFile{
+			g.filename: {Content: content},
+		},
+	}
+	if _, err := g.client.UpdateGist(g.gistID, update); err != nil {
+		return fmt.Errorf("failed to update gist: %w", err)
+	}
+	g.log.Infof("  ✓ Gist updated successfully\n")
+	return nil
+}
+
+func (g *gistJournal) String() string { return g.url }
+
+// fileJournal keeps the journal in a local markdown file, which is created on
+// the first entry
+type fileJournal struct {
+	path string
+}
+
+func (f *fileJournal) read() (string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read journal file: %w", err)
+	}
+	return string(data), nil
+}
+
+func (f *fileJournal) write(content string) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write journal file: %w", err)
+	}
+	return nil
+}
+
+func (f *fileJournal) String() string { return f.path }
+
+// newJournalStore returns the configured journal: the local file at
+// journalPath (--journal-file) if set, else the gist at gistURL, else nil
+func newJournalStore(journalPath, gistURL, githubToken string, log logger.Logger) (journalStore, error) {
+	if journalPath != "" {
+		path, err := expandHome(journalPath)
+		if err != nil {
+			return nil, err
+		}
+		return &fileJournal{path: path}, nil
+	}
+	if gistURL == "" {
+		return nil, nil
+	}
+
+	gistID, err := ghclient.ExtractGistIDFromURL(gistURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gist URL: %w", err)
+	}
+	transport, err := httpTransport()
+	if err != nil {
+		return nil, err
+	}
+	githubTimeout, _, err := apiTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	client := ghclient.NewClient(githubConfig(githubToken, log, transport, githubTimeout, nil))
+	return &gistJournal{client: client, url: gistURL, gistID: gistID, log: log}, nil
+}
+
+// expandHome replaces a leading ~/ with the user's home directory, for paths
+// given in the config file where the shell doesn't expand them
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// removeExistingEntry removes an existing journal entry for a given date header
+func removeExistingEntry(content, dateHeader string) string {
+	// Find the start of the entry
+	startIdx := strings.Index(content, dateHeader)
+	if startIdx == -1 {
+		return content // Entry not found, return unchanged
+	}
+
+	// Find the next entry (look for next "## " or end of content)
+	// We need to find where this entry ends
+	endIdx := len(content)
+
+	// Look for the next date header after this one, or the next month
+	// heading when entries are grouped by month
+	nextHeaderIdx := nextHeading(content[startIdx+len(dateHeader):])
+	if nextHeaderIdx != -1 {
+		// Found next entry, calculate actual position
+		endIdx = startIdx + len(dateHeader) + nextHeaderIdx + 1 // +1 to include the newline
+	}
+
+	// Remove the entry (from start to end, including the separator)
+	// Also remove trailing "---" separator if present
+	section := content[startIdx:endIdx]
+	if strings.Contains(section, "\n---\n") {
+		// Find and include the separator in the removal
+		separatorIdx := strings.Index(content[startIdx:endIdx], "\n---\n")
+		if separatorIdx != -1 {
+			endIdx = startIdx + separatorIdx + 5 // +5 for "\n---\n"
+		}
+	}
+
+	// Reconstruct content without the old entry
+	return content[:startIdx] + content[endIdx:]
+}
+
+// nextHeading returns the index of the newline before the first "## " entry
+// header or "# " month heading in s, or -1 if there is neither
+func nextHeading(s string) int {
+	next := -1
+	for _, prefix := range []string{"\n## ", "\n# "} {
+		if idx := strings.Index(s, prefix); idx != -1 && (next == -1 || idx < next) {
+			next = idx
+		}
+	}
+	return next
+}
+
+// journalHash returns the hash of a journal entry's content, stored in the
+// entry as an HTML comment so an unchanged entry can be detected on later runs
+func journalHash(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))[:16]
+}
+
+// journalDateLayout is the date format of journal entry headers
+const journalDateLayout = "January 2, 2006"
+
+// journalMonthLayout is the date format of the month headings entries are
+// grouped under with --group-periods-in-journal-by-month
+const journalMonthLayout = "January 2006"
+
+// journalMonthPattern matches a "# <Month> <Year>" month heading
+var journalMonthPattern = regexp.MustCompile(`(?m)^# ([A-Z][a-z]+ \d{4})$`)
+
+// insertUnderMonth inserts the entry for the period starting at start under
+// the heading of start's month, above the month's entries for earlier
+// periods. A missing heading is added above the first older month so months
+// stay newest first, at the top of a journal without month headings, and
+// otherwise at the end.
+func insertUnderMonth(content, entry string, start time.Time) string {
+	month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	heading := "# " + month.Format(journalMonthLayout) + "\n\n"
+	headings := journalMonthPattern.FindAllStringSubmatchIndex(content, -1)
+	for i, loc := range headings {
+		existing, err := time.Parse(journalMonthLayout, content[loc[2]:loc[3]])
+		if err != nil {
+			continue
+		}
+		if existing.Before(month) {
+			return content[:loc[0]] + heading + entry + content[loc[0]:]
+		}
+		if !existing.Equal(month) {
+			continue
+		}
+
+		sectionEnd := len(content)
+		if i+1 < len(headings) {
+			sectionEnd = headings[i+1][0]
+		}
+		at := loc[1]
+		for at < sectionEnd && content[at] == '\n' {
+			at++
+		}
+		for _, ranges := range journalHeaderPattern.FindAllStringSubmatchIndex(content[at:sectionEnd], -1) {
+			entryStart, err := time.Parse(journalDateLayout, content[at+ranges[2]:at+ranges[3]])
+			if err == nil && entryStart.Before(start) {
+				return content[:at+ranges[0]] + entry + content[at+ranges[0]:]
+			}
+		}
+		return content[:sectionEnd] + entry + content[sectionEnd:]
+	}
+	if len(headings) == 0 {
+		return heading + entry + content
+	}
+	if !strings.HasSuffix(content, "\n\n") {
+		content = strings.TrimRight(content, "\n") + "\n\n"
+	}
+	return content + heading + entry
+}
+
+// journalFile returns the name and content of the gist's journal file: the
+// first by name with "journal" in it, or else the first file by name (the
+// only one, typically), so the same file is chosen on every run
+func journalFile(gist *ghclient.Gist) (string, string, error) {
+	if len(gist.Files) == 0 {
+		return "", "", fmt.Errorf("gist has no files")
+	}
+	names := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	filename := names[0]
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), "journal") {
+			filename = name // Prefer files with "journal" in the name
+			break
+		}
+	}
+	return filename, gist.Files[filename].Content, nil
+}
+
+// existingEntryHash returns the hash stored in the entry under dateHeader, or
+// "" if there is no such entry or it predates hashing
+func existingEntryHash(content, dateHeader string) string {
+	idx := strings.Index(content, dateHeader)
+	if idx == -1 {
+		return ""
+	}
+	line, _, _ := strings.Cut(content[idx+len(dateHeader):], "\n")
+	hash, ok := strings.CutPrefix(line, "<!-- hash:")
+	if !ok {
+		return ""
+	}
+	hash, ok = strings.CutSuffix(hash, " -->")
+	if !ok {
+		return ""
+	}
+	return hash
+}
+
+// appendToJournal prepends the entry to the journal, replacing any entry for
+// the same date range. With byMonth the entry goes under the "# <Month>
+// <Year>" heading of its start date instead of at the top. It reports whether
+// the journal was changed: an existing entry with the same content hash is
+// left alone, keeping a gist's revision history (or a git-tracked file) free
+// of no-op updates. If the journal changes between its read and write, e.g.
+// a cron run and a manual run updating the same gist, the entry is applied
+// again on top of the new content.
+func appendToJournal(journal journalStore, startDate, endDate, content string, byMonth bool, log logger.Logger) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		changed, err := applyJournalEntry(journal, startDate, endDate, content, byMonth, log)
+		if !errors.Is(err, errJournalChanged) {
+			return changed, err
+		}
+		if attempt == journalWriteAttempts {
+			return false, fmt.Errorf("%w (gave up after %d attempts)", err, journalWriteAttempts)
+		}
+		log.Infof("  ℹ Journal %s changed since it was read, applying the entry to the new content...\n", journal)
+	}
+}
+
+// applyJournalEntry reads the journal, adds the entry and writes it back once
+func applyJournalEntry(journal journalStore, startDate, endDate, content string, byMonth bool, log logger.Logger) (bool, error) {
+	existingContent, err := journal.read()
+	if err != nil {
+		return false, err
+	}
+
+	// Create date header
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return false, err
+	}
+	dateHeader := fmt.Sprintf("## %s to %s\n", start.Format(journalDateLayout), end.Format(journalDateLayout))
+
+	hash := journalHash(content)
+	if existingEntryHash(existingContent, dateHeader) == hash {
+		log.Infof("  ✓ Entry for this date range is unchanged, skipping journal update\n")
+		return false, nil
+	}
+
+	// Check if entry for this date range already exists and remove it
+	if strings.Contains(existingContent, dateHeader) {
+		log.Infof("  ℹ Entry for this date range already exists, replacing with updated version...\n")
+		existingContent = removeExistingEntry(existingContent, dateHeader)
+	} else {
+		log.Infof("  → Appending new entry to '%s'...\n", journal)
+	}
+
+	// Prepare new content (prepend so newest entries are at the top)
+	var entry strings.Builder
+	entry.WriteString(dateHeader)
+	fmt.Fprintf(&entry, "<!-- hash:%s -->\n", hash)
+	entry.WriteString(content)
+	entry.WriteString("\n---\n\n")
+
+	newContent := entry.String() + existingContent
+	if byMonth {
+		newContent = insertUnderMonth(existingContent, entry.String(), start)
+	}
+
+	if err := journal.write(newContent); err != nil {
+		return false, err
+	}
+	return true, nil
+}
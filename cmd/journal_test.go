@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestAppendToJournalSkipsUnchangedEntry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	content := "## December 1, 2024 to December 7, 2024\n- Older entry\n\n---\n\n"
+	var updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			updates++
+			var update ghclient.GistUpdate
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("decoding gist update: %v", err)
+			}
+			content = update.Files["journal.md"].Content
+		}
+		_ = json.NewEncoder(w).Encode(ghclient.Gist{ID: "abc123", Files: map[string]ghclient.GistFile{"journal.md": {Content: content}}})
+	}))
+	defer server.Close()
+	client := ghclient.NewClient(ghclient.Config{Token: "test-token", Logger: logger.Nop(), BaseURL: server.URL})
+	journal := &gistJournal{client: client, url: "abc123", gistID: "abc123", log: logger.Nop()}
+
+	write := func(entry string) bool {
+		t.Helper()
+		changed, err := appendToJournal(journal, "01-06-2025", "01-12-2025", entry, false, logger.Nop())
+		if err != nil {
+			t.Fatalf("appendToJournal() error = %v", err)
+		}
+		return changed
+	}
+
+	if !write("- Created 3 PRs\n") || updates != 1 {
+		t.Fatalf("new entry: updates = %d, want 1", updates)
+	}
+	if !strings.HasPrefix(content, "## January 6, 2025 to January 12, 2025\n<!-- hash:") || !strings.Contains(content, "- Older entry") {
+		t.Errorf("journal = %q, want the new hashed entry above the older one", content)
+	}
+
+	if write("- Created 3 PRs\n") || updates != 1 {
+		t.Errorf("unchanged entry: updates = %d, want the gist left alone", updates)
+	}
+
+	if !write("- Created 4 PRs\n") || updates != 2 {
+		t.Errorf("changed entry: updates = %d, want 2", updates)
+	}
+	if strings.Count(content, "## January 6, 2025") != 1 || !strings.Contains(content, "4 PRs") {
+		t.Errorf("journal = %q, want the entry replaced once", content)
+	}
+}
+
+func TestAppendToJournalRetriesConcurrentUpdate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	content := "## December 1, 2024 to December 7, 2024\n- Older entry\n\n---\n\n"
+	revision := "v1"
+	var gets, updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			// Another run adds its entry between the first read and the write
+			if gets == 2 {
+				content = "## December 8, 2024 to December 14, 2024\n- Concurrent entry\n\n---\n\n" + content
+				revision = "v2"
+			}
+		case http.MethodPatch:
+			updates++
+			var update ghclient.GistUpdate
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("decoding gist update: %v", err)
+			}
+			content = update.Files["journal.md"].Content
+			revision = "v3"
+		}
+		_ = json.NewEncoder(w).Encode(ghclient.Gist{
+			ID:      "abc123",
+			Files:   map[string]ghclient.GistFile{"journal.md": {Content: content}},
+			History: []ghclient.GistRevision{{Version: revision}},
+		})
+	}))
+	defer server.Close()
+	client := ghclient.NewClient(ghclient.Config{Token: "test-token", Logger: logger.Nop(), BaseURL: server.URL})
+	journal := &gistJournal{client: client, url: "abc123", gistID: "abc123", log: logger.Nop()}
+
+	changed, err := appendToJournal(journal, "01-06-2025", "01-12-2025", "- Created 3 PRs\n", false, logger.Nop())
+	if err != nil || !changed {
+		t.Fatalf("appendToJournal() = %v, %v, want changed", changed, err)
+	}
+	if updates != 1 {
+		t.Errorf("updates = %d, want 1 (the stale write skipped)", updates)
+	}
+	for _, entry := range []string{"- Created 3 PRs", "- Concurrent entry", "- Older entry"} {
+		if !strings.Contains(content, entry) {
+			t.Errorf("journal = %q, want it to keep %q", content, entry)
+		}
+	}
+}
+
+func TestFileJournal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	store, err := newJournalStore("~/notes/journal.md", "https://gist.github.com/user/abc123", "", logger.Nop())
+	if err != nil {
+		t.Fatalf("newJournalStore() error = %v", err)
+	}
+	path := filepath.Join(home, "notes", "journal.md")
+	if store.String() != path {
+		t.Fatalf("journal = %s, want the local file %s", store, path)
+	}
+
+	for _, entry := range []string{"- Created 3 PRs\n", "- Created 3 PRs\n", "- Created 4 PRs\n"} {
+		if _, err := appendToJournal(store, "01-06-2025", "01-12-2025", entry, false, logger.Nop()); err != nil {
+			t.Fatalf("appendToJournal() error = %v", err)
+		}
+	}
+	if _, err := appendToJournal(store, "01-13-2025", "01-19-2025", "- Created 1 PR\n", false, logger.Nop()); err != nil {
+		t.Fatalf("appendToJournal() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading journal: %v", err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "## January 13, 2025 to January 19, 2025\n") {
+		t.Errorf("journal = %q, want the newest entry first", content)
+	}
+	if strings.Count(content, "## January 6, 2025") != 1 || !strings.Contains(content, "4 PRs") || strings.Contains(content, "3 PRs") {
+		t.Errorf("journal = %q, want the January 6 entry replaced once", content)
+	}
+}
+
+func TestAppendToJournalGroupsByMonth(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "journal.md")
+	store := &fileJournal{path: path}
+
+	appends := []struct{ start, end, entry string }{
+		{start: "01-06-2025", end: "01-12-2025", entry: "- January week 2\n"},
+		{start: "02-03-2025", end: "02-09-2025", entry: "- February week 1\n"},
+		{start: "01-13-2025", end: "01-19-2025", entry: "- January week 3\n"},
+		{start: "01-06-2025", end: "01-12-2025", entry: "- January week 2, updated\n"},
+		{start: "12-02-2024", end: "12-08-2024", entry: "- December week 1\n"},
+	}
+	for _, a := range appends {
+		if _, err := appendToJournal(store, a.start, a.end, a.entry, true, logger.Nop()); err != nil {
+			t.Fatalf("appendToJournal(%s) error = %v", a.start, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading journal: %v", err)
+	}
+	content := string(data)
+	order := []string{
+		"# February 2025\n\n## February 3, 2025",
+		"# January 2025\n\n## January 13, 2025",
+		"## January 6, 2025",
+		"# December 2024\n\n## December 2, 2024",
+	}
+	last := -1
+	for _, want := range order {
+		idx := strings.Index(content, want)
+		if idx <= last {
+			t.Fatalf("journal = %q, want %q after the previous heading", content, want)
+		}
+		last = idx
+	}
+	if strings.Count(content, "# January 2025\n") != 1 || strings.Count(content, "## January 6, 2025") != 1 || !strings.Contains(content, "January week 2, updated") {
+		t.Errorf("journal = %q, want the January 6 entry replaced once under one January heading", content)
+	}
+}
+
+func TestRemoveExistingEntryStopsAtMonthHeading(t *testing.T) {
+	content := "# January 2025\n\n## January 6, 2025 to January 12, 2025\n- Shipped\n" +
+		"# December 2024\n\n## December 2, 2024 to December 8, 2024\n- Older\n\n---\n\n"
+	got := removeExistingEntry(content, "## January 6, 2025 to January 12, 2025\n")
+	want := "# January 2025\n\n# December 2024\n\n## December 2, 2024 to December 8, 2024\n- Older\n\n---\n\n"
+	if got != want {
+		t.Errorf("removeExistingEntry() = %q, want %q", got, want)
+	}
+}
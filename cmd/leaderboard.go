@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+var leaderboardCmd = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "Rank a team by a composite activity score",
+	Long: `Rank every engineer listed in a team file by a composite activity score:
+
+  score = w_merged×PRs merged + w_opened×PRs opened + w_jira×Jira issues resolved + w_review×PRs reviewed
+
+Default weights are 3 (merged), 1 (opened), 2 (Jira resolved) and 1 (reviews);
+override them with the --weight-* flags or leaderboard.weights.* config keys.
+"PRs opened" counts every PR created in the period, so a merged PR scores
+both. Reviews count other people's PRs the engineer reviewed.
+
+This is a rough activity proxy based on countable events, not a performance
+judgment. No AI summaries are generated.
+
+Runs are checkpointed like 'perfdive team'; use --resume after a failure.
+
+Example:
+  perfdive leaderboard --team team.txt --period last-month
+  perfdive leaderboard --team team.txt --days 30 --output markdown
+  perfdive leaderboard --team team.txt --weight-review 2 --output html`,
+	Args: cobra.NoArgs,
+	Run:  runLeaderboard,
+}
+
+func init() {
+	rootCmd.AddCommand(leaderboardCmd)
+
+	leaderboardCmd.Flags().String("team", "", "File with one email per line (required)")
+	leaderboardCmd.Flags().IntP("days", "d", 7, "Number of days to look back (default 7)")
+	leaderboardCmd.Flags().String("since", "", "Start date (supports MM-DD-YYYY, YYYY-MM-DD, or relative like 'last monday', '2 weeks ago')")
+	leaderboardCmd.Flags().String("period", "", "Named period (this-week, last-month, this-quarter, q4-2024, 2025-W03, etc.)")
+	leaderboardCmd.Flags().StringP("output", "f", "text", "Output format (text, markdown, html)")
+	leaderboardCmd.Flags().Bool("resume", false, "Resume a previous run, skipping members that already completed")
+	leaderboardCmd.Flags().Float64("weight-pr-merged", output.DefaultLeaderboardWeights.PRMerged, "Score weight per merged PR")
+	leaderboardCmd.Flags().Float64("weight-pr-opened", output.DefaultLeaderboardWeights.PROpened, "Score weight per opened PR")
+	leaderboardCmd.Flags().Float64("weight-jira-resolved", output.DefaultLeaderboardWeights.JiraResolved, "Score weight per resolved Jira issue")
+	leaderboardCmd.Flags().Float64("weight-review", output.DefaultLeaderboardWeights.Review, "Score weight per PR reviewed")
+	_ = leaderboardCmd.MarkFlagRequired("team")
+
+	_ = viper.BindPFlag("leaderboard.weights.pr_merged", leaderboardCmd.Flags().Lookup("weight-pr-merged"))
+	_ = viper.BindPFlag("leaderboard.weights.pr_opened", leaderboardCmd.Flags().Lookup("weight-pr-opened"))
+	_ = viper.BindPFlag("leaderboard.weights.jira_resolved", leaderboardCmd.Flags().Lookup("weight-jira-resolved"))
+	_ = viper.BindPFlag("leaderboard.weights.review", leaderboardCmd.Flags().Lookup("weight-review"))
+}
+
+func runLeaderboard(cmd *cobra.Command, args []string) {
+	teamFile, _ := cmd.Flags().GetString("team")
+	days, _ := cmd.Flags().GetInt("days")
+	since, _ := cmd.Flags().GetString("since")
+	period, _ := cmd.Flags().GetString("period")
+	outputFormat, _ := cmd.Flags().GetString("output")
+	resume, _ := cmd.Flags().GetBool("resume")
+	log := newLogger(viper.GetInt("verbose"))
+
+	// Input validation
+	if days <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --days must be a positive number\n")
+		os.Exit(1)
+	}
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if format != output.FormatText && format != output.FormatMarkdown && format != output.FormatHTML {
+		fmt.Fprintf(os.Stderr, "Error: leaderboard output must be text, markdown, or html\n")
+		os.Exit(1)
+	}
+	if viper.GetBool("preview_prompt") {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt is not supported for leaderboard: it ranks counts and sends no prompts\n")
+		os.Exit(1)
+	}
+	weights := output.LeaderboardWeights{
+		PRMerged:     viper.GetFloat64("leaderboard.weights.pr_merged"),
+		PROpened:     viper.GetFloat64("leaderboard.weights.pr_opened"),
+		JiraResolved: viper.GetFloat64("leaderboard.weights.jira_resolved"),
+		Review:       viper.GetFloat64("leaderboard.weights.review"),
+	}
+	if weights.PRMerged < 0 || weights.PROpened < 0 || weights.JiraResolved < 0 || weights.Review < 0 {
+		fmt.Fprintf(os.Stderr, "Error: leaderboard weights must be non-negative\n")
+		os.Exit(1)
+	}
+	if _, _, err := apiTimeouts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	emails, err := readEmailsFile(teamFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	startDate, endDate, err := resolveDateRange(days, since, period, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	startDateStr := dateparse.FormatForAPI(startDate)
+	endDateStr := dateparse.FormatForAPI(endDate)
+
+	// Get configuration values
+	jiraURL := viper.GetString("jira.url")
+	jiraUsername := viper.GetString("jira.username")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	githubToken := resolveGitHubToken()
+
+	if jiraURL == "" || jiraUsername == "" || jiraToken == "" {
+		fmt.Fprintf(os.Stderr, "Error: Jira credentials required. Set via config file or flags.\n")
+		os.Exit(1)
+	}
+
+	transport, err := httpTransport()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	githubTimeout, _, _ := apiTimeouts()
+	redactor, err := piiRedactor()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// One client counts every member's reviews
+	githubClient := ghclient.NewClient(githubConfig(githubToken, log, transport, githubTimeout, redactor))
+	runStats.track(githubClient, nil)
+
+	state, failed, err := runMembers("leaderboard", emails, startDateStr, endDateStr, 0, resume, log,
+		func(email string) (output.HighlightData, error) {
+			// A rate limit wait declined for an earlier member is asked again,
+			// as collectHighlight's per-member clients do
+			githubClient.ResetRateLimitWait()
+			// No Ollama URL: the leaderboard only needs counts, not AI summaries
+			data, err := collectHighlight(email, startDateStr, endDateStr, jiraURL, jiraUsername, jiraToken, "", githubToken, "", log, 0)
+			if err != nil {
+				return data, err
+			}
+			if data.GitHubUsername != "" {
+				reviews, err := githubClient.CountReviewedPullRequests(data.GitHubUsername, dateparse.FormatISO(startDate), dateparse.FormatISO(endDate))
+				if err != nil {
+					return data, fmt.Errorf("failed to count reviews: %w", err)
+				}
+				data.Reviews = reviews
+			}
+			return data, nil
+		})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var entries []output.LeaderboardEntry
+	for _, email := range emails {
+		data, ok := state.Results[email]
+		if !ok {
+			continue
+		}
+		entries = append(entries, output.LeaderboardEntry{
+			Email:        email,
+			PRsOpened:    data.PRsCreated,
+			PRsMerged:    data.PRsMerged,
+			JiraResolved: data.JiraResolved,
+			Reviews:      data.Reviews,
+		})
+	}
+
+	formatted, err := output.FormatLeaderboard(output.LeaderboardData{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Weights:   weights,
+		Entries:   output.RankLeaderboard(entries, weights),
+	}, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(formatted)
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d members failed and are missing from the leaderboard; re-run with --resume to retry only those (state: %s)\n",
+			failed, len(emails), state.Path())
+		os.Exit(1)
+	}
+
+	if err := state.Remove(); err != nil {
+		log.Printf("Warning: failed to remove run state: %v\n", err)
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+// limitIssues keeps the max most recently updated issues (max <= 0 means no limit)
+func limitIssues(issues []jira.Issue, max int) []jira.Issue {
+	if max <= 0 || len(issues) <= max {
+		return issues
+	}
+
+	sorted := make([]jira.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := jira.ParseTime(sorted[i].Updated)
+		b, _ := jira.ParseTime(sorted[j].Updated)
+		return a.After(b)
+	})
+	return sorted[:max]
+}
+
+// limitPullRequests keeps the max most recently updated pull requests (max <= 0 means no limit)
+func limitPullRequests(prs []ghclient.UserPullRequest, max int) []ghclient.UserPullRequest {
+	if max <= 0 || len(prs) <= max {
+		return prs
+	}
+
+	sorted := make([]ghclient.UserPullRequest, len(prs))
+	copy(sorted, prs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := jira.ParseTime(sorted[i].UpdatedAt)
+		b, _ := jira.ParseTime(sorted[j].UpdatedAt)
+		return a.After(b)
+	})
+	return sorted[:max]
+}
+
+// summaryCoverage measures the coverage of a summary's data, including the
+// --max-issues, --max-prs and --max-references caps: keptIssues of
+// totalIssues issues and the kept of totalPRs authored PRs in ctx. The
+// referenced PRs only count those fetched: PRs beyond --max-references and
+// those --shipped-only dropped (unshipped) are left out.
+func summaryCoverage(ctx *ghclient.GitHubContext, keptIssues, totalIssues, totalPRs, maxReferences int, unshipped *ghclient.Unshipped) output.Coverage {
+	coverage := output.NewCoverage(ctx)
+	coverage.Issues = output.CoverageCap{Kept: keptIssues, Found: totalIssues}
+	if ctx == nil {
+		return coverage
+	}
+	// References are fetched PRs first, so the cap keeps the first PRs
+	if maxReferences > 0 {
+		coverage.PRsReferenced = min(coverage.PRsReferenced, maxReferences)
+	}
+	coverage.PRsReferenced = max(coverage.PRsReferenced-unshipped.ReferencedPRs, 0)
+	if ctx.ComprehensiveActivity != nil {
+		coverage.PRs = output.CoverageCap{Kept: len(ctx.ComprehensiveActivity.PullRequests), Found: totalPRs}
+	}
+	references := len(ctx.References)
+	coverage.References = output.CoverageCap{Kept: references, Found: references}
+	if maxReferences > 0 {
+		coverage.References.Kept = min(references, maxReferences)
+	}
+	return coverage
+}
+
+// shippedExclusions describes what --shipped-only left out, e.g. "2 Jira
+// issues not Done/Closed", "3 open PRs"; nothing dropped gives nil
+func shippedExclusions(jiraIssues int, unshipped *ghclient.Unshipped) []string {
+	openPRs, unmergedPRs := unshipped.PRs()
+	var excluded []string
+	for _, count := range []struct {
+		n                int
+		singular, plural string
+	}{
+		{n: jiraIssues, singular: "Jira issue not Done/Closed", plural: "Jira issues not Done/Closed"},
+		{n: openPRs, singular: "open PR", plural: "open PRs"},
+		{n: unmergedPRs, singular: "closed-unmerged PR", plural: "closed-unmerged PRs"},
+		{n: unshipped.OpenIssues, singular: "open GitHub issue", plural: "open GitHub issues"},
+	} {
+		switch {
+		case count.n == 1:
+			excluded = append(excluded, "1 "+count.singular)
+		case count.n > 1:
+			excluded = append(excluded, fmt.Sprintf("%d %s", count.n, count.plural))
+		}
+	}
+	return excluded
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+// reportOfflineMisses lists what an --offline run couldn't serve from the
+// cache and left out, so a thin summary isn't mistaken for a quiet period
+func reportOfflineMisses(log logger.Logger, misses ...[]string) {
+	var all []string
+	for _, m := range misses {
+		all = append(all, m...)
+	}
+	if len(all) == 0 {
+		return
+	}
+	log.Printf("⚠ Offline: %d items were not in the cache and were omitted:\n", len(all))
+	for _, miss := range all {
+		log.Printf("  - %s\n", miss)
+	}
+	log.Printf("  Run without --offline (or 'perfdive cache warm') to fetch them\n")
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+)
+
+var periodsCmd = &cobra.Command{
+	Use:   "periods",
+	Short: "List the named periods accepted by --period",
+	Long: `List every named period accepted by --period with the dates it resolves
+to today, in chronological order. Weeks honor --week-start (config:
+date.week_start); ISO weeks always run Monday to Sunday.
+
+Example:
+  perfdive periods
+  perfdive periods --week-start sunday`,
+	Args: cobra.NoArgs,
+	Run:  runPeriods,
+}
+
+func init() {
+	rootCmd.AddCommand(periodsCmd)
+}
+
+func runPeriods(cmd *cobra.Command, args []string) {
+	weekStart, err := dateparse.ParseWeekStart(viper.GetString("date.week_start"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	printPeriods(os.Stdout, time.Now(), weekStart)
+}
+
+// printPeriods writes the named periods as of now as an aligned table
+func printPeriods(w io.Writer, now time.Time, weekStart time.Weekday) {
+	periods := dateparse.GetNamedPeriodsAt(now, weekStart)
+	keys := dateparse.SortedPeriodKeys(periods)
+
+	width := len("PERIOD")
+	for _, key := range keys {
+		width = max(width, len(key))
+	}
+
+	fmt.Fprintf(w, "Named periods as of %s (weeks start on %s):\n\n", dateparse.FormatForDisplay(now), weekStart)
+	fmt.Fprintf(w, "  %-*s  %-10s  %-10s  %s\n", width, "PERIOD", "START", "END", "NAME")
+	for _, key := range keys {
+		period := periods[key]
+		fmt.Fprintf(w, "  %-*s  %s  %s  %s\n", width, key, dateparse.FormatISO(period.StartDate), dateparse.FormatISO(period.EndDate), period.Name)
+	}
+	isoYear, isoWeek := now.ISOWeek()
+	fmt.Fprintf(w, "\nAny ISO week can also be given as YYYY-Www (e.g. %d-W%02d).\n", isoYear, isoWeek)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+// preflightCheck verifies one integration a run will use
+type preflightCheck struct {
+	name string
+	run  func() error
+}
+
+// runPreflight runs every check before any real work and reports all the
+// failures together, so several misconfigurations surface in one run instead
+// of one at a time after slow fetches
+func runPreflight(checks []preflightCheck, log logger.Logger) error {
+	var failures []string
+	for _, check := range checks {
+		log.Printf("Checking %s...\n", check.name)
+		if err := check.run(); err != nil {
+			log.Printf("✗ %s: %v\n", check.name, err)
+			failures = append(failures, fmt.Sprintf("  - %s: %v", check.name, err))
+			continue
+		}
+		log.Printf("✓ %s OK\n", check.name)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("preflight checks failed; fix the following and re-run:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestRunPreflightReportsEveryFailure(t *testing.T) {
+	var ran []string
+	check := func(name string, err error) preflightCheck {
+		return preflightCheck{name: name, run: func() error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+
+	err := runPreflight([]preflightCheck{
+		check("Jira connection", errors.New("401 Unauthorized")),
+		check("Ollama connection", nil),
+		check("GitHub token", errors.New("token rejected")),
+	}, logger.Nop())
+	if err == nil {
+		t.Fatal("runPreflight() = nil, want the failed checks")
+	}
+	if len(ran) != 3 {
+		t.Errorf("ran %v, want every check to run", ran)
+	}
+	for _, want := range []string{"- Jira connection: 401 Unauthorized", "- GitHub token: token rejected"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "Ollama") {
+		t.Errorf("error %q lists a passing check", err)
+	}
+
+	if err := runPreflight([]preflightCheck{check("Jira connection", nil)}, logger.Nop()); err != nil {
+		t.Errorf("runPreflight() with passing checks = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+// confirmRateLimitWait asks an interactive user whether to wait out a GitHub
+// rate limit reset beyond --max-wait. Non-interactive runs never wait.
+func confirmRateLimitWait(wait time.Duration) bool {
+	if viper.GetBool("quiet") || !isInteractive() {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, color.Symbols("⚠ GitHub rate limit resets in %v. Wait, or continue with partial data? [w/C] "), wait.Round(time.Minute))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "w")
+}
+
+// isInteractive reports whether stdin and stderr are both terminals
+func isInteractive() bool {
+	return color.IsTerminal(os.Stdin) && color.IsTerminal(os.Stderr)
+}
+
+// warnPartialGitHubData tells the user GitHub data is incomplete after a
+// rate limit wait was skipped
+func warnPartialGitHubData(log logger.Logger) {
+	log.Printf("⚠ GitHub rate limit reset is beyond --max-wait; GitHub data is partial. Re-run after the reset, or use --github-token for higher limits\n")
+}
+
+// warnGitHubCircuitOpen tells the user GitHub data is incomplete after
+// repeated failures stopped further GitHub requests
+func warnGitHubCircuitOpen(log logger.Logger, err error) {
+	log.Printf("⚠ GitHub requests stopped after %v; GitHub data is partial\n", err)
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo [owner/name] [period]",
+	Short: "Summarize one repository's activity across all contributors",
+	Long: `Summarize what happened in a GitHub repository over a period: every PR
+merged and issue closed, grouped by author, with an AI summary when
+ollama.url is configured. Jira is not used.
+
+The period is a named period as accepted by 'highlight --period'; without
+one, the last 7 days (or --days N) are used.
+
+Example:
+  perfdive repo redhat-best-practices-for-k8s/certsuite last-month
+  perfdive repo redhat-best-practices-for-k8s/certsuite --days 14
+  perfdive repo redhat-best-practices-for-k8s/certsuite q4-2024 --output markdown`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runRepo,
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+
+	repoCmd.Flags().IntP("days", "d", 7, "Number of days to look back when no period is given")
+	repoCmd.Flags().String("since", "", "Start date (supports MM-DD-YYYY, YYYY-MM-DD, or relative like 'last monday', '2 weeks ago')")
+	repoCmd.Flags().StringP("output", "f", "text", "Output format (text, json, markdown, csv)")
+}
+
+func runRepo(cmd *cobra.Command, args []string) {
+	var period string
+	if len(args) == 2 {
+		period = args[1]
+	}
+	days, _ := cmd.Flags().GetInt("days")
+	since, _ := cmd.Flags().GetString("since")
+	outputFormat, _ := cmd.Flags().GetString("output")
+	log := newLogger(viper.GetInt("verbose"))
+
+	// Input validation
+	owner, name, err := ghclient.ParseRepo(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if days <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --days must be a positive number\n")
+		os.Exit(1)
+	}
+	if since != "" && period != "" {
+		fmt.Fprintf(os.Stderr, "Error: --since cannot be combined with a period\n")
+		os.Exit(1)
+	}
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if format == output.FormatHTML {
+		fmt.Fprintf(os.Stderr, "Error: repo output must be text, json, markdown, or csv\n")
+		os.Exit(1)
+	}
+	githubTimeout, ollamaTimeout, err := apiTimeouts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	modelOptions, err := ollamaOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	redactor, err := piiRedactor()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	startDate, endDate, err := resolveDateRange(days, since, period, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	transport, err := httpTransport()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	githubClient := ghclient.NewClient(githubConfig(resolveGitHubToken(), log, transport, githubTimeout, redactor))
+	runStats.track(githubClient, nil)
+
+	log.Infof("→ Fetching merged PRs and closed issues in %s/%s...\n", owner, name)
+	activity, err := githubClient.FetchRepositoryActivity(owner, name, dateparse.FormatISO(startDate), dateparse.FormatISO(endDate))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	log.Infof("  ✓ Found %d merged PRs, %d closed issues\n", len(activity.PullRequests), len(activity.Issues))
+	if githubClient.RateLimitWaitExceeded() {
+		warnPartialGitHubData(log)
+	}
+	if err := githubClient.CircuitOpen(); err != nil {
+		warnGitHubCircuitOpen(log, err)
+	}
+	reportStaleCache(log, viper.GetDuration("cache.max_age"), githubClient.StaleCacheEntries())
+
+	data := output.RepoData{
+		Repo:         activity.Repo,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		PullRequests: activity.PullRequests,
+		Issues:       activity.Issues,
+		Contributors: activity.Contributors(),
+	}
+
+	// AI-generated repository summary
+	if ollamaURL := viper.GetString("ollama.url"); ollamaURL != "" && (len(activity.PullRequests) > 0 || len(activity.Issues) > 0) {
+		model := viper.GetString("ollama.model")
+		if model == "" {
+			model = "llama3.2:latest"
+		}
+		ollamaClient := ollama.NewClient(ollama.Config{URL: ollamaURL, URLs: ollamaHosts(), Logger: log, Transport: transport, Timeout: ollamaTimeout, Options: modelOptions, Redactor: redactor})
+		if viper.GetBool("preview_prompt") {
+			ollama.WritePrompts(os.Stdout, []ollama.Prompt{ollamaClient.SimplePrompt("repository summary", repoSummaryPrompt(activity))})
+			return
+		}
+		log.Infof("\n→ Generating AI summary using Ollama...\n")
+		summary, err := ollamaClient.CallOllama(model, repoSummaryPrompt(activity))
+		if err == nil {
+			log.Infof("  ✓ AI summary generated\n")
+			data.Summary = strings.TrimSpace(summary)
+		} else {
+			log.Infof("  ✗ Failed to generate AI summary: %v\n", err)
+			data.SummaryError = err.Error()
+		}
+	}
+
+	formatted, err := output.FormatRepo(data, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(formatted)
+	if !strings.HasSuffix(formatted, "\n") {
+		fmt.Println()
+	}
+}
+
+// repoSummaryPrompt asks for a short repository-level summary of the merged
+// PRs and closed issues
+func repoSummaryPrompt(activity *ghclient.RepositoryActivity) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "You are summarizing recent activity in the GitHub repository %s for a team lead.\n\n", activity.Repo)
+	sb.WriteString("Write 3-5 sentences describing the main themes of the work: features, fixes, and maintenance. ")
+	sb.WriteString("Mention notable contributors where relevant. Do not list every PR.\n\n")
+
+	if len(activity.PullRequests) > 0 {
+		sb.WriteString("MERGED PULL REQUESTS:\n")
+		for i, pr := range activity.PullRequests {
+			if i >= 30 {
+				fmt.Fprintf(&sb, "- ... and %d more\n", len(activity.PullRequests)-i)
+				break
+			}
+			fmt.Fprintf(&sb, "- #%d %s (@%s)\n", pr.Number, pr.Title, pr.User.Login)
+		}
+		sb.WriteString("\n")
+	}
+	if len(activity.Issues) > 0 {
+		sb.WriteString("CLOSED ISSUES:\n")
+		for i, issue := range activity.Issues {
+			if i >= 15 {
+				fmt.Fprintf(&sb, "- ... and %d more\n", len(activity.Issues)-i)
+				break
+			}
+			fmt.Fprintf(&sb, "- #%d %s\n", issue.Number, issue.Title)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,13 +14,28 @@ import (
 
 	"github.com/sebrandon1/jiracrawler/lib"
 
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
 	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/httpclient"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/progress"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/redact"
 )
 
-var cfgFile string
+var (
+	cfgFile       string
+	verbosityFlag int
+	version       = "dev"
+
+	// ollamaURLChanged records an explicit --ollama-url, which overrides ollama.urls
+	ollamaURLChanged bool
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -34,20 +52,105 @@ Model defaults to llama3.2:latest if not specified.
 Supported date formats:
   - MM-DD-YYYY (e.g., 01-15-2025)
   - YYYY-MM-DD (e.g., 2025-01-15)
-  - Relative: "today", "yesterday", "last monday", "2 weeks ago"
+  - Relative: "today", "now", "yesterday", "last monday", "2 weeks ago"
+
+The end date may be omitted (or given as "" or "today") for a range that
+runs up to today. Instead of start/end arguments, --since sets the start date and --until the
+end date (default today).
+
+Omitted arguments are read from PERFDIVE_EMAIL, PERFDIVE_START_DATE and
+PERFDIVE_END_DATE, which is convenient in CI. Explicit arguments and flags
+override the environment.
 
 Example:
   perfdive bpalm@redhat.com 06-01-2025 06-31-2025
   perfdive bpalm@redhat.com 2025-06-01 2025-06-31
   perfdive bpalm@redhat.com "2 weeks ago" today
+  perfdive bpalm@redhat.com 2025-06-01
+  perfdive --since "2 weeks ago" bpalm@redhat.com
+  perfdive --since 2025-06-01 --until 2025-06-30 bpalm@redhat.com llama3.2:latest
   perfdive bpalm@redhat.com 06-01-2025 06-31-2025 llama3.2:latest
   perfdive --github-username sebrandon1 bpalm@redhat.com 06-01-2025 06-31-2025
   perfdive --github-activity bpalm@redhat.com 06-01-2025 06-31-2025
-  perfdive --verbose bpalm@redhat.com 06-01-2025 06-31-2025`,
-	Args: cobra.RangeArgs(3, 4),
+  perfdive --verbose bpalm@redhat.com 06-01-2025 06-31-2025
+  perfdive -vv bpalm@redhat.com 06-01-2025 06-31-2025
+  PERFDIVE_EMAIL=bpalm@redhat.com PERFDIVE_START_DATE=2025-06-01 perfdive
+
+Verbosity levels:
+  -v    High-level progress and warnings
+  -vv   Per-request information (API URLs, status codes)
+  -vvv  Full request/response bodies`,
+	Args: rootArgs,
 	Run:  runPerfdive,
 }
 
+// rootArgs accepts "email start-date [end-date [model]]", or "email [model]"
+// when --since provides the date range. A missing end date means today.
+// Omitted positional arguments fall back to PERFDIVE_EMAIL,
+// PERFDIVE_START_DATE and PERFDIVE_END_DATE.
+func rootArgs(cmd *cobra.Command, args []string) error {
+	_, _, _, _, err := resolveRootArgs(cmd, args)
+	return err
+}
+
+// resolveRootArgs maps the root command's positional arguments and
+// --since/--until flags to the user, date range and model. Explicit
+// arguments and flags override the environment.
+func resolveRootArgs(cmd *cobra.Command, args []string) (email, startDate, endDate, model string, err error) {
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	email = firstNonEmpty(arg(0), viper.GetString("email"))
+	if since != "" {
+		if err := cobra.MaximumNArgs(2)(cmd, args); err != nil {
+			return "", "", "", "", err
+		}
+		startDate = since
+		endDate = firstNonEmpty(until, viper.GetString("end_date"), "today")
+		model = arg(1)
+	} else {
+		if until != "" {
+			return "", "", "", "", fmt.Errorf("--until requires --since")
+		}
+		if err := cobra.MaximumNArgs(4)(cmd, args); err != nil {
+			return "", "", "", "", err
+		}
+		startDate = firstNonEmpty(arg(1), viper.GetString("start_date"))
+		endDate = firstNonEmpty(arg(2), viper.GetString("end_date"))
+		model = arg(3)
+	}
+
+	if email == "" {
+		return "", "", "", "", fmt.Errorf("email required: pass it as the first argument or set PERFDIVE_EMAIL")
+	}
+	if startDate == "" {
+		return "", "", "", "", fmt.Errorf("start date required: pass it as the second argument, use --since, or set PERFDIVE_START_DATE")
+	}
+	return email, startDate, endDate, model, nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// SetVersionInfo records the build version, enabling --version
+func SetVersionInfo(v, buildTime string) {
+	version = v
+	rootCmd.Version = fmt.Sprintf("%s (built %s)", v, buildTime)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	err := rootCmd.Execute()
@@ -58,27 +161,74 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPostRun = finishRun
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.perfdive.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "Config profile to use (overlays the profiles.<name> section of the config file)")
+	rootCmd.PersistentFlags().CountVarP(&verbosityFlag, "verbose", "v", "Increase verbosity (-v progress, -vv per-request info, -vvv full request/response bodies)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress all progress and diagnostic output (stderr)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also disabled when NO_COLOR is set or output is not a terminal)")
+	rootCmd.PersistentFlags().Bool("ascii", false, "Use ASCII symbols ([OK], [WARN], ->) and spinner instead of Unicode (default on when LANG/LC_ALL is not UTF-8)")
+	rootCmd.PersistentFlags().String("ca-cert", "", "Path to an extra PEM root CA for GitHub/Ollama TLS (e.g. a corporate proxy CA)")
+	rootCmd.PersistentFlags().Duration("github-timeout", constants.GitHubTimeout, "Timeout for each GitHub API request (e.g. 45s, 2m)")
+	rootCmd.PersistentFlags().String("github-api-version", constants.GitHubAPIVersion, "GitHub REST API version sent as the X-GitHub-Api-Version header")
+	rootCmd.PersistentFlags().Duration("ollama-timeout", constants.OllamaTimeout, "Timeout for each Ollama generate request (e.g. 90s, 10m)")
+	rootCmd.PersistentFlags().String("model-params", "", "Ollama model options as key=value pairs (e.g. temperature=0.2,seed=42,num_ctx=8192); overrides ollama.options in config")
+	rootCmd.PersistentFlags().Duration("max-wait", 0, "Longest to wait for a GitHub rate limit reset before continuing with partial data (e.g. 5m; 0 waits indefinitely)")
+	rootCmd.PersistentFlags().Bool("commits", false, "Also fetch raw commits and summarize them when there are no PRs (for direct-to-main workflows)")
+	rootCmd.PersistentFlags().Bool("include-draft-prs", true, "Count draft pull requests as created PRs in metrics and summaries")
+	rootCmd.PersistentFlags().Bool("include-bot-prs", false, "Count PRs authored by bots (logins ending in [bot] or listed in github.bot_logins) in metrics and summaries")
+	rootCmd.PersistentFlags().StringSlice("github-activity-types", ghclient.DefaultActivityTypes, "GitHub event types counted as activity (e.g. PushEvent,ReleaseEvent), or 'all'")
+	rootCmd.PersistentFlags().String("jira-auth-type", "", "Jira authentication: pat (bearer personal access token, self-hosted) or basic (account email + API token, Jira Cloud); defaults to basic for *.atlassian.net URLs")
+	rootCmd.PersistentFlags().Bool("strip-pii-from-cache", false, "Redact secrets and email addresses from fetched PR, issue and diff text before it is cached or sent to the LLM (extra patterns: redact.patterns)")
+	rootCmd.PersistentFlags().Bool("redact-diffs", false, "Don't fetch PR diffs or file patches, keeping only the changed files' names, types and line counts (per repository: github.redact_diff_repos)")
+	rootCmd.PersistentFlags().Bool("preview-prompt", false, "Fetch as usual, then print the prompts that would be sent to the LLM and exit without calling it")
+	rootCmd.PersistentFlags().String("jira-role", "assignee", "Which Jira issues to fetch: assignee (work owned), reporter, or contributor (assigned, reported, or watched/commented)")
+	rootCmd.PersistentFlags().Bool("record-metrics", false, "Record this run's activity counts in ~/.perfdive/metrics.db for 'perfdive trends'")
+	rootCmd.PersistentFlags().String("metrics-file", "", "Write operational metrics of the run (API calls, cache hit ratio, LLM latency) to this file: Prometheus textfile format for .prom, JSON otherwise")
+	rootCmd.PersistentFlags().String("week-start", "monday", "First day of the week for this-week/last-week periods (monday or sunday)")
+	rootCmd.PersistentFlags().Duration("max-age", 0, "Warn when a cached Jira or GitHub entry served is older than this (e.g. 1h), independently of the cache TTLs; 0 disables the check")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Fetch fresh Jira and GitHub data instead of serving cached entries; the fetched data still refreshes the cache")
+	rootCmd.PersistentFlags().Bool("refresh-expired-only", false, "Remove the expired cache entries of this run's Jira issues, GitHub activity, PRs and issues before fetching, so only they are refetched while valid entries keep being served")
+	rootCmd.PersistentFlags().Bool("offline", false, "Serve Jira and GitHub data only from the cache, including expired entries, without network calls; missing entries are omitted and reported (Ollama is still called)")
 
 	// Local flags
 	rootCmd.Flags().StringP("jira-url", "j", "https://issues.redhat.com", "Jira base URL")
 	rootCmd.Flags().StringP("jira-username", "u", "", "Jira username")
 	rootCmd.Flags().StringP("jira-token", "t", "", "Jira API token")
+	rootCmd.Flags().String("jira-token-cmd", "", "Command that prints the Jira API token (used when no token is configured and JIRA_TOKEN is unset)")
 	rootCmd.Flags().StringP("ollama-url", "o", "http://localhost:11434", "Ollama API URL")
 	rootCmd.Flags().StringP("ollama-model", "m", "llama3.2:latest", "Ollama model to use")
 	rootCmd.Flags().StringP("output", "f", "text", "Output format (text, json, markdown, html, csv)")
 	rootCmd.Flags().StringP("github-token", "g", "", "GitHub API token (optional, for private repos)")
 	rootCmd.Flags().StringP("github-username", "", "", "Explicit GitHub username (overrides email-based search)")
 	rootCmd.Flags().BoolP("github-activity", "a", false, "Fetch user's GitHub activity via email search (auto-enabled if --github-username provided)")
-	rootCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output including warnings and debug information")
 	rootCmd.Flags().IntP("rate-limit-delay", "r", 500, "Delay between Jira API requests in milliseconds (default 500ms, increase if seeing rate limit errors)")
+	rootCmd.Flags().String("since", "", "Start date instead of the start/end arguments (e.g. '2 weeks ago', 'last monday', 2025-06-01)")
+	rootCmd.Flags().String("until", "", "End date when using --since (default today)")
+	rootCmd.Flags().Int("max-issues", 0, "Only summarize the N most recently updated Jira issues (0 = no limit)")
+	rootCmd.Flags().Int("max-prs", 0, "Only summarize the N most recently updated GitHub pull requests (0 = no limit)")
+	rootCmd.Flags().Int("max-references", 0, "Only fetch details for the first N GitHub references in Jira, PRs first (0 = no limit)")
+	rootCmd.Flags().Int("repo-request-budget", 0, "After N GitHub requests for one repository, fetch its further referenced PRs without reviews, files or diff (0 = no limit)")
+	rootCmd.Flags().String("summary-length", "medium", "Length of the AI narratives: short (2 sentences), medium, or long (detailed paragraphs)")
+	rootCmd.Flags().String("persona", "", "Writing voice of the AI narratives: engineer (terse, technical), manager (outcome-focused narrative) or brag (first person, impact-focused); default is a professional summary")
+	rootCmd.Flags().String("sections", "all", "Comma-separated summary sections to emit: jira, github, metrics, references, summary (jira,github), all")
+	rootCmd.Flags().String("section-order", "", "Comma-separated order of the summary sections, e.g. github,jira,metrics; sections left out follow in the default order (jira, github, metrics)")
+	rootCmd.Flags().Bool("group-by-repo", false, "Add a per-repository breakdown of GitHub PRs to the metrics")
+	rootCmd.Flags().Int("jira-comments", constants.DefaultJiraPromptComments, "Most recent comments per Jira issue to include in the summary prompt (0 = none)")
+	rootCmd.Flags().String("jira-group-by", "project", "Group Jira issues in the summary and metrics by project, component or label")
+	rootCmd.Flags().Bool("timeline", false, "Print each Jira issue's status transitions (To Do → In Progress → Done) with the time spent in each status")
+	rootCmd.Flags().String("split-by", "", "Summarize the range in sections, one per calendar quarter or month (quarter or month), e.g. a year by quarter for an annual review")
+	rootCmd.Flags().Bool("no-llm", false, "Skip the LLM and generate a statistical summary from the fetched data: counts by project, status, label and repository, PR cycle times and the largest PRs")
+	rootCmd.Flags().Bool("shipped-only", false, "Summarize only delivered work: merged PRs and Jira issues in Done or Closed status, framed as completed; the metrics note what was excluded")
+	rootCmd.Flags().String("jira-resolution", "", "Comma-separated resolutions (e.g. Done,Fixed) resolved issues must have to be summarized; unresolved issues are always kept")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("jira.url", rootCmd.Flags().Lookup("jira-url"))
 	_ = viper.BindPFlag("jira.username", rootCmd.Flags().Lookup("jira-username"))
 	_ = viper.BindPFlag("jira.token", rootCmd.Flags().Lookup("jira-token"))
+	_ = viper.BindPFlag("jira.token_cmd", rootCmd.Flags().Lookup("jira-token-cmd"))
 	_ = viper.BindPFlag("ollama.url", rootCmd.Flags().Lookup("ollama-url"))
 	_ = viper.BindPFlag("ollama.model", rootCmd.Flags().Lookup("ollama-model"))
 	_ = viper.BindPFlag("output.format", rootCmd.Flags().Lookup("output"))
@@ -86,17 +236,58 @@ func init() {
 	_ = viper.BindPFlag("github.username", rootCmd.Flags().Lookup("github-username"))
 	_ = viper.BindPFlag("github.activity", rootCmd.Flags().Lookup("github-activity"))
 	_ = viper.BindPFlag("github.gist_url", rootCmd.Flags().Lookup("github-gist-url"))
-	_ = viper.BindPFlag("verbose", rootCmd.Flags().Lookup("verbose"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	_ = viper.BindPFlag("no_color", rootCmd.PersistentFlags().Lookup("no-color"))
+	_ = viper.BindPFlag("ascii", rootCmd.PersistentFlags().Lookup("ascii"))
+	_ = viper.BindPFlag("http.ca_cert", rootCmd.PersistentFlags().Lookup("ca-cert"))
+	_ = viper.BindPFlag("github.timeout", rootCmd.PersistentFlags().Lookup("github-timeout"))
+	_ = viper.BindPFlag("github.api_version", rootCmd.PersistentFlags().Lookup("github-api-version"))
+	_ = viper.BindPFlag("ollama.timeout", rootCmd.PersistentFlags().Lookup("ollama-timeout"))
+	_ = viper.BindPFlag("ollama.model_params", rootCmd.PersistentFlags().Lookup("model-params"))
+	_ = viper.BindPFlag("github.max_wait", rootCmd.PersistentFlags().Lookup("max-wait"))
+	_ = viper.BindPFlag("github.commits", rootCmd.PersistentFlags().Lookup("commits"))
+	_ = viper.BindPFlag("github.include_draft_prs", rootCmd.PersistentFlags().Lookup("include-draft-prs"))
+	_ = viper.BindPFlag("github.include_bot_prs", rootCmd.PersistentFlags().Lookup("include-bot-prs"))
+	_ = viper.BindPFlag("github.activity_types", rootCmd.PersistentFlags().Lookup("github-activity-types"))
+	_ = viper.BindPFlag("jira.role", rootCmd.PersistentFlags().Lookup("jira-role"))
+	_ = viper.BindPFlag("jira.auth_type", rootCmd.PersistentFlags().Lookup("jira-auth-type"))
+	_ = viper.BindPFlag("preview_prompt", rootCmd.PersistentFlags().Lookup("preview-prompt"))
+	_ = viper.BindPFlag("github.redact_diffs", rootCmd.PersistentFlags().Lookup("redact-diffs"))
+	_ = viper.BindPFlag("redact.enabled", rootCmd.PersistentFlags().Lookup("strip-pii-from-cache"))
+	_ = viper.BindPFlag("metrics.record", rootCmd.PersistentFlags().Lookup("record-metrics"))
+	_ = viper.BindPFlag("metrics.file", rootCmd.PersistentFlags().Lookup("metrics-file"))
+	_ = viper.BindPFlag("date.week_start", rootCmd.PersistentFlags().Lookup("week-start"))
+	_ = viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
+	_ = viper.BindPFlag("cache.max_age", rootCmd.PersistentFlags().Lookup("max-age"))
+	_ = viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("cache.refresh_expired_only", rootCmd.PersistentFlags().Lookup("refresh-expired-only"))
 	_ = viper.BindPFlag("rate_limit_delay", rootCmd.Flags().Lookup("rate-limit-delay"))
+	_ = viper.BindPFlag("max_issues", rootCmd.Flags().Lookup("max-issues"))
+	_ = viper.BindPFlag("max_prs", rootCmd.Flags().Lookup("max-prs"))
+	_ = viper.BindPFlag("max_references", rootCmd.Flags().Lookup("max-references"))
+	_ = viper.BindPFlag("github.repo_request_budget", rootCmd.Flags().Lookup("repo-request-budget"))
+	_ = viper.BindPFlag("ollama.summary_length", rootCmd.Flags().Lookup("summary-length"))
+	_ = viper.BindPFlag("ollama.persona", rootCmd.Flags().Lookup("persona"))
+	_ = viper.BindPFlag("output.sections", rootCmd.Flags().Lookup("sections"))
+	_ = viper.BindPFlag("output.section_order", rootCmd.Flags().Lookup("section-order"))
+	_ = viper.BindPFlag("group_by_repo", rootCmd.Flags().Lookup("group-by-repo"))
+	_ = viper.BindPFlag("jira.comments", rootCmd.Flags().Lookup("jira-comments"))
+	_ = viper.BindPFlag("jira.resolution", rootCmd.Flags().Lookup("jira-resolution"))
+	_ = viper.BindPFlag("shipped_only", rootCmd.Flags().Lookup("shipped-only"))
+	_ = viper.BindPFlag("jira.timeline", rootCmd.Flags().Lookup("timeline"))
+	_ = viper.BindPFlag("jira.group_by", rootCmd.Flags().Lookup("jira-group-by"))
+	_ = viper.BindPFlag("no_llm", rootCmd.Flags().Lookup("no-llm"))
+	_ = viper.BindPFlag("split_by", rootCmd.Flags().Lookup("split-by"))
 
 	// Set defaults for configurable values
-	viper.SetDefault("cache.activity_ttl_hours", 1)
-	viper.SetDefault("cache.issue_ttl_hours", 24)
 	viper.SetDefault("api.review_comments_limit", 20)
 	viper.SetDefault("api.issue_comments_limit", 10)
 	viper.SetDefault("api.diff_size_limit", 5000)
 	viper.SetDefault("api.patch_size_limit", 2000)
 	viper.SetDefault("ollama.model", "llama3.2:latest")
+	viper.SetDefault("highlight.frontmatter_tags", []string{"highlights"})
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -115,18 +306,211 @@ func initConfig() {
 		viper.SetConfigName(".perfdive")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	configureEnv()
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	if profile := viper.GetString("profile"); profile != "" {
+		if err := applyProfile(viper.GetViper(), profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Commands that don't use the tokens (config, cache, version, ...) must
+	// still run when the provider fails; those that do report the missing token
+	if err := applySecrets(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; continuing without it\n", err)
+	}
+
+	if viper.GetBool("offline") && viper.GetBool("no_cache") {
+		fmt.Fprintf(os.Stderr, "Error: --offline and --no-cache are mutually exclusive\n")
+		os.Exit(1)
+	}
+	if viper.GetBool("cache.refresh_expired_only") && (viper.GetBool("offline") || viper.GetBool("no_cache")) {
+		fmt.Fprintf(os.Stderr, "Error: --refresh-expired-only can't be combined with --offline or --no-cache\n")
+		os.Exit(1)
+	}
+
+	color.SetDisabled(viper.GetBool("no_color"))
+	ascii := viper.GetBool("ascii")
+	if !viper.IsSet("ascii") {
+		ascii = !color.LocaleIsUTF8()
+	}
+	color.SetASCII(ascii)
+	if err := jira.SetProjectKeyPattern(viper.GetString("jira.project_key_pattern")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ollamaURLChanged = rootCmd.Flags().Changed("ollama-url")
+}
+
+// configureEnv reads config keys from PERFDIVE_-prefixed environment
+// variables, e.g. PERFDIVE_JIRA_URL for jira.url and PERFDIVE_START_DATE for
+// the root command's start date
+func configureEnv() {
+	viper.SetEnvPrefix("PERFDIVE")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viper.AutomaticEnv()
+	_ = viper.BindEnv("email")
+	_ = viper.BindEnv("start_date")
+	_ = viper.BindEnv("end_date")
+}
+
+// applyProfile overlays the profiles.<name> section of v onto its top-level
+// config. Keys missing from the profile keep their top-level values, and
+// command-line flags still take precedence over both.
+func applyProfile(v *viper.Viper, name string) error {
+	key := "profiles." + name
+	if !v.IsSet(key) {
+		return fmt.Errorf("profile '%s' not found in config: define it under 'profiles.%s'", name, name)
+	}
+	profile := v.GetStringMap(key)
+	if err := v.MergeConfigMap(profile); err != nil {
+		return fmt.Errorf("failed to apply profile '%s': %w", name, err)
+	}
+	fmt.Fprintln(os.Stderr, "Using config profile:", name)
+	return nil
+}
+
+// newLogger creates the diagnostic logger for the current invocation.
+// Diagnostics go to stderr so stdout only carries the requested output;
+// --quiet discards them entirely.
+func newLogger(verbosity int) logger.Logger {
+	if viper.GetBool("quiet") {
+		return logger.Nop()
+	}
+	return logger.Default(verbosity)
+}
+
+// githubPageProgress reports each page of the user's GitHub PR, issue and
+// commit searches at -v, so long paginated fetches don't look hung
+func githubPageProgress() ghclient.PageFunc {
+	if viper.GetBool("quiet") {
+		return nil
+	}
+	status := progress.NewStatusLine(viper.GetInt("verbose"))
+	return func(kind string, page, fetched int) {
+		status.Print("Fetched page %d (%d %s so far)", page, fetched, kind)
+	}
+}
+
+// githubConfig builds the GitHub client configuration from the flags and
+// config keys, so every command fetches and caches the same data
+func githubConfig(token string, log logger.Logger, transport http.RoundTripper, timeout time.Duration, redactor *redact.Redactor) ghclient.Config {
+	return ghclient.Config{
+		Token:             token,
+		Logger:            log,
+		Transport:         transport,
+		Timeout:           timeout,
+		APIVersion:        viper.GetString("github.api_version"),
+		EmailMap:          viper.GetStringMapString("github.email_map"),
+		Org:               viper.GetString("github.org"),
+		FetchCommits:      viper.GetBool("github.commits"),
+		ExcludeDraftPRs:   !viper.GetBool("github.include_draft_prs"),
+		IncludeBotPRs:     viper.GetBool("github.include_bot_prs"),
+		BotLogins:         viper.GetStringSlice("github.bot_logins"),
+		ActivityTypes:     viper.GetStringSlice("github.activity_types"),
+		MaxReferences:     viper.GetInt("max_references"),
+		RepoRequestBudget: viper.GetInt("github.repo_request_budget"),
+		RedactDiffs:       viper.GetBool("github.redact_diffs"),
+		RedactDiffRepos:   viper.GetStringSlice("github.redact_diff_repos"),
+		DiffSizeLimit:     viper.GetInt("api.diff_size_limit"),
+		PatchSizeLimit:    viper.GetInt("api.patch_size_limit"),
+		Redactor:          redactor,
+		MaxWait:           viper.GetDuration("github.max_wait"),
+		ConfirmWait:       confirmRateLimitWait,
+		BreakerThreshold:  viper.GetInt("github.circuit_breaker_threshold"),
+		OnPage:            githubPageProgress(),
+		Offline:           viper.GetBool("offline"),
+		NoCache:           viper.GetBool("no_cache"),
+		MaxAge:            viper.GetDuration("cache.max_age"),
+
+		ReviewCommentsLimit: viper.GetInt("api.review_comments_limit"),
+		IssueCommentsLimit:  viper.GetInt("api.issue_comments_limit"),
+		RefreshExpiredOnly:  viper.GetBool("cache.refresh_expired_only"),
+	}
+}
+
+// httpTransport builds the transport shared by the GitHub and Ollama clients.
+// It honors HTTP(S)_PROXY/NO_PROXY and trusts the --ca-cert root CA if set.
+func httpTransport() (http.RoundTripper, error) {
+	transport, err := httpclient.NewTransport(viper.GetString("http.ca_cert"))
+	if err != nil {
+		return nil, err
+	}
+	return transport, nil
+}
+
+// apiTimeouts returns the configured GitHub and Ollama request timeouts
+func apiTimeouts() (githubTimeout, ollamaTimeout time.Duration, err error) {
+	githubTimeout = viper.GetDuration("github.timeout")
+	if githubTimeout <= 0 {
+		return 0, 0, fmt.Errorf("--github-timeout must be a positive duration (e.g. 30s), got %v", githubTimeout)
+	}
+	ollamaTimeout = viper.GetDuration("ollama.timeout")
+	if ollamaTimeout <= 0 {
+		return 0, 0, fmt.Errorf("--ollama-timeout must be a positive duration (e.g. 5m), got %v", ollamaTimeout)
+	}
+	return githubTimeout, ollamaTimeout, nil
+}
+
+// jiraAuthType resolves --jira-auth-type (config jira.auth_type) for the Jira URL
+func jiraAuthType(jiraURL string) (jira.AuthType, error) {
+	return jira.ParseAuthType(viper.GetString("jira.auth_type"), jiraURL)
+}
+
+// piiRedactor returns the --strip-pii-from-cache redactor (config
+// redact.enabled, with extra redact.patterns), or nil when redaction is off
+func piiRedactor() (*redact.Redactor, error) {
+	if !viper.GetBool("redact.enabled") {
+		return nil, nil
+	}
+	redactor, err := redact.New(viper.GetStringSlice("redact.patterns"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid redact.patterns: %w", err)
+	}
+	return redactor, nil
+}
+
+// ollamaHosts returns the ollama.urls hosts to spread requests over, or nil
+// to use the single ollama.url. An explicit --ollama-url overrides the list.
+func ollamaHosts() []string {
+	if ollamaURLChanged {
+		return nil
+	}
+	return viper.GetStringSlice("ollama.urls")
+}
+
+// ollamaOptions returns the Ollama model options from the ollama.options config
+// block, overlaid with any --model-params values
+func ollamaOptions() (map[string]any, error) {
+	params, err := ollama.ParseModelParams(viper.GetString("ollama.model_params"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --model-params: %w", err)
+	}
+	options := make(map[string]any)
+	for key, value := range viper.GetStringMap("ollama.options") {
+		options[key] = value
+	}
+	for key, value := range params {
+		options[key] = value
+	}
+	if len(options) == 0 {
+		return nil, nil
+	}
+	return options, nil
 }
 
 func runPerfdive(cmd *cobra.Command, args []string) {
-	email := args[0]
-	startDateArg := args[1]
-	endDateArg := args[2]
+	email, startDateArg, endDateArg, modelArg, err := resolveRootArgs(cmd, args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Input validation: email format
 	if !strings.Contains(email, "@") {
@@ -134,6 +518,20 @@ func runPerfdive(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Input validation: API timeouts and model options
+	if _, _, err := apiTimeouts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := ollamaOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := piiRedactor(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Parse start date with flexible format support
 	startTime, err := dateparse.ParseDateOrRelative(startDateArg)
 	if err != nil {
@@ -142,7 +540,7 @@ func runPerfdive(cmd *cobra.Command, args []string) {
 	}
 
 	// Parse end date with flexible format support
-	endTime, err := dateparse.ParseDateOrRelative(endDateArg)
+	endTime, err := dateparse.ParseEndDate(endDateArg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing end date: %v\n", err)
 		os.Exit(1)
@@ -163,183 +561,444 @@ func runPerfdive(cmd *cobra.Command, args []string) {
 	if model == "" {
 		model = "llama3.2:latest"
 	}
-	if len(args) >= 4 {
-		model = args[3]
+	if modelArg != "" {
+		model = modelArg
 	}
 
-	fmt.Printf("Processing Jira issues for %s from %s to %s using model %s\n",
-		email, dateparse.FormatForDisplay(startTime), dateparse.FormatForDisplay(endTime), model)
-
 	// Get configuration values
 	jiraURL := viper.GetString("jira.url")
 	jiraUsername := viper.GetString("jira.username")
-	jiraToken := viper.GetString("jira.token")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	ollamaURL := viper.GetString("ollama.url")
 	outputFormat := viper.GetString("output.format")
-	githubToken := viper.GetString("github.token")
+	githubToken := resolveGitHubToken()
 	githubUsername := viper.GetString("github.username")
 	fetchGitHubActivity := viper.GetBool("github.activity")
-	verbose := viper.GetBool("verbose")
+	verbosity := viper.GetInt("verbose")
 	rateLimitDelay := viper.GetInt("rate_limit_delay")
+	maxIssues := viper.GetInt("max_issues")
+	maxPRs := viper.GetInt("max_prs")
+	groupByRepo := viper.GetBool("group_by_repo")
 
-	// Validate required configuration
-	if jiraURL == "" {
+	log := newLogger(verbosity)
+	log.Printf("Processing Jira issues for %s from %s to %s using model %s\n",
+		email, dateparse.FormatForDisplay(startTime), dateparse.FormatForDisplay(endTime), model)
+
+	// Validate required configuration; offline runs don't talk to Jira
+	offline := viper.GetBool("offline")
+	if jiraURL == "" && !offline {
 		fmt.Fprintf(os.Stderr, "Error: Jira URL is required. Set via --jira-url flag or config file\n")
 		os.Exit(1)
 	}
-	if jiraUsername == "" {
+	if jiraUsername == "" && !offline {
 		fmt.Fprintf(os.Stderr, "Error: Jira username is required. Set via --jira-username flag or config file\n")
 		os.Exit(1)
 	}
-	if jiraToken == "" {
+	if jiraToken == "" && !offline {
 		fmt.Fprintf(os.Stderr, "Error: Jira token is required. Set via --jira-token flag or config file\n")
 		os.Exit(1)
 	}
+	if maxIssues < 0 || maxPRs < 0 || viper.GetInt("max_references") < 0 || viper.GetInt("github.repo_request_budget") < 0 || viper.GetInt("jira.comments") < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-issues, --max-prs, --max-references, --repo-request-budget and --jira-comments must be non-negative\n")
+		os.Exit(1)
+	}
+	sections, err := output.ParseSections(viper.GetString("output.sections"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	sectionOrder, err := output.ParseSectionOrder(viper.GetString("output.section_order"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	summaryLength, err := ollama.ParseSummaryLength(viper.GetString("ollama.summary_length"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	persona, err := ollama.ParsePersona(viper.GetString("ollama.persona"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	jiraRole, err := jira.ParseRole(viper.GetString("jira.role"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	jiraGroupBy, err := jira.ParseGroupBy(viper.GetString("jira.group_by"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	previewPrompt := viper.GetBool("preview_prompt")
+	noLLM := viper.GetBool("no_llm")
+	if previewPrompt && noLLM {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt and --no-llm cannot be combined: --no-llm sends no prompts\n")
+		os.Exit(1)
+	}
 
-	if err = processUserActivity(email, startDate, endDate, model, jiraURL, jiraUsername, jiraToken, ollamaURL, outputFormat, githubToken, githubUsername, fetchGitHubActivity, verbose, rateLimitDelay); err != nil {
+	opts := &summaryOptions{
+		email:               email,
+		model:               model,
+		jiraURL:             jiraURL,
+		jiraUsername:        jiraUsername,
+		jiraToken:           jiraToken,
+		jiraRole:            jiraRole,
+		jiraGroupBy:         jiraGroupBy,
+		jiraComments:        viper.GetInt("jira.comments"),
+		resolutionFilter:    jira.ParseResolutionFilter(viper.GetString("jira.resolution")),
+		timeline:            viper.GetBool("jira.timeline"),
+		ollamaURL:           ollamaURL,
+		outputFormat:        outputFormat,
+		githubToken:         githubToken,
+		githubUsername:      githubUsername,
+		fetchGitHubActivity: fetchGitHubActivity,
+		log:                 log,
+		rateLimitDelay:      rateLimitDelay,
+		maxIssues:           maxIssues,
+		maxPRs:              maxPRs,
+		maxReferences:       viper.GetInt("max_references"),
+		groupByRepo:         groupByRepo,
+		shippedOnly:         viper.GetBool("shipped_only"),
+		sections:            sections,
+		sectionOrder:        sectionOrder,
+		summaryLength:       summaryLength,
+		persona:             persona,
+		previewPrompt:       previewPrompt,
+		noLLM:               noLLM,
+	}
+	summarize := func(startDate, endDate string) error {
+		return processUserActivity(opts, startDate, endDate)
+	}
+	if splitBy := viper.GetString("split_by"); splitBy != "" {
+		if outputFormat == "json" {
+			fmt.Fprintf(os.Stderr, "Error: --split-by concatenates text summaries and cannot be combined with --output json\n")
+			os.Exit(1)
+		}
+		periods, err := dateparse.SplitRange(startTime, endTime, splitBy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		err = summarizeByPeriod(os.Stdout, email, startTime, endTime, periods, summarize, log)
+	} else {
+		err = summarize(startDate, endDate)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// summarizeByPeriod runs the summary once per period (--split-by), under a
+// heading per period, so a long range reads as one sectioned document. Each
+// period is fetched separately; overlapping cached activity is reused.
+func summarizeByPeriod(w io.Writer, email string, start, end time.Time, periods []dateparse.NamedPeriod, summarize func(startDate, endDate string) error, log logger.Logger) error {
+	fmt.Fprintf(w, "# Summary for %s: %s to %s\n", email, dateparse.FormatForDisplay(start), dateparse.FormatForDisplay(end))
+	for i, period := range periods {
+		log.Printf("\n[%d/%d] Summarizing %s...\n", i+1, len(periods), period.Name)
+		fmt.Fprintf(w, "\n## %s (%s to %s)\n", period.Name, dateparse.FormatForDisplay(period.StartDate), dateparse.FormatForDisplay(period.EndDate))
+		if err := summarize(dateparse.FormatForAPI(period.StartDate), dateparse.FormatForAPI(period.EndDate)); err != nil {
+			return fmt.Errorf("failed to summarize %s: %w", period.Name, err)
+		}
+	}
+	return nil
+}
+
+// usesResolutions reports whether a summary of the given sections needs the
+// Jira issue resolutions: the Jira narrative and the metrics report them, and
+// --jira-resolution (filtered) filters on them
+func usesResolutions(sections output.Sections, filtered bool) bool {
+	return filtered || sections.Has(output.SectionJira) || sections.Has(output.SectionMetrics)
+}
+
+// summaryOptions holds the settings of a summary run, resolved from flags and
+// config once by runPerfdive and shared by every period of a --split-by run
+type summaryOptions struct {
+	email               string
+	model               string
+	jiraURL             string
+	jiraUsername        string
+	jiraToken           string
+	jiraRole            jira.Role
+	jiraGroupBy         jira.GroupBy
+	jiraComments        int
+	resolutionFilter    []string
+	timeline            bool
+	ollamaURL           string
+	outputFormat        string
+	githubToken         string
+	githubUsername      string
+	fetchGitHubActivity bool
+	log                 logger.Logger
+	rateLimitDelay      int
+	maxIssues           int
+	maxPRs              int
+	maxReferences       int
+	groupByRepo         bool
+	shippedOnly         bool
+	sections            output.Sections
+	sectionOrder        []output.Section
+	summaryLength       ollama.SummaryLength
+	persona             ollama.Persona
+	previewPrompt       bool
+	noLLM               bool
+}
+
 // processUserActivity handles the core logic of fetching Jira issues and generating summaries
-func processUserActivity(email, startDate, endDate, model, jiraURL, jiraUsername, jiraToken, ollamaURL, outputFormat, githubToken, githubUsername string, fetchGitHubActivity, verbose bool, rateLimitDelay int) error {
+func processUserActivity(opts *summaryOptions, startDate, endDate string) error {
+	log := opts.log
+	verbose := log.Level() >= constants.VerbosityProgress
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
 	// Configure jiracrawler's global rate limiter to avoid 429 errors
-	rateLimiter := lib.NewRateLimiter(time.Duration(rateLimitDelay)*time.Millisecond, 3)
+	rateLimiter := lib.NewRateLimiter(time.Duration(opts.rateLimitDelay)*time.Millisecond, 3)
 	lib.SetGlobalRateLimiter(rateLimiter)
-	if verbose {
-		fmt.Printf("Configured rate limiter: %dms delay between requests, 3 retries\n", rateLimitDelay)
+	log.Infof("Configured rate limiter: %dms delay between requests, 3 retries\n", opts.rateLimitDelay)
+
+	transport, err := httpTransport()
+	if err != nil {
+		return err
+	}
+	redactor, err := piiRedactor()
+	if err != nil {
+		return err
 	}
 
 	// Create Jira client
+	jiraAuth, err := jiraAuthType(opts.jiraURL)
+	if err != nil {
+		return err
+	}
+	offline := viper.GetBool("offline")
 	jiraClient, err := jira.NewClient(jira.Config{
-		URL:      jiraURL,
-		Username: jiraUsername,
-		Token:    jiraToken,
+		URL:       opts.jiraURL,
+		Username:  opts.jiraUsername,
+		Token:     opts.jiraToken,
+		Logger:    log,
+		Role:      opts.jiraRole,
+		AuthType:  jiraAuth,
+		Transport: transport,
+		Redactor:  redactor,
+		Offline:   offline,
+		NoCache:   viper.GetBool("no_cache"),
+		MaxAge:    viper.GetDuration("cache.max_age"),
+
+		RefreshExpiredOnly: viper.GetBool("cache.refresh_expired_only"),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create Jira client: %w", err)
 	}
+	runStats.trackJira(jiraClient)
+	if offline {
+		log.Printf("ℹ Offline: serving Jira and GitHub data from the cache only\n")
+	}
 
-	// Test Jira connection
-	fmt.Println("Testing Jira connection...")
-	if err := jiraClient.TestConnection(); err != nil {
-		return fmt.Errorf("failed to connect to Jira: %w", err)
+	githubTimeout, ollamaTimeout, err := apiTimeouts()
+	if err != nil {
+		return err
+	}
+	modelOptions, err := ollamaOptions()
+	if err != nil {
+		return err
 	}
-	fmt.Println("✓ Jira connection successful")
 
 	// Create Ollama client
 	ollamaClient := ollama.NewClient(ollama.Config{
-		URL: ollamaURL,
+		URL:       opts.ollamaURL,
+		URLs:      ollamaHosts(),
+		Logger:    log,
+		Transport: transport,
+		Timeout:   ollamaTimeout,
+		Options:   modelOptions,
+		Redactor:  redactor,
 	})
 
-	// Test Ollama connection
-	fmt.Printf("Testing Ollama connection with model %s...\n", model)
-	if err := ollamaClient.TestConnection(model); err != nil {
-		return fmt.Errorf("failed to connect to Ollama: %w", err)
+	// Create the GitHub client; Jira references are always extracted to show their count
+	githubClient := ghclient.NewClient(githubConfig(opts.githubToken, log, transport, githubTimeout, redactor))
+	runStats.track(githubClient, ollamaClient)
+
+	// Verify every integration this run uses before the slow Jira fetch;
+	// previewing prompts and --no-llm don't need the model, offline runs need only the model
+	var checks []preflightCheck
+	if !offline {
+		checks = append(checks, preflightCheck{name: "Jira connection", run: jiraClient.TestConnection})
+	}
+	if !opts.previewPrompt && !opts.noLLM {
+		checks = append(checks, preflightCheck{name: fmt.Sprintf("Ollama connection with model %s", opts.model), run: func() error {
+			return ollamaClient.TestConnection(opts.model)
+		}})
+	}
+	if !offline && (opts.fetchGitHubActivity || (opts.githubUsername != "" && opts.githubToken != "")) {
+		checks = append(checks, preflightCheck{name: "GitHub token", run: func() error {
+			if opts.githubToken == "" {
+				return fmt.Errorf("--github-activity needs a token: set --github-token, GITHUB_TOKEN or github.token, or run 'gh auth login'")
+			}
+			_, err := githubClient.VerifyToken()
+			return err
+		}})
+	}
+	if err := runPreflight(checks, log); err != nil {
+		return err
 	}
-	fmt.Println("✓ Ollama connection successful")
 
 	// Fetch Jira issues
-	fmt.Printf("Fetching Jira issues for %s from %s to %s...\n", email, startDate, endDate)
-	issues, err := jiraClient.GetUserIssuesInDateRangeWithContext(email, startDate, endDate, true, verbose)
+	log.Printf("Fetching Jira issues %s %s from %s to %s...\n", opts.jiraRole.Description(), opts.email, startDate, endDate)
+	issues, err := jiraClient.GetUserIssuesInDateRangeWithContext(opts.email, startDate, endDate, true, verbose)
 	if err != nil {
 		return fmt.Errorf("failed to fetch Jira issues: %w", err)
 	}
 
-	fmt.Printf("Found %d issues\n", len(issues))
+	log.Printf("Found %d issues\n", len(issues))
 
-	// Always extract GitHub references to show count
-	githubClient := ghclient.NewClient(ghclient.Config{Token: githubToken})
+	// Resolutions tell completed work apart from issues closed as Won't Do or
+	// Duplicate. They aren't cached, so they're only searched for when used.
+	var resolutions map[string]string
+	allowed := opts.resolutionFilter
+	if usesResolutions(opts.sections, len(allowed) > 0) {
+		resolutions, err = jiraClient.Resolutions(issues)
+		if err != nil {
+			log.Printf("Warning: failed to fetch Jira issue resolutions: %v\n", err)
+		}
+	}
+	if len(allowed) > 0 {
+		if err != nil {
+			return fmt.Errorf("--jira-resolution requires issue resolutions: %w", err)
+		}
+		issues = jira.FilterByResolution(issues, resolutions, allowed)
+		log.Printf("Kept %d issues that are unresolved or resolved as %s (--jira-resolution)\n", len(issues), strings.Join(allowed, ", "))
+	}
 
-	// Convert jira issues to ghclient.JiraIssue format for GitHub parsing
-	var jiraIssuesForGithub []ghclient.JiraIssue
-	for _, issue := range issues {
-		jiraIssuesForGithub = append(jiraIssuesForGithub, ghclient.JiraIssue{
-			Key:         issue.Key,
-			Summary:     issue.Summary,
-			Description: issue.Description,
-		})
+	// Cap the number of issues before enhancement and summarization
+	allIssues := issues
+	var unshippedIssues int
+	var unshipped ghclient.Unshipped
+	if opts.shippedOnly {
+		issues, unshippedIssues = jira.FilterShipped(issues)
+		log.Printf("Kept %d issues in Done or Closed status (--shipped-only)\n", len(issues))
+	}
+	totalIssues := len(issues)
+	issues = limitIssues(issues, opts.maxIssues)
+	if len(issues) < totalIssues {
+		log.Printf("ℹ Limiting to the %d most recently updated issues (--max-issues)\n", len(issues))
+	}
+	if opts.timeline {
+		log.Printf("\nStatus timelines:\n")
+		for _, issue := range issues {
+			log.Printf("%s", formatStatusTimeline(issue, end))
+		}
+		log.Printf("\n")
 	}
 
 	// Fetch GitHub context from URLs found in Jira issues
-	fmt.Println("Analyzing GitHub references in Jira issues...")
-	githubContext, err := githubClient.FetchGitHubContextFromJiraIssues(jiraIssuesForGithub)
+	log.Printf("Analyzing GitHub references in Jira issues...\n")
+	githubContext, err := githubClient.FetchGitHubContextFromJiraIssues(jiraIssuesForGitHub(issues))
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch GitHub context: %v\n", err)
+		log.Printf("Warning: failed to fetch GitHub context: %v\n", err)
 		githubContext = &ghclient.GitHubContext{} // Create empty context to avoid nil pointer
 	}
 
 	// Show GitHub references found
 	if len(githubContext.References) > 0 {
-		fmt.Printf("Found %d GitHub references in Jira issues\n", len(githubContext.References))
-		if githubToken == "" {
-			fmt.Printf("ℹ Use --github-token to fetch detailed GitHub context\n")
+		log.Printf("Found %d GitHub references in Jira issues\n", len(githubContext.References))
+		if opts.githubToken == "" {
+			log.Printf("ℹ Use --github-token to fetch detailed GitHub context\n")
 		} else {
-			fmt.Printf("✓ Enhanced GitHub context enabled (fetching PR diffs, reviews, file analysis)\n")
+			log.Printf("✓ Enhanced GitHub context enabled (fetching PR diffs, reviews, file analysis)\n")
 		}
 	} else {
-		fmt.Println("No GitHub references found in Jira issues")
+		log.Printf("No GitHub references found in Jira issues\n")
+	}
+	if incomplete := len(githubContext.IncompletePullRequests()); incomplete > 0 {
+		log.Infof("⚠ %d of %d referenced PRs had incomplete data due to rate limits or API errors\n", incomplete, len(githubContext.PullRequests))
 	}
 
 	// Enhanced context status for Jira
-	fmt.Printf("✓ Enhanced Jira context enabled (fetching comments, history, time tracking)\n")
+	log.Printf("✓ Enhanced Jira context enabled (fetching comments, history, time tracking)\n")
 
 	// Fetch user's GitHub activity if requested or if GitHub username is provided
-	if fetchGitHubActivity || githubUsername != "" {
-		if githubToken == "" {
-			fmt.Println("⚠ GitHub activity requires --github-token for user search")
+	var totalPRs int
+	var allPRs []ghclient.UserPullRequest
+	if opts.fetchGitHubActivity || opts.githubUsername != "" {
+		if opts.githubToken == "" {
+			log.Printf("⚠ GitHub activity requires --github-token for user search\n")
 		} else {
 			// Convert date format for GitHub API
-			start, _ := time.Parse("01-02-2006", startDate)
-			end, _ := time.Parse("01-02-2006", endDate)
-			startDateFormatted := start.Format("2006-01-02")
-			endDateFormatted := end.Format("2006-01-02")
+			startDateFormatted := dateparse.FormatISO(start)
+			endDateFormatted := dateparse.FormatISO(end)
 
 			var userActivity []ghclient.UserActivity
 			var foundUsername string
 			var err error
 
-			if githubUsername != "" {
+			if opts.githubUsername != "" {
 				// Use explicit GitHub username
-				fmt.Printf("ℹ Using explicit GitHub username '%s' (overriding email-based search)\n", githubUsername)
-				fmt.Printf("Fetching comprehensive GitHub activity for username: %s...\n", githubUsername)
+				log.Printf("ℹ Using explicit GitHub username '%s' (overriding email-based search)\n", opts.githubUsername)
+				log.Printf("Fetching comprehensive GitHub activity for username: %s...\n", opts.githubUsername)
 
 				// Fetch comprehensive activity from multiple sources
-				comprehensiveActivity, err := githubClient.FetchComprehensiveUserActivity(githubUsername, startDateFormatted, endDateFormatted)
+				comprehensiveActivity, err := githubClient.FetchComprehensiveUserActivity(opts.githubUsername, startDateFormatted, endDateFormatted)
 				if err != nil {
-					fmt.Printf("⚠ Could not fetch comprehensive GitHub activity for %s: %v\n", githubUsername, err)
+					log.Printf("⚠ Could not fetch comprehensive GitHub activity for %s: %v\n", opts.githubUsername, err)
 
 					// Fallback to legacy activity fetching
-					activities, err := githubClient.FetchUserActivity(githubUsername)
+					activities, err := githubClient.FetchUserActivity(opts.githubUsername)
 					if err != nil {
-						fmt.Printf("⚠ Could not fetch GitHub user activity for %s: %v\n", githubUsername, err)
+						log.Printf("⚠ Could not fetch GitHub user activity for %s: %v\n", opts.githubUsername, err)
 					} else {
 						userActivity = githubClient.FilterActivityByDateRange(activities, startDateFormatted, endDateFormatted)
-						foundUsername = githubUsername
+						foundUsername = opts.githubUsername
 					}
 				} else {
 					// Use comprehensive activity
-					foundUsername = githubUsername
+					foundUsername = opts.githubUsername
 					if githubContext == nil {
 						githubContext = &ghclient.GitHubContext{}
 					}
+					// Cap the number of PRs before summarization
+					allPRs = comprehensiveActivity.PullRequests
+					if opts.shippedOnly {
+						comprehensiveActivity.PullRequests = unshipped.KeepMerged(comprehensiveActivity.PullRequests)
+					}
+					totalPRs = len(comprehensiveActivity.PullRequests)
+					comprehensiveActivity.PullRequests = limitPullRequests(comprehensiveActivity.PullRequests, opts.maxPRs)
+					if len(comprehensiveActivity.PullRequests) < totalPRs {
+						log.Printf("ℹ Limiting to the %d most recently updated pull requests (--max-prs)\n", len(comprehensiveActivity.PullRequests))
+					}
 					githubContext.ComprehensiveActivity = comprehensiveActivity
 					githubContext.GitHubUsername = foundUsername
 
+					// Search results carry no line counts; fetch them for the size distribution
+					log.Printf("Fetching sizes of %d authored pull requests...\n", len(comprehensiveActivity.PullRequests))
+					githubClient.EnhanceAuthoredPullRequests(githubContext)
+
 					totalActivity := len(comprehensiveActivity.Events) + len(comprehensiveActivity.PullRequests) + len(comprehensiveActivity.Issues)
-					fmt.Printf("✓ Found GitHub user '%s' with %d total activities in date range\n", foundUsername, totalActivity)
-					fmt.Printf("  - Events: %d, Pull Requests: %d, Issues: %d\n",
+					log.Printf("✓ Found GitHub user '%s' with %d total activities in date range\n", foundUsername, totalActivity)
+					log.Printf("  - Events: %d, Pull Requests: %d, Issues: %d\n",
 						len(comprehensiveActivity.Events),
 						len(comprehensiveActivity.PullRequests),
 						len(comprehensiveActivity.Issues))
+					if comprehensiveActivity.Commits != nil {
+						log.Printf("  - Commits: %d\n", len(comprehensiveActivity.Commits))
+					}
 				}
 			} else {
 				// Fall back to email-based search
-				fmt.Printf("Searching for GitHub user with email %s...\n", email)
-				userActivity, foundUsername, err = githubClient.FetchUserGitHubActivity(email, startDateFormatted, endDateFormatted)
+				log.Printf("Searching for GitHub user with email %s...\n", opts.email)
+				userActivity, foundUsername, err = githubClient.FetchUserGitHubActivity(opts.email, startDateFormatted, endDateFormatted)
 				if err != nil {
-					fmt.Printf("⚠ Could not fetch GitHub user activity: %v\n", err)
+					log.Printf("⚠ Could not fetch GitHub user activity: %v\n", err)
 				}
 			}
 
@@ -350,74 +1009,217 @@ func processUserActivity(email, startDate, endDate, model, jiraURL, jiraUsername
 				}
 				githubContext.UserActivity = userActivity
 				githubContext.GitHubUsername = foundUsername
-				fmt.Printf("✓ Found GitHub user '%s' with %d activities in date range\n", foundUsername, len(userActivity))
+				log.Printf("✓ Found GitHub user '%s' with %d activities in date range\n", foundUsername, len(userActivity))
 			}
 		}
 	}
 
-	// Extract user's display name from Jira issues
-	var displayName string
-	for _, issue := range issues {
-		if issue.Assignee != nil && issue.Assignee.DisplayName != "" {
-			displayName = issue.Assignee.DisplayName
-			break // Use the first non-empty assignee name we find
+	if githubClient.RateLimitWaitExceeded() {
+		warnPartialGitHubData(log)
+	}
+	if err := githubClient.CircuitOpen(); err != nil {
+		warnGitHubCircuitOpen(log, err)
+	}
+	reportOfflineMisses(log, jiraClient.OfflineMisses(), githubClient.OfflineMisses())
+	reportStaleCache(log, viper.GetDuration("cache.max_age"), jiraClient.StaleCacheEntries(), githubClient.StaleCacheEntries())
+
+	var excluded []string
+	if opts.shippedOnly {
+		unshipped.KeepShipped(githubContext)
+		excluded = shippedExclusions(unshippedIssues, &unshipped)
+		if len(excluded) > 0 {
+			log.Printf("ℹ Excluded by --shipped-only: %s\n", strings.Join(excluded, ", "))
 		}
 	}
 
-	// Generate summary using Ollama
-	fmt.Printf("Generating summary using %s...\n", model)
-	summary, err := ollamaClient.GenerateSummary(ollama.SummaryRequest{
-		Email:         email,
+	coverage := summaryCoverage(githubContext, len(issues), totalIssues, totalPRs, opts.maxReferences, &unshipped)
+
+	// Extract user's display name from Jira issues
+	displayName := jiraDisplayName(issues, opts.email, opts.jiraRole)
+
+	summaryReq := ollama.SummaryRequest{
+		Email:         opts.email,
 		DisplayName:   displayName,
 		StartDate:     startDate,
 		EndDate:       endDate,
-		Model:         model,
+		Model:         opts.model,
 		Issues:        issues,
-		Format:        outputFormat,
+		Format:        opts.outputFormat,
 		GitHubContext: githubContext,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to generate summary: %w", err)
+		TotalIssues:   totalIssues,
+		TotalPRs:      totalPRs,
+		GroupByRepo:   opts.groupByRepo,
+		Sections:      opts.sections,
+		Order:         opts.sectionOrder,
+		Length:        opts.summaryLength,
+		Persona:       opts.persona,
+		Resolutions:   resolutions,
+		Comments:      opts.jiraComments,
+		GroupBy:       opts.jiraGroupBy,
+		ShippedOnly:   opts.shippedOnly,
+		Excluded:      excluded,
+	}
+	if opts.previewPrompt {
+		ollama.WritePrompts(os.Stdout, ollamaClient.SummaryPrompts(summaryReq))
+		return nil
 	}
 
+	// Generate summary using Ollama, or from the data alone with --no-llm
+	var summary string
+	if opts.noLLM {
+		log.Printf("Generating statistical summary (--no-llm)...\n")
+		summary = ollamaClient.StatisticalSummary(summaryReq)
+	} else {
+		log.Printf("Generating summary using %s...\n", opts.model)
+		summary, err = ollamaClient.GenerateSummary(summaryReq)
+		if err != nil {
+			return fmt.Errorf("failed to generate summary: %w", err)
+		}
+	}
+
+	// Record counts from before the --max-issues/--max-prs caps
+	runStats.fetched(len(allIssues), len(allPRs))
+	recordRun(metrics.NewRun(opts.email, start, end, allPRs, allIssues), log)
+
 	// Output the result
+	if opts.outputFormat == "json" {
+		data := output.SummaryData{
+			User:          opts.email,
+			DisplayName:   displayName,
+			Period:        fmt.Sprintf("%s to %s", startDate, endDate),
+			Summary:       summary,
+			TotalIssues:   totalIssues,
+			JiraRole:      string(opts.jiraRole),
+			FetchWarnings: output.FetchWarningsFromContext(githubContext),
+			Coverage:      coverage,
+		}
+		if opts.groupByRepo {
+			data.Repositories = ghclient.RepoBreakdown(githubContext)
+		}
+		formatted, err := output.FormatSummaryJSON(data)
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatted)
+		return nil
+	}
+
+	palette := color.For(os.Stdout)
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	if displayName != "" {
-		fmt.Printf("SUMMARY FOR %s (%s) (%s to %s)\n", displayName, email, startDate, endDate)
+		fmt.Println(palette.Bold(fmt.Sprintf("SUMMARY FOR %s (%s) (%s to %s)", displayName, opts.email, startDate, endDate)))
 	} else {
-		fmt.Printf("SUMMARY FOR %s (%s to %s)\n", email, startDate, endDate)
+		fmt.Println(palette.Bold(fmt.Sprintf("SUMMARY FOR %s (%s to %s)", opts.email, startDate, endDate)))
 	}
+	fmt.Printf("Jira issues %s the user (--jira-role %s)\n", opts.jiraRole.Description(), opts.jiraRole)
+	fmt.Println(coverage)
 	fmt.Println(strings.Repeat("=", 60))
 	fmt.Println(summary)
 
-	// Add reference URLs section (only for text format)
-	if outputFormat != "json" {
-		fmt.Println("\n" + strings.Repeat("=", 60))
-		fmt.Println("REFERENCE URLS")
-		fmt.Println(strings.Repeat("=", 60))
-
-		// List Jira URLs
-		if len(issues) > 0 {
-			fmt.Println("\nJira Issues:")
-			for _, issue := range issues {
-				jiraIssueURL := fmt.Sprintf("%s/browse/%s", jiraURL, issue.Key)
-				fmt.Printf("- %s: %s\n", issue.Key, jiraIssueURL)
-			}
-		}
+	// Add reference URLs section
+	if !opts.sections.Has(output.SectionReferences) {
+		return nil
+	}
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println(palette.Bold("REFERENCE URLS"))
+	fmt.Println(strings.Repeat("=", 60))
 
-		// List GitHub URLs
-		if githubContext != nil && len(githubContext.References) > 0 {
-			fmt.Println("\nGitHub References from Jira:")
-			for _, ref := range githubContext.References {
-				fmt.Printf("- %s/%s #%s: %s\n", ref.Owner, ref.Repo, ref.Number, ref.URL)
-			}
+	// List Jira URLs
+	if len(issues) > 0 {
+		fmt.Println("\nJira Issues:")
+		for _, issue := range issues {
+			fmt.Printf("- %s: %s\n", issue.Key, output.JiraIssueURL(opts.jiraURL, issue.Key))
 		}
+	}
 
-		// Show if no references were found
-		if len(issues) == 0 && (githubContext == nil || len(githubContext.References) == 0) {
-			fmt.Println("\nNo Jira issues or GitHub references found for this period.")
+	// List GitHub URLs
+	if githubContext != nil && len(githubContext.References) > 0 {
+		fmt.Println("\nGitHub References from Jira:")
+		for _, ref := range githubContext.References {
+			fmt.Printf("- %s/%s #%s: %s\n", ref.Owner, ref.Repo, ref.Number, ref.URL)
 		}
 	}
 
+	// Show if no references were found
+	if len(issues) == 0 && (githubContext == nil || len(githubContext.References) == 0) {
+		fmt.Println("\nNo Jira issues or GitHub references found for this period.")
+	}
+
 	return nil
 }
+
+// jiraIssuesForGitHub converts Jira issues to the form the GitHub client
+// scans for PR and issue links
+func jiraIssuesForGitHub(issues []jira.Issue) []ghclient.JiraIssue {
+	var converted []ghclient.JiraIssue
+	for _, issue := range issues {
+		converted = append(converted, ghclient.JiraIssue{
+			Key:         issue.Key,
+			Summary:     issue.Summary,
+			Description: issue.Description,
+		})
+	}
+	return converted
+}
+
+// jiraDisplayName finds the user's display name in the fetched issues. With
+// the assignee role every assignee is the user; for other roles the assignee
+// may be someone else, so only users whose email matches are used.
+func jiraDisplayName(issues []jira.Issue, email string, role jira.Role) string {
+	for _, issue := range issues {
+		if role == jira.RoleAssignee {
+			if issue.Assignee != nil && issue.Assignee.DisplayName != "" {
+				return issue.Assignee.DisplayName
+			}
+			continue
+		}
+		for _, user := range []*jira.User{issue.Assignee, issue.Reporter} {
+			if user != nil && user.DisplayName != "" && strings.EqualFold(user.EmailAddress, email) {
+				return user.DisplayName
+			}
+		}
+	}
+	return ""
+}
+
+// formatStatusTimeline renders an issue's status transitions and the time
+// spent in each status for --timeline, e.g.
+//
+//	CNF-1: To Do → In Progress (Jan 6) → Done (Jan 9)
+//	  In Progress 3.0 days, To Do 1.0 days
+//
+// An open issue's current status counts up to the end of endDate, like the
+// time in status of the summary metrics, so both agree for a past range.
+func formatStatusTimeline(issue jira.Issue, endDate time.Time) string {
+	transitions := jira.StatusTimeline(issue)
+	if len(transitions) == 0 {
+		return fmt.Sprintf("%s: %s (no status changes recorded)\n", issue.Key, issue.Status.Name)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s", issue.Key, transitions[0].From)
+	for _, transition := range transitions {
+		fmt.Fprintf(&sb, color.Symbols(" → %s (%s)"), transition.To, transition.At.Local().Format("Jan 2"))
+	}
+	sb.WriteString("\n")
+
+	durations := jira.TimeInStatus(issue, endDate.AddDate(0, 0, 1))
+	statuses := make([]string, 0, len(durations))
+	for status := range durations {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if durations[statuses[i]] != durations[statuses[j]] {
+			return durations[statuses[i]] > durations[statuses[j]]
+		}
+		return statuses[i] < statuses[j]
+	})
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s %.1f days", status, durations[status].Hours()/24))
+	}
+	if len(parts) > 0 {
+		fmt.Fprintf(&sb, "  %s\n", strings.Join(parts, ", "))
+	}
+	return sb.String()
+}
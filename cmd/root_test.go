@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+func TestResolveRootArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		since     string
+		env       map[string]string
+		wantEmail string
+		wantStart string
+		wantEnd   string
+		wantModel string
+		wantErr   bool
+	}{
+		{name: "positional", args: []string{"a@example.com", "2025-01-01", "2025-01-31", "m"}, wantEmail: "a@example.com", wantStart: "2025-01-01", wantEnd: "2025-01-31", wantModel: "m"},
+		{name: "all from env", env: map[string]string{"PERFDIVE_EMAIL": "ci@example.com", "PERFDIVE_START_DATE": "2025-02-01", "PERFDIVE_END_DATE": "2025-02-28"},
+			wantEmail: "ci@example.com", wantStart: "2025-02-01", wantEnd: "2025-02-28"},
+		{name: "args override env", args: []string{"a@example.com", "2025-01-01"}, env: map[string]string{"PERFDIVE_EMAIL": "ci@example.com", "PERFDIVE_START_DATE": "2025-02-01", "PERFDIVE_END_DATE": "2025-02-28"},
+			wantEmail: "a@example.com", wantStart: "2025-01-01", wantEnd: "2025-02-28"},
+		{name: "since with env email", since: "2 weeks ago", env: map[string]string{"PERFDIVE_EMAIL": "ci@example.com", "PERFDIVE_START_DATE": "2025-02-01"},
+			wantEmail: "ci@example.com", wantStart: "2 weeks ago", wantEnd: "today"},
+		{name: "missing email", wantErr: true},
+		{name: "missing start date", args: []string{"a@example.com"}, wantErr: true},
+		{name: "too many args", args: []string{"a", "b", "c", "d", "e"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"PERFDIVE_EMAIL", "PERFDIVE_START_DATE", "PERFDIVE_END_DATE"} {
+				t.Setenv(key, tt.env[key])
+			}
+			configureEnv()
+			cmd := &cobra.Command{}
+			cmd.Flags().String("since", tt.since, "")
+			cmd.Flags().String("until", "", "")
+
+			email, start, end, model, err := resolveRootArgs(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveRootArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if email != tt.wantEmail || start != tt.wantStart || end != tt.wantEnd || model != tt.wantModel {
+				t.Errorf("resolveRootArgs() = %q, %q, %q, %q, want %q, %q, %q, %q",
+					email, start, end, model, tt.wantEmail, tt.wantStart, tt.wantEnd, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestSummarizeByPeriod(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	periods, err := dateparse.SplitRange(start, end, "quarter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	var ranges []string
+	summarize := func(startDate, endDate string) error {
+		ranges = append(ranges, startDate+".."+endDate)
+		fmt.Fprintf(&out, "summary %s\n", startDate)
+		return nil
+	}
+	if err := summarizeByPeriod(&out, "dev@example.com", start, end, periods, summarize, logger.Nop()); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"01-01-2025..03-31-2025", "04-01-2025..06-30-2025"}; !slices.Equal(ranges, want) {
+		t.Errorf("summarized ranges = %v, want %v", ranges, want)
+	}
+	want := "# Summary for dev@example.com: January 1, 2025 to June 30, 2025\n" +
+		"\n## Q1 2025 (January 1, 2025 to March 31, 2025)\nsummary 01-01-2025\n" +
+		"\n## Q2 2025 (April 1, 2025 to June 30, 2025)\nsummary 04-01-2025\n"
+	if out.String() != want {
+		t.Errorf("document =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestSummaryCoverageCountsFetchedReferences(t *testing.T) {
+	ctx := &ghclient.GitHubContext{
+		References: []ghclient.GitHubReference{{Type: "pull"}, {Type: "pull"}, {Type: "pull"}, {Type: "pull"}, {Type: "issues"}},
+		PullRequests: []ghclient.PullRequest{
+			{Number: 1, HTMLURL: "https://github.com/o/r/pull/1", MergedAt: "2025-01-02T00:00:00Z"},
+			{Number: 2, HTMLURL: "https://github.com/o/r/pull/2", State: "open"},
+			{Number: 3, HTMLURL: "https://github.com/o/r/pull/3", MergedAt: "2025-01-03T00:00:00Z"},
+		},
+	}
+	var unshipped ghclient.Unshipped
+	unshipped.KeepShipped(ctx)
+
+	// --max-references 3 fetched PRs 1-3, and --shipped-only dropped PR 2
+	coverage := summaryCoverage(ctx, 0, 0, 0, 3, &unshipped)
+	if coverage.PRsReferenced != 2 || coverage.PRsEnhanced != 2 {
+		t.Errorf("PRs %d/%d enhanced, want 2/2", coverage.PRsEnhanced, coverage.PRsReferenced)
+	}
+}
+
+func TestUsesResolutions(t *testing.T) {
+	tests := []struct {
+		spec     string
+		filtered bool
+		want     bool
+	}{
+		{spec: "all", want: true},
+		{spec: "jira", want: true},
+		{spec: "metrics", want: true},
+		{spec: "github", want: false},
+		{spec: "github,references", want: false},
+		{spec: "github", filtered: true, want: true},
+	}
+	for _, tt := range tests {
+		sections, err := output.ParseSections(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSections(%q) error = %v", tt.spec, err)
+		}
+		if got := usesResolutions(sections, tt.filtered); got != tt.want {
+			t.Errorf("usesResolutions(%q, %v) = %v, want %v", tt.spec, tt.filtered, got, tt.want)
+		}
+	}
+	if !usesResolutions(nil, false) {
+		t.Error("usesResolutions(nil) = false, want true for the default sections")
+	}
+}
+
+func TestStatusTimelineMatchesMetrics(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.January, d, 0, 0, 0, 0, time.Local) }
+	// Still open: In Progress counts up to the end of the range, not to now
+	issue := jira.Issue{
+		Key:     "CNF-1",
+		Created: day(1).Format(time.RFC3339),
+		History: []jira.HistoryItem{{Created: day(3), Items: []jira.HistoryChange{{Field: "status", FromString: "To Do", ToString: "In Progress"}}}},
+	}
+	end, err := dateparse.ParseDate("01-10-2025")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+
+	timeline := formatStatusTimeline(issue, end)
+	if want := "  In Progress 8.0 days, To Do 2.0 days\n"; !strings.HasSuffix(timeline, want) {
+		t.Errorf("formatStatusTimeline() = %q, want it to end with %q", timeline, want)
+	}
+	req := ollama.SummaryRequest{Issues: []jira.Issue{issue}, EndDate: "01-10-2025", Sections: output.Sections{output.SectionMetrics: true}}
+	if metrics := ollama.NewClient(ollama.Config{}).StatisticalSummary(req); !strings.Contains(metrics, "- Time in status: In Progress 8.0 days, To Do 2.0 days\n") {
+		t.Errorf("metrics disagree with the timeline %q:\n%s", timeline, metrics)
+	}
+}
+
+func TestRunMembersResumesFailedMembers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	emails := []string{"a@example.com", "b@example.com", "c@example.com"}
+
+	var collected []string
+	collect := func(fail string) func(string) (output.HighlightData, error) {
+		return func(email string) (output.HighlightData, error) {
+			collected = append(collected, email)
+			if email == fail {
+				return output.HighlightData{}, fmt.Errorf("connection refused")
+			}
+			return output.HighlightData{Email: email}, nil
+		}
+	}
+
+	_, failed, err := runMembers("team", emails, "01-01-2025", "01-31-2025", 0, false, logger.Nop(), collect("b@example.com"))
+	if err != nil {
+		t.Fatalf("runMembers() error = %v", err)
+	}
+	if failed != 1 {
+		t.Errorf("first run failed = %d, want 1", failed)
+	}
+
+	// --resume retries only the member that failed
+	collected = nil
+	state, failed, err := runMembers("team", emails, "01-01-2025", "01-31-2025", 0, true, logger.Nop(), collect(""))
+	if err != nil {
+		t.Fatalf("runMembers(resume) error = %v", err)
+	}
+	if failed != 0 || !slices.Equal(collected, []string{"b@example.com"}) {
+		t.Errorf("resumed run collected %q with %d failures, want only b@example.com", collected, failed)
+	}
+	for _, email := range emails {
+		if !state.Done(email) {
+			t.Errorf("state has no result for %s after resuming", email)
+		}
+	}
+
+	// Without --resume the checkpoint is ignored and every member is collected again
+	collected = nil
+	if _, _, err := runMembers("team", emails, "01-01-2025", "01-31-2025", 0, false, logger.Nop(), collect("")); err != nil {
+		t.Fatalf("runMembers() error = %v", err)
+	}
+	if !slices.Equal(collected, emails) {
+		t.Errorf("fresh run collected %q, want every member", collected)
+	}
+}
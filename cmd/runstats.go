@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+)
+
+// runStats collects the clients and fetched counts of this run for --metrics-file
+var runStats = &runCollector{started: time.Now()}
+
+// runCollector gathers operational counters from every client a run creates.
+// Team and leaderboard runs create clients concurrently, hence the mutex.
+type runCollector struct {
+	mu           sync.Mutex
+	started      time.Time
+	github       []*ghclient.Client
+	jira         []*jira.Client
+	ollama       []*ollama.Client
+	issues       int
+	pullRequests int
+}
+
+// track registers clients whose counters are included in the report; nil
+// clients are ignored
+func (r *runCollector) track(github *ghclient.Client, ai *ollama.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if github != nil {
+		r.github = append(r.github, github)
+	}
+	if ai != nil {
+		r.ollama = append(r.ollama, ai)
+	}
+}
+
+// trackJira registers a Jira client whose cache lookup counts are saved when
+// the command completes
+func (r *runCollector) trackJira(client *jira.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jira = append(r.jira, client)
+}
+
+// saveCacheCounts persists the cache hits and misses of every tracked client
+// for 'cache stats', once per run rather than on every lookup
+func (r *runCollector) saveCacheCounts() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, client := range r.github {
+		if err := client.SaveCacheCounts(); err != nil {
+			return err
+		}
+	}
+	for _, client := range r.jira {
+		if err := client.SaveCacheCounts(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetched adds to the Jira issues and PRs fetched in this run
+func (r *runCollector) fetched(issues, pullRequests int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.issues += issues
+	r.pullRequests += pullRequests
+}
+
+// runReport is the operational report written to --metrics-file
+type runReport struct {
+	Command         string         `json:"command"`
+	StartedAt       time.Time      `json:"startedAt"`
+	DurationSeconds float64        `json:"durationSeconds"`
+	JiraIssues      int            `json:"jiraIssues"`
+	PullRequests    int            `json:"pullRequests"`
+	GitHub          githubRunStats `json:"github"`
+	LLM             llmRunStats    `json:"llm"`
+}
+
+// githubRunStats sums the GitHub clients' counters
+type githubRunStats struct {
+	ghclient.Stats
+	CacheHitRatio float64 `json:"cacheHitRatio"`
+}
+
+// llmRunStats sums the Ollama clients' counters
+type llmRunStats struct {
+	Requests              int64   `json:"requests"`
+	Failures              int64   `json:"failures"`
+	TotalLatencySeconds   float64 `json:"totalLatencySeconds"`
+	AverageLatencySeconds float64 `json:"averageLatencySeconds"`
+}
+
+// report sums the counters collected so far
+func (r *runCollector) report(command string, now time.Time) runReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := runReport{
+		Command:         command,
+		StartedAt:       r.started,
+		DurationSeconds: now.Sub(r.started).Seconds(),
+		JiraIssues:      r.issues,
+		PullRequests:    r.pullRequests,
+	}
+	for _, client := range r.github {
+		s := client.Stats()
+		stats.GitHub.APICalls += s.APICalls
+		stats.GitHub.CacheHits += s.CacheHits
+		stats.GitHub.CacheMisses += s.CacheMisses
+	}
+	stats.GitHub.CacheHitRatio = stats.GitHub.HitRatio()
+
+	var llm ollama.Stats
+	for _, client := range r.ollama {
+		s := client.Stats()
+		llm.Requests += s.Requests
+		llm.Failures += s.Failures
+		llm.TotalLatency += s.TotalLatency
+	}
+	stats.LLM = llmRunStats{
+		Requests:              llm.Requests,
+		Failures:              llm.Failures,
+		TotalLatencySeconds:   llm.TotalLatency.Seconds(),
+		AverageLatencySeconds: llm.AverageLatency().Seconds(),
+	}
+	return stats
+}
+
+// formatPrometheus renders the report in the Prometheus textfile format
+// read by node_exporter's textfile collector
+func (s runReport) formatPrometheus() string {
+	var sb strings.Builder
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&sb, "# HELP perfdive_%s %s\n# TYPE perfdive_%s gauge\nperfdive_%s{command=%q} %g\n", name, help, name, name, s.Command, value)
+	}
+	gauge("last_run_timestamp_seconds", "Start time of the last run.", float64(s.StartedAt.Unix()))
+	gauge("last_run_duration_seconds", "Duration of the last run.", s.DurationSeconds)
+	gauge("jira_issues_fetched", "Jira issues fetched in the last run.", float64(s.JiraIssues))
+	gauge("pull_requests_fetched", "GitHub pull requests fetched in the last run.", float64(s.PullRequests))
+	gauge("github_api_calls", "GitHub API requests made in the last run.", float64(s.GitHub.APICalls))
+	gauge("github_cache_hits", "GitHub cache hits in the last run.", float64(s.GitHub.CacheHits))
+	gauge("github_cache_misses", "GitHub cache misses in the last run.", float64(s.GitHub.CacheMisses))
+	gauge("github_cache_hit_ratio", "Share of GitHub cache lookups that hit in the last run.", s.GitHub.CacheHitRatio)
+	gauge("llm_requests", "Ollama generate requests made in the last run.", float64(s.LLM.Requests))
+	gauge("llm_failures", "Ollama generate requests that failed in the last run.", float64(s.LLM.Failures))
+	gauge("llm_latency_seconds_total", "Total Ollama generate latency in the last run.", s.LLM.TotalLatencySeconds)
+	gauge("llm_latency_seconds_average", "Average Ollama generate latency in the last run.", s.LLM.AverageLatencySeconds)
+	return sb.String()
+}
+
+// finishRun saves the run's cache lookup counts and writes --metrics-file
+// after a command completes
+func finishRun(cmd *cobra.Command, args []string) {
+	if err := runStats.saveCacheCounts(); err != nil {
+		newLogger(viper.GetInt("verbose")).Printf("Warning: failed to save cache hit counts: %v\n", err)
+	}
+	writeRunStats(cmd, args)
+}
+
+// writeRunStats writes the run's report to --metrics-file after a command
+// completes: Prometheus textfile format for a .prom path, JSON otherwise.
+// Failures only warn, like recordRun.
+func writeRunStats(cmd *cobra.Command, _ []string) {
+	path := viper.GetString("metrics.file")
+	if path == "" {
+		return
+	}
+	log := newLogger(viper.GetInt("verbose"))
+
+	stats := runStats.report(cmd.Name(), time.Now())
+	var content []byte
+	if filepath.Ext(path) == ".prom" {
+		content = []byte(stats.formatPrometheus())
+	} else {
+		var err error
+		if content, err = json.MarshalIndent(stats, "", "  "); err != nil {
+			log.Printf("Warning: failed to encode run metrics: %v\n", err)
+			return
+		}
+		content = append(content, '\n')
+	}
+
+	// Write then rename, so a collector never reads a half-written file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		log.Printf("Warning: failed to write run metrics: %v\n", err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.Printf("Warning: failed to write run metrics: %v\n", err)
+		return
+	}
+	log.Infof("✓ Wrote run metrics to %s\n", path)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+)
+
+func TestWriteRunStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/down/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"response": "ok", "done": true}`))
+	}))
+	defer server.Close()
+
+	client := ollama.NewClient(ollama.Config{URL: server.URL, Logger: logger.Nop()})
+	if _, err := client.CallOllama("llama3.2", "summarize"); err != nil {
+		t.Fatalf("CallOllama() error = %v", err)
+	}
+	client2 := ollama.NewClient(ollama.Config{URL: server.URL + "/down", Logger: logger.Nop()})
+	_, _ = client2.CallOllama("llama3.2", "summarize")
+
+	orig := runStats
+	runStats = &runCollector{started: time.Now().Add(-time.Minute)}
+	t.Cleanup(func() { runStats = orig })
+	runStats.track(nil, client)
+	runStats.track(nil, client2)
+	runStats.fetched(4, 2)
+	runStats.fetched(1, 0)
+
+	dir := t.TempDir()
+	cmd := &cobra.Command{Use: "highlight"}
+
+	jsonPath := filepath.Join(dir, "perfdive.json")
+	viper.Set("metrics.file", jsonPath)
+	t.Cleanup(func() { viper.Set("metrics.file", "") })
+	writeRunStats(cmd, nil)
+
+	content, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("metrics file not written: %v", err)
+	}
+	var report runReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("metrics file is not JSON: %v", err)
+	}
+	if report.Command != "highlight" || report.JiraIssues != 5 || report.PullRequests != 2 {
+		t.Errorf("report = %+v, want highlight with 5 issues and 2 PRs", report)
+	}
+	if report.LLM.Requests != 2 || report.LLM.Failures != 1 || report.DurationSeconds < 60 {
+		t.Errorf("llm = %+v, duration %.0fs; want 2 requests, 1 failure, >= 60s", report.LLM, report.DurationSeconds)
+	}
+
+	promPath := filepath.Join(dir, "perfdive.prom")
+	viper.Set("metrics.file", promPath)
+	writeRunStats(cmd, nil)
+	content, err = os.ReadFile(promPath)
+	if err != nil {
+		t.Fatalf("metrics file not written: %v", err)
+	}
+	for _, want := range []string{"# TYPE perfdive_llm_requests gauge", `perfdive_llm_requests{command="highlight"} 2`, `perfdive_jira_issues_fetched{command="highlight"} 5`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Prometheus output missing %q:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [summary|highlight]",
+	Short: "Print the JSON Schema of the --output json documents",
+	Long: `Print a JSON Schema describing perfdive's JSON output, so downstream
+tools can validate it and detect breaking changes.
+
+  summary    the main command's --output json document (default)
+  highlight  the highlight command's --output json document
+
+The schema $id includes the perfdive version (see --version).
+
+Example:
+  perfdive schema > perfdive-summary.schema.json
+  perfdive schema highlight`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{output.SchemaSummary, output.SchemaHighlight},
+	Run:       runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) {
+	name := output.SchemaSummary
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	schema, err := output.JSONSchema(name, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(schema)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/runstate"
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team [emails-file]",
+	Short: "Highlight summaries for every member of a team",
+	Long: `Generate a highlight summary for each email listed in a file (one per line,
+blank lines and lines starting with # are ignored).
+
+Progress is checkpointed to ~/.perfdive/runs/<run-id>.json as each member
+completes. If a run fails partway (rate limits, network errors), re-run the
+same command with --resume to skip members that already finished. The state
+file is removed once every member has completed.
+
+Example:
+  perfdive team team.txt
+  perfdive team team.txt --period last-month --output markdown
+  perfdive team team.txt --days 14 --resume`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTeam,
+}
+
+func init() {
+	rootCmd.AddCommand(teamCmd)
+
+	teamCmd.Flags().IntP("days", "d", 7, "Number of days to look back (default 7)")
+	teamCmd.Flags().String("since", "", "Start date (supports MM-DD-YYYY, YYYY-MM-DD, or relative like 'last monday', '2 weeks ago')")
+	teamCmd.Flags().String("period", "", "Named period (this-week, last-month, this-quarter, q4-2024, 2025-W03, etc.)")
+	teamCmd.Flags().IntP("list", "l", 0, "List top N accomplishments per member instead of just the biggest")
+	teamCmd.Flags().StringP("output", "f", "text", "Output format (text, json, markdown, csv, table)")
+	teamCmd.Flags().Bool("resume", false, "Resume a previous run, skipping members that already completed")
+}
+
+func runTeam(cmd *cobra.Command, args []string) {
+	days, _ := cmd.Flags().GetInt("days")
+	since, _ := cmd.Flags().GetString("since")
+	period, _ := cmd.Flags().GetString("period")
+	listCount, _ := cmd.Flags().GetInt("list")
+	outputFormat, _ := cmd.Flags().GetString("output")
+	resume, _ := cmd.Flags().GetBool("resume")
+	log := newLogger(viper.GetInt("verbose"))
+
+	// Input validation
+	if days <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --days must be a positive number\n")
+		os.Exit(1)
+	}
+	if listCount < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --list must be a non-negative number\n")
+		os.Exit(1)
+	}
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if format == output.FormatHTML {
+		fmt.Fprintf(os.Stderr, "Error: html output is not supported for team runs\n")
+		os.Exit(1)
+	}
+
+	if viper.GetBool("preview_prompt") {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt is not supported for team runs; preview one member with 'perfdive highlight'\n")
+		os.Exit(1)
+	}
+
+	// API timeouts
+	if _, _, err := apiTimeouts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	emails, err := readEmailsFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	startDate, endDate, err := resolveDateRange(days, since, period, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	startDateStr := dateparse.FormatForAPI(startDate)
+	endDateStr := dateparse.FormatForAPI(endDate)
+
+	// Get configuration values
+	jiraURL := viper.GetString("jira.url")
+	jiraUsername := viper.GetString("jira.username")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ollamaURL := viper.GetString("ollama.url")
+	githubToken := resolveGitHubToken()
+
+	if jiraURL == "" || jiraUsername == "" || jiraToken == "" {
+		fmt.Fprintf(os.Stderr, "Error: Jira credentials required. Set via config file or flags.\n")
+		os.Exit(1)
+	}
+
+	state, failed, err := runMembers("team", emails, startDateStr, endDateStr, listCount, resume, log,
+		func(email string) (output.HighlightData, error) {
+			// Per-member lookups go by email; a configured github.username belongs to one person only
+			return collectHighlight(email, startDateStr, endDateStr, jiraURL, jiraUsername, jiraToken, ollamaURL, githubToken, "", log, listCount)
+		})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	formatted, err := formatTeamResults(emails, state.Results, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(formatted)
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d members failed; re-run with --resume to retry only those (state: %s)\n",
+			failed, len(emails), state.Path())
+		os.Exit(1)
+	}
+
+	if err := state.Remove(); err != nil {
+		log.Printf("Warning: failed to remove run state: %v\n", err)
+	}
+}
+
+// runMembers collects a result for each email, checkpointing to a run-state
+// file after every member so an interrupted run can be resumed. It returns the
+// state (holding all completed results) and the number of members that failed.
+func runMembers(command string, emails []string, startDate, endDate string, listCount int, resume bool, log logger.Logger,
+	collect func(email string) (output.HighlightData, error)) (*runstate.State, int, error) {
+	runID := runstate.RunID(command, emails, startDate, endDate, listCount)
+	state, err := runstate.Open(runID, emails, startDate, endDate, resume)
+	if err != nil {
+		return nil, 0, err
+	}
+	log.Printf("%s run %s: %d members (%s to %s)\n", command, runID, len(emails), startDate, endDate)
+
+	failed := 0
+	for i, email := range emails {
+		if state.Done(email) {
+			log.Printf("[%d/%d] %s: already completed, skipping\n", i+1, len(emails), email)
+			continue
+		}
+
+		log.Printf("[%d/%d] %s...\n", i+1, len(emails), email)
+		data, err := collect(email)
+		if err != nil {
+			log.Printf("  ✗ %v\n", err)
+			failed++
+			continue
+		}
+		if err := state.Record(email, data); err != nil {
+			log.Printf("  Warning: failed to save run state: %v\n", err)
+		}
+	}
+
+	return state, failed, nil
+}
+
+// readEmailsFile reads one email per line, skipping blank lines and # comments
+func readEmailsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open emails file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var emails []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.Contains(line, "@") {
+			return nil, fmt.Errorf("invalid email format '%s' in %s", line, path)
+		}
+		if !seen[line] {
+			seen[line] = true
+			emails = append(emails, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read emails file: %w", err)
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("no emails found in %s", path)
+	}
+	return emails, nil
+}
+
+// formatTeamResults renders the completed members' highlights, in file order, as one document
+func formatTeamResults(emails []string, results map[string]output.HighlightData, format output.Format) (string, error) {
+	var parts []string
+	for _, email := range emails {
+		data, ok := results[email]
+		if !ok {
+			continue
+		}
+		formatted, err := output.FormatHighlight(data, format)
+		if err != nil {
+			return "", fmt.Errorf("failed to format highlight for %s: %w", email, err)
+		}
+		if format == output.FormatText {
+			formatted = fmt.Sprintf("=== %s ===\n%s", email, formatted)
+		}
+		parts = append(parts, formatted)
+	}
+
+	switch format {
+	case output.FormatJSON:
+		return "[\n" + strings.Join(parts, ",\n") + "\n]\n", nil
+	case output.FormatCSV:
+		return joinCSV(parts), nil
+	default:
+		return strings.Join(parts, "\n"), nil
+	}
+}
+
+// joinCSV merges single-user CSV documents, keeping only the first header row
+func joinCSV(parts []string) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	for i, part := range parts {
+		records, err := csv.NewReader(strings.NewReader(part)).ReadAll()
+		if err != nil {
+			continue
+		}
+		if i > 0 && len(records) > 0 {
+			records = records[1:]
+		}
+		_ = w.WriteAll(records)
+	}
+	w.Flush()
+	return sb.String()
+}
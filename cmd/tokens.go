@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/secrets"
+)
+
+// secretKeys are the config keys fetched from the secrets provider
+var secretKeys = []string{"jira.token", "github.token"}
+
+// External command hooks, replaced in tests
+var (
+	lookPath        = exec.LookPath
+	runTokenCommand = func(name string, args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		command := exec.Command(name, args...)
+		command.Stdout = &stdout
+		command.Stderr = &stderr
+		if err := command.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("%w: %s", err, msg)
+			}
+			return "", err
+		}
+		return strings.TrimSpace(stdout.String()), nil
+	}
+)
+
+// resolveGitHubToken finds a GitHub token: --github-token flag or config, then
+// GITHUB_TOKEN, then `gh auth token` if the gh CLI is installed. An empty
+// result means unauthenticated access.
+func resolveGitHubToken() string {
+	if token := viper.GetString("github.token"); token != "" {
+		return token
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	if _, err := lookPath("gh"); err == nil {
+		if token, err := runTokenCommand("gh", "auth", "token"); err == nil && token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// resolveJiraToken finds the Jira token: --jira-token flag or config, then
+// JIRA_TOKEN, then the output of --jira-token-cmd (e.g. a password manager lookup)
+func resolveJiraToken() (string, error) {
+	if token := viper.GetString("jira.token"); token != "" {
+		return token, nil
+	}
+	if token := os.Getenv("JIRA_TOKEN"); token != "" {
+		return token, nil
+	}
+	if tokenCmd := viper.GetString("jira.token_cmd"); tokenCmd != "" {
+		token, err := runTokenCommand("sh", "-c", tokenCmd)
+		if err != nil {
+			return "", fmt.Errorf("jira token command failed: %w", err)
+		}
+		if token == "" {
+			return "", fmt.Errorf("jira token command printed nothing")
+		}
+		return token, nil
+	}
+	return "", nil
+}
+
+// applySecrets fetches tokens that are not set by flag or config from the
+// configured secrets.provider. Keys the provider doesn't have fall through to
+// the environment and token command lookups above.
+func applySecrets() error {
+	name := viper.GetString("secrets.provider")
+	if name == "" {
+		return nil
+	}
+
+	dir := viper.GetString("secrets.dir")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(home, ".perfdive", "secrets")
+	}
+	provider, err := secrets.New(name, secrets.Config{Dir: dir, EnvPrefix: "PERFDIVE_"})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range secretKeys {
+		if viper.GetString(key) != "" {
+			continue
+		}
+		value, err := provider.GetSecret(key)
+		if errors.Is(err, secrets.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s from %s secrets provider: %w", key, name, err)
+		}
+		viper.Set(key, value)
+	}
+	return nil
+}
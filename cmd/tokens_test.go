@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// stubTokenCommands replaces the external command hooks for one test
+func stubTokenCommands(t *testing.T, ghInstalled bool, output string, err error) *[]string {
+	t.Helper()
+	var calls []string
+	origLookPath, origRun := lookPath, runTokenCommand
+	lookPath = func(file string) (string, error) {
+		if ghInstalled {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	runTokenCommand = func(name string, args ...string) (string, error) {
+		calls = append(calls, name)
+		return output, err
+	}
+	t.Cleanup(func() {
+		lookPath, runTokenCommand = origLookPath, origRun
+	})
+	return &calls
+}
+
+func TestResolveGitHubToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		env         string
+		ghInstalled bool
+		ghOutput    string
+		ghErr       error
+		want        string
+		wantGhCall  bool
+	}{
+		{name: "config wins", config: "cfg", env: "env", ghInstalled: true, ghOutput: "gh", want: "cfg"},
+		{name: "env before gh", env: "env", ghInstalled: true, ghOutput: "gh", want: "env"},
+		{name: "gh auth token", ghInstalled: true, ghOutput: "gh", want: "gh", wantGhCall: true},
+		{name: "gh not logged in", ghInstalled: true, ghErr: errors.New("not logged in"), want: "", wantGhCall: true},
+		{name: "no gh", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := stubTokenCommands(t, tt.ghInstalled, tt.ghOutput, tt.ghErr)
+			t.Setenv("GITHUB_TOKEN", tt.env)
+			viper.Set("github.token", tt.config)
+
+			if got := resolveGitHubToken(); got != tt.want {
+				t.Errorf("resolveGitHubToken() = %q, want %q", got, tt.want)
+			}
+			if gotCall := len(*calls) > 0; gotCall != tt.wantGhCall {
+				t.Errorf("gh called = %v, want %v", gotCall, tt.wantGhCall)
+			}
+		})
+	}
+}
+
+func TestResolveJiraToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		env      string
+		tokenCmd string
+		cmdOut   string
+		cmdErr   error
+		want     string
+		wantErr  bool
+	}{
+		{name: "config wins", config: "cfg", env: "env", tokenCmd: "pass jira", cmdOut: "cmd", want: "cfg"},
+		{name: "env before command", env: "env", tokenCmd: "pass jira", cmdOut: "cmd", want: "env"},
+		{name: "token command", tokenCmd: "pass jira", cmdOut: "cmd", want: "cmd"},
+		{name: "token command fails", tokenCmd: "pass jira", cmdErr: errors.New("exit status 1"), wantErr: true},
+		{name: "token command prints nothing", tokenCmd: "pass jira", wantErr: true},
+		{name: "nothing configured", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubTokenCommands(t, false, tt.cmdOut, tt.cmdErr)
+			t.Setenv("JIRA_TOKEN", tt.env)
+			viper.Set("jira.token", tt.config)
+			viper.Set("jira.token_cmd", tt.tokenCmd)
+
+			got, err := resolveJiraToken()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveJiraToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveJiraToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySecrets(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "jira.token"), []byte("vaulted\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "github.token"), []byte("from-file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("secrets.provider", "file")
+	viper.Set("secrets.dir", dir)
+	viper.Set("jira.token", "")
+	viper.Set("github.token", "from-flag")
+	t.Cleanup(func() {
+		viper.Set("secrets.provider", "")
+		viper.Set("secrets.dir", "")
+		viper.Set("jira.token", "")
+		viper.Set("github.token", "")
+	})
+
+	if err := applySecrets(); err != nil {
+		t.Fatalf("applySecrets() error = %v", err)
+	}
+	if got := viper.GetString("jira.token"); got != "vaulted" {
+		t.Errorf("jira.token = %q, want the provider's value", got)
+	}
+	if got := viper.GetString("github.token"); got != "from-flag" {
+		t.Errorf("github.token = %q, want the flag to take precedence", got)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends [email]",
+	Short: "Show activity counts recorded by previous runs",
+	Long: `Show the activity counts (PRs created/merged, Jira issues created/updated)
+recorded for the last N periods, without fetching anything.
+
+Runs are recorded in ~/.perfdive/metrics.db when perfdive or perfdive highlight
+is run with --record-metrics (or metrics.record: true in the config file). A
+period that was run more than once shows its latest counts.
+
+Example:
+  perfdive highlight bpalm@redhat.com --period last-month --record-metrics
+  perfdive trends bpalm@redhat.com --last 6
+  perfdive trends bpalm@redhat.com --last 12 --output csv`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTrends,
+}
+
+func init() {
+	rootCmd.AddCommand(trendsCmd)
+
+	trendsCmd.Flags().Int("last", 6, "Number of most recent periods to show")
+	trendsCmd.Flags().StringP("output", "f", "text", "Output format (text, json, csv)")
+}
+
+func runTrends(cmd *cobra.Command, args []string) {
+	email := args[0]
+	last, _ := cmd.Flags().GetInt("last")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	// Input validation
+	if !strings.Contains(email, "@") {
+		fmt.Fprintf(os.Stderr, "Error: invalid email format '%s'\n", email)
+		os.Exit(1)
+	}
+	if last <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --last must be a positive number\n")
+		os.Exit(1)
+	}
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	store, err := openMetricsStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = store.Close() }()
+
+	runs, err := store.Last(email, last)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	formatted, err := output.FormatTrends(output.TrendsData{Email: email, Runs: runs}, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(formatted)
+	if !strings.HasSuffix(formatted, "\n") {
+		fmt.Println()
+	}
+}
+
+// openMetricsStore opens the metrics database at metrics.path, or ~/.perfdive/metrics.db
+func openMetricsStore() (*metrics.Store, error) {
+	path := viper.GetString("metrics.path")
+	if path == "" {
+		var err error
+		if path, err = metrics.DefaultStorePath(); err != nil {
+			return nil, err
+		}
+	}
+	return metrics.OpenStore(path)
+}
+
+// recordRun saves a run's counts when --record-metrics is enabled. Failures
+// only warn: the run's output matters more than its history.
+func recordRun(run metrics.Run, log logger.Logger) {
+	if !viper.GetBool("metrics.record") {
+		return
+	}
+	store, err := openMetricsStore()
+	if err != nil {
+		log.Printf("Warning: failed to open metrics store: %v\n", err)
+		return
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.Record(run); err != nil {
+		log.Printf("Warning: %v\n", err)
+		return
+	}
+	log.Infof("✓ Recorded metrics for %s (%s)\n", run.Email, run.Period)
+}
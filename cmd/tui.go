@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/ollama"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/tui"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [email] [period]",
+	Short: "Interactively browse Jira issues and PRs for a period",
+	Long: `Fetch a user's Jira issues and GitHub pull requests and browse them in an
+interactive terminal UI. Defaults to the last 7 days if no period is given.
+
+Keys:
+  j/k or arrows  move between items
+  enter          expand or collapse the selected item's details
+  s              generate or refresh the AI summary (requires ollama.url)
+  q              quit
+
+Example:
+  perfdive tui bpalm@redhat.com
+  perfdive tui bpalm@redhat.com last-month
+  perfdive tui bpalm@redhat.com q4-2024`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().IntP("days", "d", 7, "Number of days to look back when no period is given")
+}
+
+func runTUI(cmd *cobra.Command, args []string) {
+	email := args[0]
+	var period string
+	if len(args) == 2 {
+		period = args[1]
+	}
+	days, _ := cmd.Flags().GetInt("days")
+	log := newLogger(viper.GetInt("verbose"))
+
+	// Input validation: email format
+	if !strings.Contains(email, "@") {
+		fmt.Fprintf(os.Stderr, "Error: invalid email format '%s'\n", email)
+		os.Exit(1)
+	}
+
+	// Input validation: days must be positive
+	if days <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --days must be a positive number\n")
+		os.Exit(1)
+	}
+
+	// Input validation: API timeouts and model options
+	if _, _, err := apiTimeouts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := ollamaOptions(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := piiRedactor(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if viper.GetBool("preview_prompt") {
+		fmt.Fprintf(os.Stderr, "Error: --preview-prompt is not supported in the interactive browser\n")
+		os.Exit(1)
+	}
+
+	startDate, endDate, err := resolveDateRange(days, "", period, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Get configuration values
+	jiraURL := viper.GetString("jira.url")
+	jiraUsername := viper.GetString("jira.username")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jiraURL == "" || jiraUsername == "" || jiraToken == "" {
+		fmt.Fprintf(os.Stderr, "Error: Jira credentials required. Set via config file or flags.\n")
+		os.Exit(1)
+	}
+
+	// Fetch before the UI takes over the terminal; the summary is generated on demand
+	log.Printf("Fetching activity for %s...\n", email)
+	data, err := collectHighlight(email, dateparse.FormatForAPI(startDate), dateparse.FormatForAPI(endDate),
+		jiraURL, jiraUsername, jiraToken, "", resolveGitHubToken(), viper.GetString("github.username"), log, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	config := tui.Config{
+		Title: fmt.Sprintf("%s: %s to %s (%d Jira issues, %d PRs)", email,
+			dateparse.FormatForDisplay(data.StartDate), dateparse.FormatForDisplay(data.EndDate),
+			len(data.Issues), len(data.PullRequests)),
+		Issues:       data.Issues,
+		PullRequests: data.PullRequests,
+	}
+	if ollamaURL := viper.GetString("ollama.url"); ollamaURL != "" {
+		config.Summarize = tuiSummarizer(ollamaURL, email, data)
+	}
+
+	if err := tui.Run(config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// tuiSummarizer returns a function generating the biggest accomplishment for
+// the fetched data. It logs nothing, since the UI owns the terminal.
+func tuiSummarizer(ollamaURL, email string, data output.HighlightData) func() (string, error) {
+	return func() (string, error) {
+		transport, err := httpTransport()
+		if err != nil {
+			return "", err
+		}
+		_, ollamaTimeout, err := apiTimeouts()
+		if err != nil {
+			return "", err
+		}
+		modelOptions, err := ollamaOptions()
+		if err != nil {
+			return "", err
+		}
+		redactor, err := piiRedactor()
+		if err != nil {
+			return "", err
+		}
+		client := ollama.NewClient(ollama.Config{URL: ollamaURL, URLs: ollamaHosts(), Logger: logger.Nop(), Transport: transport, Timeout: ollamaTimeout, Options: modelOptions, Redactor: redactor})
+		runStats.track(nil, client)
+
+		model := viper.GetString("ollama.model")
+		if model == "" {
+			model = "llama3.2:latest"
+		}
+		activity := &ghclient.ComprehensiveUserActivity{PullRequests: data.PullRequests}
+		accomplishment, why, _, err := generateAccomplishmentSummary(client, data.Issues, activity, email, false, model, false)
+		if err != nil {
+			return "", err
+		}
+		if why != "" {
+			return fmt.Sprintf("%s\n\nWhy: %s", accomplishment, why), nil
+		}
+		return accomplishment, nil
+	}
+}
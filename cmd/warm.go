@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+	ghclient "github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm <email> <period>",
+	Short: "Pre-populate the cache for a user and period",
+	Long: `Run the Jira and GitHub fetches of a full analysis purely to populate the
+cache, without generating a summary. The period is any named period accepted by
+highlight --period (this-week, last-month, last-quarter, q4-2024, 2025-W03, ...).
+
+Warm the cache while you have rate limit budget, then generate reports later,
+or offline with a local Ollama, from the cached data:
+
+  perfdive cache warm user@company.com last-quarter`,
+	Args: cobra.ExactArgs(2),
+	Run:  runCacheWarm,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheWarmCmd)
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) {
+	log := newLogger(viper.GetInt("verbose"))
+	email := args[0]
+	if viper.GetBool("offline") {
+		fmt.Fprintf(os.Stderr, "Error: cache warm fetches from Jira and GitHub and cannot run --offline\n")
+		os.Exit(1)
+	}
+
+	start, end, err := resolveDateRange(0, "", args[1], log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	jiraURL := viper.GetString("jira.url")
+	jiraUsername := viper.GetString("jira.username")
+	jiraToken, err := resolveJiraToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jiraURL == "" || jiraUsername == "" || jiraToken == "" {
+		fmt.Fprintf(os.Stderr, "Error: Jira credentials required. Set via config file or flags.\n")
+		os.Exit(1)
+	}
+
+	if err := warmCaches(email, start, end, jiraURL, jiraUsername, jiraToken, resolveGitHubToken(), viper.GetString("github.username"), log); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// warmCaches runs the fetch half of a full analysis — enhanced Jira issues,
+// the GitHub PRs and issues they reference, and the user's GitHub activity
+// with PR details — so later runs are served from the cache
+func warmCaches(email string, start, end time.Time, jiraURL, jiraUsername, jiraToken, githubToken, githubUsername string, log logger.Logger) error {
+	verbose := log.Level() >= constants.VerbosityProgress
+	startDate, endDate := dateparse.FormatForAPI(start), dateparse.FormatForAPI(end)
+
+	lib.SetGlobalRateLimiter(lib.NewRateLimiter(time.Duration(viper.GetInt("rate_limit_delay"))*time.Millisecond, 3))
+
+	transport, err := httpTransport()
+	if err != nil {
+		return err
+	}
+	redactor, err := piiRedactor()
+	if err != nil {
+		return err
+	}
+	jiraRole, err := jira.ParseRole(viper.GetString("jira.role"))
+	if err != nil {
+		return err
+	}
+	jiraAuth, err := jiraAuthType(jiraURL)
+	if err != nil {
+		return err
+	}
+	jiraClient, err := jira.NewClient(jira.Config{
+		URL:       jiraURL,
+		Username:  jiraUsername,
+		Token:     jiraToken,
+		Logger:    log,
+		Role:      jiraRole,
+		AuthType:  jiraAuth,
+		Transport: transport,
+		Redactor:  redactor,
+		NoCache:   viper.GetBool("no_cache"),
+		MaxAge:    viper.GetDuration("cache.max_age"),
+
+		RefreshExpiredOnly: viper.GetBool("cache.refresh_expired_only"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Jira client: %w", err)
+	}
+	runStats.trackJira(jiraClient)
+	githubTimeout, _, err := apiTimeouts()
+	if err != nil {
+		return err
+	}
+	githubClient := ghclient.NewClient(githubConfig(githubToken, log, transport, githubTimeout, redactor))
+	runStats.track(githubClient, nil)
+
+	githubBefore, jiraBefore := cacheEntryCounts()
+	if githubToken != "" {
+		logRateLimitHeadroom(githubClient, "before warming", log)
+	}
+
+	log.Printf("Fetching Jira issues %s %s from %s to %s...\n", jiraRole.Description(), email, dateparse.FormatForDisplay(start), dateparse.FormatForDisplay(end))
+	issues, err := jiraClient.GetUserIssuesInDateRangeWithContext(email, startDate, endDate, true, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Jira issues: %w", err)
+	}
+	log.Printf("✓ Cached %d Jira issues with comments and history\n", len(issues))
+
+	log.Printf("Fetching GitHub PRs and issues referenced from Jira...\n")
+	githubContext, err := githubClient.FetchGitHubContextFromJiraIssues(jiraIssuesForGitHub(issues))
+	if err != nil {
+		log.Printf("Warning: failed to fetch GitHub context: %v\n", err)
+		githubContext = &ghclient.GitHubContext{}
+	}
+	log.Printf("✓ Cached %d referenced PRs and %d issues\n", len(githubContext.PullRequests), len(githubContext.Issues))
+
+	if githubToken == "" {
+		log.Printf("ℹ No GitHub token; skipping the user's GitHub activity\n")
+	} else {
+		username := githubUsername
+		if username == "" {
+			if username, err = githubClient.ResolveUsername(email); err != nil {
+				log.Printf("⚠ Could not find the GitHub user for %s: %v\n", email, err)
+			}
+		}
+		if username != "" {
+			log.Printf("Fetching GitHub activity for %s...\n", username)
+			activity, err := githubClient.FetchComprehensiveUserActivityWithCache(username, dateparse.FormatISO(start), dateparse.FormatISO(end), verbose)
+			if err != nil {
+				log.Printf("⚠ Could not fetch GitHub activity for %s: %v\n", username, err)
+			} else {
+				githubContext.ComprehensiveActivity = activity
+				log.Printf("Fetching details of %d authored pull requests...\n", len(activity.PullRequests))
+				githubClient.EnhanceAuthoredPullRequests(githubContext)
+				log.Printf("✓ Cached GitHub activity: %d PRs, %d issues\n", len(activity.PullRequests), len(activity.Issues))
+			}
+		}
+	}
+
+	if githubClient.RateLimitWaitExceeded() {
+		warnPartialGitHubData(log)
+	}
+	if err := githubClient.CircuitOpen(); err != nil {
+		warnGitHubCircuitOpen(log, err)
+	}
+	reportStaleCache(log, viper.GetDuration("cache.max_age"), jiraClient.StaleCacheEntries(), githubClient.StaleCacheEntries())
+
+	githubAfter, jiraAfter := cacheEntryCounts()
+	log.Printf("\n✓ Cache warmed: %d new GitHub entries, %d new Jira entries\n", max(githubAfter-githubBefore, 0), max(jiraAfter-jiraBefore, 0))
+	if githubToken != "" {
+		logRateLimitHeadroom(githubClient, "left", log)
+	}
+	return nil
+}
+
+// cacheEntryCounts returns the number of GitHub and Jira cache entries, 0 for
+// a cache that can't be opened
+func cacheEntryCounts() (github, jiraEntries int) {
+	if cache, err := ghclient.NewCache(); err == nil {
+		github = cache.GetCacheStats()["total"]
+	}
+	if cache, err := jira.NewCache(); err == nil {
+		jiraEntries, _ = cache.GetCacheStats()["total"].(int)
+	}
+	return github, jiraEntries
+}
+
+// logRateLimitHeadroom prints the remaining GitHub core and search rate limits
+func logRateLimitHeadroom(client *ghclient.Client, when string, log logger.Logger) {
+	status, err := client.GetRateLimitStatus()
+	if err != nil {
+		log.Printf("Warning: failed to read the GitHub rate limit: %v\n", err)
+		return
+	}
+	core, search := status.Resources.Core, status.Resources.Search
+	log.Printf("GitHub rate limit %s: %d/%d core requests, %d/%d searches (core resets at %s)\n", when,
+		core.Remaining, core.Limit, search.Remaining, search.Limit, time.Unix(core.Reset, 0).Format("15:04"))
+}
@@ -0,0 +1,64 @@
+package cachelog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
+)
+
+// ErrOffline is returned instead of a network call when a client is offline
+// and the data isn't in the cache
+var ErrOffline = errors.New("not in the cache (--offline)")
+
+// Log lists cache entries a client served or missed, for the warnings shown
+// after a run's fetches. Each key is listed once, in the order it was first
+// recorded. The zero value is ready to use.
+type Log struct {
+	mu      sync.Mutex
+	seen    map[string]bool
+	entries []string
+}
+
+// Record adds entry unless an entry was already recorded under key
+func (l *Log) Record(key, entry string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen[key] {
+		return
+	}
+	if l.seen == nil {
+		l.seen = make(map[string]bool)
+	}
+	l.seen[key] = true
+	l.entries = append(l.entries, entry)
+}
+
+// Entries returns the recorded entries
+func (l *Log) Entries() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.entries...)
+}
+
+// CheckAge records a cache hit on what, written at timestamp, if it is older
+// than maxAge (--max-age), e.g. "PR owner/repo#12, cached 3h20m ago". Nothing
+// is recorded on a nil Log or when maxAge isn't positive.
+func (l *Log) CheckAge(what string, timestamp time.Time, maxAge time.Duration) {
+	if l == nil || maxAge <= 0 {
+		return
+	}
+	if age := time.Since(timestamp); age > maxAge {
+		l.Record(what, fmt.Sprintf("%s, cached %s ago", what, dateparse.FormatAge(age)))
+	}
+}
+
+// Miss records what, an item an offline client couldn't serve from the cache
+// such as "PR owner/repo#12", and returns an error naming it that wraps
+// ErrOffline
+func (l *Log) Miss(what string) error {
+	l.Record(what, what)
+	return fmt.Errorf("%s: %w", what, ErrOffline)
+}
@@ -0,0 +1,43 @@
+package cachelog
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheckAge(t *testing.T) {
+	var log Log
+	now := time.Now()
+	log.CheckAge("PR o/r#1", now.Add(-3*time.Hour-20*time.Minute), time.Hour)
+	log.CheckAge("PR o/r#2", now.Add(-10*time.Minute), time.Hour)
+	log.CheckAge("PR o/r#1", now.Add(-3*time.Hour-20*time.Minute), time.Hour) // served again
+	log.CheckAge("PR o/r#3", now.Add(-5*time.Hour), 0)                        // check disabled
+
+	want := []string{"PR o/r#1, cached 3h20m ago"}
+	if got := log.Entries(); !slices.Equal(got, want) {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+
+	var none *Log
+	none.CheckAge("PR o/r#1", now.Add(-5*time.Hour), time.Hour) // must not panic
+}
+
+func TestMiss(t *testing.T) {
+	var log Log
+	err := log.Miss("PR o/r#1")
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("Miss() = %v, want it to wrap ErrOffline", err)
+	}
+	if want := "PR o/r#1: not in the cache (--offline)"; err.Error() != want {
+		t.Errorf("Miss() = %q, want %q", err, want)
+	}
+	log.Miss("issue o/r#2")
+	log.Miss("PR o/r#1") // requested again
+
+	want := []string{"PR o/r#1", "issue o/r#2"}
+	if got := log.Entries(); !slices.Equal(got, want) {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+// Package color adds ANSI colors to terminal output. Colors are only used when
+// writing to a terminal, and never when NO_COLOR is set or --no-color is given,
+// so redirected output and machine-readable formats stay plain. In ASCII mode
+// (--ascii, or a non-UTF-8 locale) the Unicode symbols are replaced too.
+package color
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	reset  = "\033[0m"
+	bold   = "\033[1m"
+	red    = "\033[31m"
+	green  = "\033[32m"
+	yellow = "\033[33m"
+	cyan   = "\033[36m"
+)
+
+// disabled is set by --no-color
+var disabled bool
+
+// SetDisabled turns colors off for the rest of the run (--no-color)
+func SetDisabled(d bool) {
+	disabled = d
+}
+
+// ascii is set by --ascii or a non-UTF-8 locale
+var ascii bool
+
+// SetASCII replaces Unicode symbols with ASCII for the rest of the run (--ascii)
+func SetASCII(a bool) {
+	ascii = a
+}
+
+// ASCII reports whether output uses ASCII symbols only
+func ASCII() bool {
+	return ascii
+}
+
+// LocaleIsUTF8 reports whether the locale from LC_ALL, LC_CTYPE or LANG (the
+// first one set) uses UTF-8. An unset locale is assumed to be UTF-8.
+func LocaleIsUTF8() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
+
+// Symbols replaces the Unicode symbols within s with ASCII equivalents in
+// ASCII mode, e.g. ✓ with [OK] and → with ->, and returns s unchanged otherwise
+func Symbols(s string) string {
+	if !ascii {
+		return s
+	}
+	return symbolReplacer.Replace(s)
+}
+
+var symbolReplacer = strings.NewReplacer(
+	"✓", "[OK]",
+	"⚠", "[WARN]",
+	"✗", "[FAIL]",
+	"ℹ", "[i]",
+	"→", "->",
+	"←", "<-",
+	"♻", "[~]",
+	"🏆", "*",
+	"💡", "[TIP]",
+	"…", "...",
+	"·", "-",
+	"–", "-",
+	"—", "-",
+	"×", "x",
+)
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Enabled reports whether output written to w should be colored
+func Enabled(w io.Writer) bool {
+	if disabled || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && IsTerminal(f)
+}
+
+// Palette colors strings for one destination; the zero value is colorless
+type Palette struct {
+	on bool
+}
+
+// For returns the palette for output written to w
+func For(w io.Writer) Palette {
+	return Palette{on: Enabled(w)}
+}
+
+func (p Palette) wrap(code, s string) string {
+	s = Symbols(s)
+	if !p.on || s == "" {
+		return s
+	}
+	return code + s + reset
+}
+
+// Success colors s green
+func (p Palette) Success(s string) string { return p.wrap(green, s) }
+
+// Warning colors s yellow
+func (p Palette) Warning(s string) string { return p.wrap(yellow, s) }
+
+// Error colors s red
+func (p Palette) Error(s string) string { return p.wrap(red, s) }
+
+// Info colors s cyan
+func (p Palette) Info(s string) string { return p.wrap(cyan, s) }
+
+// Bold makes s bold, for banners
+func (p Palette) Bold(s string) string { return p.wrap(bold, s) }
+
+// Markers colors the ✓, ⚠, ✗ and ℹ status markers within s, after
+// replacing its symbols in ASCII mode
+func (p Palette) Markers(s string) string {
+	s = Symbols(s)
+	if !p.on {
+		return s
+	}
+	if ascii {
+		return asciiMarkerReplacer.Replace(s)
+	}
+	return markerReplacer.Replace(s)
+}
+
+var markerReplacer = strings.NewReplacer(
+	"✓", green+"✓"+reset,
+	"⚠", yellow+"⚠"+reset,
+	"✗", red+"✗"+reset,
+	"ℹ", cyan+"ℹ"+reset,
+)
+
+var asciiMarkerReplacer = strings.NewReplacer(
+	"[OK]", green+"[OK]"+reset,
+	"[WARN]", yellow+"[WARN]"+reset,
+	"[FAIL]", red+"[FAIL]"+reset,
+	"[i]", cyan+"[i]"+reset,
+)
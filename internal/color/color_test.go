@@ -0,0 +1,83 @@
+package color
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPaletteMarkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		palette Palette
+		input   string
+		want    string
+	}{
+		{name: "disabled", palette: Palette{}, input: "✓ done", want: "✓ done"},
+		{name: "success", palette: Palette{on: true}, input: "  ✓ done", want: "  \033[32m✓\033[0m done"},
+		{name: "warning and error", palette: Palette{on: true}, input: "⚠ slow ✗ failed", want: "\033[33m⚠\033[0m slow \033[31m✗\033[0m failed"},
+		{name: "no markers", palette: Palette{on: true}, input: "plain", want: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.palette.Markers(tt.input); got != tt.want {
+				t.Errorf("Markers(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnabledNonTerminal(t *testing.T) {
+	if Enabled(&bytes.Buffer{}) {
+		t.Error("Enabled() = true for a buffer, want false")
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	SetASCII(true)
+	defer SetASCII(false)
+
+	tests := []struct {
+		name    string
+		palette Palette
+		input   string
+		want    string
+	}{
+		{name: "symbols", palette: Palette{}, input: "✓ done → next ℹ", want: "[OK] done -> next [i]"},
+		{name: "punctuation", palette: Palette{}, input: "2×PRs merged…", want: "2xPRs merged..."},
+		{name: "colored markers", palette: Palette{on: true}, input: "⚠ slow ✗ failed", want: "\033[33m[WARN]\033[0m slow \033[31m[FAIL]\033[0m failed"},
+		{name: "plain", palette: Palette{on: true}, input: "plain", want: "plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.palette.Markers(tt.input); got != tt.want {
+				t.Errorf("Markers(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleIsUTF8(t *testing.T) {
+	tests := []struct {
+		name                 string
+		lcAll, lcCtype, lang string
+		want                 bool
+	}{
+		{name: "unset", want: true},
+		{name: "utf-8 lang", lang: "en_US.UTF-8", want: true},
+		{name: "utf8 lang", lang: "de_DE.utf8", want: true},
+		{name: "C locale", lang: "C", want: false},
+		{name: "LC_ALL overrides LANG", lcAll: "POSIX", lang: "en_US.UTF-8", want: false},
+		{name: "LC_CTYPE overrides LANG", lcCtype: "en_US.UTF-8", lang: "C", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_CTYPE", tt.lcCtype)
+			t.Setenv("LANG", tt.lang)
+			if got := LocaleIsUTF8(); got != tt.want {
+				t.Errorf("LocaleIsUTF8() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,14 @@ const (
 
 	// DefaultIssueCacheTTL is the TTL for Jira issues and GitHub PRs/issues
 	DefaultIssueCacheTTL = 24 * time.Hour
+
+	// DefaultNegativeCacheTTL is how long a GitHub PR/issue that returned 404
+	// or 403 is skipped before being retried
+	DefaultNegativeCacheTTL = 1 * time.Hour
+
+	// DefaultUserCacheTTL is the TTL for email to GitHub username resolutions,
+	// which rarely change and cost rate-limited searches
+	DefaultUserCacheTTL = 7 * 24 * time.Hour
 )
 
 // Date formats
@@ -46,6 +54,14 @@ const (
 	// DefaultIssueCommentsLimit is the max number of issue comments to fetch
 	DefaultIssueCommentsLimit = 10
 
+	// DefaultJiraPromptComments is how many of each Jira issue's most recent
+	// comments are quoted in the summary prompt
+	DefaultJiraPromptComments = 3
+
+	// JiraCommentTokenBudget caps the estimated tokens all quoted Jira
+	// comments may take in one prompt
+	JiraCommentTokenBudget = 1500
+
 	// DefaultDiffSizeLimit is the max size of diff to fetch (5KB)
 	DefaultDiffSizeLimit = 5000
 
@@ -54,8 +70,33 @@ const (
 
 	// DefaultRateLimitDelay is the delay between Jira API requests in milliseconds
 	DefaultRateLimitDelay = 500
+
+	// GitHubCircuitBreakerThreshold is how many consecutive GitHub failures of
+	// the same class stop further GitHub requests for the run
+	GitHubCircuitBreakerThreshold = 5
 )
 
+// PR size buckets, modeled on GitHub's size labels. Each value is the
+// smallest PR of that size; a PR takes the larger of its line and file sizes.
+const (
+	// PRSizeSmallLines, PRSizeMediumLines, PRSizeLargeLines and PRSizeXLLines
+	// are thresholds on lines changed (additions + deletions). Smaller is XS.
+	PRSizeSmallLines  = 10
+	PRSizeMediumLines = 30
+	PRSizeLargeLines  = 100
+	PRSizeXLLines     = 500
+
+	// PRSizeSmallFiles, PRSizeMediumFiles, PRSizeLargeFiles and PRSizeXLFiles
+	// are thresholds on files changed
+	PRSizeSmallFiles  = 3
+	PRSizeMediumFiles = 6
+	PRSizeLargeFiles  = 15
+	PRSizeXLFiles     = 30
+)
+
+// GitHubAPIVersion is the REST API version sent as X-GitHub-Api-Version
+const GitHubAPIVersion = "2022-11-28"
+
 // Timeouts
 const (
 	// OllamaTimeout is the timeout for Ollama API requests
@@ -64,10 +105,28 @@ const (
 	// GitHubTimeout is the timeout for GitHub API requests
 	GitHubTimeout = 30 * time.Second
 
+	// JiraTimeout is the timeout for Jira API requests made without jiracrawler
+	JiraTimeout = 30 * time.Second
+
 	// OllamaTestTimeout is the timeout for Ollama connection test
 	OllamaTestTimeout = 30 * time.Second
 )
 
+// Verbosity levels (set via repeated -v flags)
+const (
+	// VerbosityQuiet suppresses progress and diagnostic output
+	VerbosityQuiet = 0
+
+	// VerbosityProgress shows high-level progress (-v)
+	VerbosityProgress = 1
+
+	// VerbosityRequests shows per-request information such as API URLs (-vv)
+	VerbosityRequests = 2
+
+	// VerbosityBodies shows full request and response bodies (-vvv)
+	VerbosityBodies = 3
+)
+
 // Cache directories
 const (
 	// CacheBaseDir is the base directory name for cache
@@ -75,4 +134,7 @@ const (
 
 	// CacheSubDir is the subdirectory for cache files
 	CacheSubDir = "cache"
+
+	// RunsSubDir is the subdirectory for resumable multi-user run state
+	RunsSubDir = "runs"
 )
@@ -3,6 +3,7 @@ package dateparse
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,20 +28,33 @@ type NamedPeriod struct {
 	EndDate   time.Time
 }
 
-// GetNamedPeriods returns available named periods based on current time
+// ParseWeekStart parses a week start day ("monday" or "sunday"); empty defaults to Monday
+func ParseWeekStart(s string) (time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "monday", "mon":
+		return time.Monday, nil
+	case "sunday", "sun":
+		return time.Sunday, nil
+	default:
+		return time.Monday, fmt.Errorf("invalid week start '%s': must be 'monday' or 'sunday'", s)
+	}
+}
+
+// GetNamedPeriods returns available named periods based on current time, with Monday-start weeks
 func GetNamedPeriods() map[string]NamedPeriod {
-	now := time.Now()
+	return GetNamedPeriodsAt(time.Now(), time.Monday)
+}
+
+// GetNamedPeriodsAt returns available named periods relative to now, with weeks starting on weekStart
+func GetNamedPeriodsAt(now time.Time, weekStart time.Weekday) map[string]NamedPeriod {
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	periods := make(map[string]NamedPeriod)
 
-	// This week (Sunday to Saturday, or Monday to Sunday depending on locale)
-	weekday := int(today.Weekday())
-	if weekday == 0 {
-		weekday = 7 // Sunday is 7
-	}
-	thisWeekStart := today.AddDate(0, 0, -weekday+1) // Monday
-	thisWeekEnd := thisWeekStart.AddDate(0, 0, 6)     // Sunday
+	// This week: days elapsed since the most recent weekStart (0 if today is weekStart)
+	offset := (int(today.Weekday()) - int(weekStart) + 7) % 7
+	thisWeekStart := today.AddDate(0, 0, -offset)
+	thisWeekEnd := thisWeekStart.AddDate(0, 0, 6)
 	periods["this-week"] = NamedPeriod{"This Week", thisWeekStart, thisWeekEnd}
 
 	// Last week
@@ -48,6 +62,11 @@ func GetNamedPeriods() map[string]NamedPeriod {
 	lastWeekEnd := thisWeekStart.AddDate(0, 0, -1)
 	periods["last-week"] = NamedPeriod{"Last Week", lastWeekStart, lastWeekEnd}
 
+	// ISO weeks always run Monday–Sunday, regardless of weekStart
+	thisISOWeekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	periods["this-iso-week"] = NamedPeriod{"This ISO Week", thisISOWeekStart, thisISOWeekStart.AddDate(0, 0, 6)}
+	periods["last-iso-week"] = NamedPeriod{"Last ISO Week", thisISOWeekStart.AddDate(0, 0, -7), thisISOWeekStart.AddDate(0, 0, -1)}
+
 	// This month
 	thisMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	thisMonthEnd := thisMonthStart.AddDate(0, 1, -1)
@@ -98,6 +117,31 @@ func GetNamedPeriods() map[string]NamedPeriod {
 	return periods
 }
 
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-?w(\d{1,2})$`)
+
+// ParseISOWeek parses an ISO 8601 week such as "2025-W03" (or "2025W03") and
+// returns that week's Monday and Sunday
+func ParseISOWeek(input string) (time.Time, time.Time, error) {
+	matches := isoWeekPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(input)))
+	if matches == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid ISO week '%s': expected format like 2025-W03", input)
+	}
+	year, _ := strconv.Atoi(matches[1])
+	week, _ := strconv.Atoi(matches[2])
+
+	// December 28 is always in the last ISO week of its year (52 or 53)
+	_, weeksInYear := time.Date(year, 12, 28, 0, 0, 0, 0, time.Local).ISOWeek()
+	if week < 1 || week > weeksInYear {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid ISO week '%s': %d has weeks 1-%d", input, year, weeksInYear)
+	}
+
+	// January 4 is always in ISO week 1; back up to that week's Monday
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.Local)
+	week1Monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	start := week1Monday.AddDate(0, 0, (week-1)*7)
+	return start, start.AddDate(0, 0, 6), nil
+}
+
 // ParseDate attempts to parse a date string using multiple formats
 // Returns the parsed time and the format used, or an error if parsing fails
 func ParseDate(input string) (time.Time, error) {
@@ -119,8 +163,8 @@ func ParseRelativeDate(input string) (time.Time, error) {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
-	// Handle "today" and "yesterday"
-	if input == "today" {
+	// Handle "today" (or "now") and "yesterday"
+	if input == "today" || input == "now" {
 		return today, nil
 	}
 	if input == "yesterday" {
@@ -182,25 +226,62 @@ func ParseDateOrRelative(input string) (time.Time, error) {
 		return t, nil
 	}
 
-	return time.Time{}, fmt.Errorf("unable to parse date '%s': try formats like '01-15-2025', '2025-01-15', 'last monday', or '2 weeks ago'", input)
+	// An ISO week means its Monday
+	if start, _, err := ParseISOWeek(input); err == nil {
+		return start, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date '%s': try formats like '01-15-2025', '2025-01-15', '2025-W03', 'last monday', or '2 weeks ago'", input)
+}
+
+// ParseEndDate parses the end of an open-ended range: empty means today,
+// otherwise anything ParseDateOrRelative accepts (including "today" and "now")
+func ParseEndDate(input string) (time.Time, error) {
+	if strings.TrimSpace(input) == "" {
+		return ParseRelativeDate("today")
+	}
+	return ParseDateOrRelative(input)
 }
 
 // ParseNamedPeriod parses a named period string and returns start and end dates
 func ParseNamedPeriod(name string) (time.Time, time.Time, error) {
+	return ParseNamedPeriodWithWeekStart(name, time.Monday)
+}
+
+// ParseNamedPeriodWithWeekStart parses a named period string, with weeks starting on weekStart
+func ParseNamedPeriodWithWeekStart(name string, weekStart time.Weekday) (time.Time, time.Time, error) {
 	name = strings.ToLower(strings.TrimSpace(name))
-	periods := GetNamedPeriods()
+	periods := GetNamedPeriodsAt(time.Now(), weekStart)
 
 	if period, ok := periods[name]; ok {
 		return period.StartDate, period.EndDate, nil
 	}
+	if isoWeekPattern.MatchString(name) {
+		return ParseISOWeek(name)
+	}
 
-	// List available periods for error message
-	available := make([]string, 0, len(periods))
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown period '%s': available periods are %s (see 'perfdive periods')", name, strings.Join(SortedPeriodKeys(periods), ", "))
+}
+
+// SortedPeriodKeys returns the keys of periods in chronological order: by
+// start date, longest first (a year before its Q1), then key, so the order is
+// stable across calls
+func SortedPeriodKeys(periods map[string]NamedPeriod) []string {
+	keys := make([]string, 0, len(periods))
 	for k := range periods {
-		available = append(available, k)
+		keys = append(keys, k)
 	}
-
-	return time.Time{}, time.Time{}, fmt.Errorf("unknown period '%s': available periods are %s", name, strings.Join(available[:8], ", "))
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := periods[keys[i]], periods[keys[j]]
+		if !a.StartDate.Equal(b.StartDate) {
+			return a.StartDate.Before(b.StartDate)
+		}
+		if !a.EndDate.Equal(b.EndDate) {
+			return a.EndDate.After(b.EndDate)
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
 }
 
 // FormatForDisplay formats a time.Time for display in output
@@ -218,6 +299,19 @@ func FormatISO(t time.Time) string {
 	return t.Format("2006-01-02")
 }
 
+// FormatAge formats a duration to the minute without zero units, e.g. "45m",
+// "3h20m" or "26h"; durations under a minute are shown in seconds
+func FormatAge(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	age := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(age, "h0m") {
+		age = strings.TrimSuffix(age, "0m")
+	}
+	return age
+}
+
 // ValidateDateRange ensures start date is before end date
 func ValidateDateRange(start, end time.Time) error {
 	if start.After(end) {
@@ -226,3 +320,50 @@ func ValidateDateRange(start, end time.Time) error {
 	}
 	return nil
 }
+
+// SplitRange divides the range from start to end (inclusive dates) into
+// calendar quarters or months, by is "quarter" or "month". The first and
+// last subranges are clipped to the range, keeping the full period's name,
+// e.g. "Q1 2025" or "January 2025".
+func SplitRange(start, end time.Time, by string) ([]NamedPeriod, error) {
+	months := 0
+	switch strings.ToLower(strings.TrimSpace(by)) {
+	case "quarter":
+		months = 3
+	case "month":
+		months = 1
+	default:
+		return nil, fmt.Errorf("invalid split '%s': must be quarter or month", by)
+	}
+	if err := ValidateDateRange(start, end); err != nil {
+		return nil, err
+	}
+
+	var periods []NamedPeriod
+	first := time.Month((int(start.Month())-1)/months*months + 1)
+	for periodStart := time.Date(start.Year(), first, 1, 0, 0, 0, 0, start.Location()); !periodStart.After(end); periodStart = periodStart.AddDate(0, months, 0) {
+		periodEnd := periodStart.AddDate(0, months, -1)
+		name := periodStart.Format("January 2006")
+		if months == 3 {
+			name = fmt.Sprintf("Q%d %d", (int(periodStart.Month())-1)/3+1, periodStart.Year())
+		}
+		periods = append(periods, NamedPeriod{Name: name, StartDate: latest(periodStart, start), EndDate: earliest(periodEnd, end)})
+	}
+	return periods, nil
+}
+
+// latest returns the later of a and b
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// earliest returns the earlier of a and b
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -1,6 +1,7 @@
 package dateparse
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -43,6 +44,7 @@ func TestParseRelativeDate(t *testing.T) {
 		wantErr  bool
 	}{
 		{"today", "today", today, false},
+		{"now", "now", today, false},
 		{"yesterday", "yesterday", today.AddDate(0, 0, -1), false},
 		{"1 day ago", "1 day ago", today.AddDate(0, 0, -1), false},
 		{"5 days ago", "5 days ago", today.AddDate(0, 0, -5), false},
@@ -91,6 +93,36 @@ func TestParseDateOrRelative(t *testing.T) {
 	}
 }
 
+func TestParseEndDate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{"empty means today", "", today, false},
+		{"today", "today", today, false},
+		{"now", "NOW", today, false},
+		{"absolute date", "2025-01-15", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), false},
+		{"invalid", "someday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEndDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEndDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.expected) {
+				t.Errorf("ParseEndDate(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseNamedPeriod(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -127,6 +159,148 @@ func TestParseNamedPeriod(t *testing.T) {
 	}
 }
 
+func TestGetNamedPeriodsAtWeekStart(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		weekStart     time.Weekday
+		thisWeekStart time.Time
+		thisWeekEnd   time.Time
+		lastWeekStart time.Time
+		lastWeekEnd   time.Time
+	}{
+		{"Monday start, mid-week", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC), time.Monday, day(13), day(19), day(6), day(12)},
+		{"Monday start, on Monday", day(13), time.Monday, day(13), day(19), day(6), day(12)},
+		{"Monday start, on Sunday", day(19), time.Monday, day(13), day(19), day(6), day(12)},
+		{"Sunday start, mid-week", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC), time.Sunday, day(12), day(18), day(5), day(11)},
+		{"Sunday start, on Sunday", day(19), time.Sunday, day(19), day(25), day(12), day(18)},
+		{"Sunday start, on Saturday", day(18), time.Sunday, day(12), day(18), day(5), day(11)},
+		{"Sunday start, on Monday", day(13), time.Sunday, day(12), day(18), day(5), day(11)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods := GetNamedPeriodsAt(tt.now, tt.weekStart)
+
+			thisWeek := periods["this-week"]
+			if !thisWeek.StartDate.Equal(tt.thisWeekStart) || !thisWeek.EndDate.Equal(tt.thisWeekEnd) {
+				t.Errorf("this-week = %s to %s, want %s to %s",
+					FormatISO(thisWeek.StartDate), FormatISO(thisWeek.EndDate), FormatISO(tt.thisWeekStart), FormatISO(tt.thisWeekEnd))
+			}
+
+			lastWeek := periods["last-week"]
+			if !lastWeek.StartDate.Equal(tt.lastWeekStart) || !lastWeek.EndDate.Equal(tt.lastWeekEnd) {
+				t.Errorf("last-week = %s to %s, want %s to %s",
+					FormatISO(lastWeek.StartDate), FormatISO(lastWeek.EndDate), FormatISO(tt.lastWeekStart), FormatISO(tt.lastWeekEnd))
+			}
+		})
+	}
+}
+
+func TestParseWeekStart(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{"", time.Monday, false},
+		{"monday", time.Monday, false},
+		{"Sunday", time.Sunday, false},
+		{"sun", time.Sunday, false},
+		{"friday", time.Monday, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWeekStart(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWeekStart(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseWeekStart(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	date := func(y int, m time.Month, d int) string {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		input   string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{"2025-W03", date(2025, 1, 13), date(2025, 1, 19), false},
+		{"2025w03", date(2025, 1, 13), date(2025, 1, 19), false},
+		// 2025 starts on a Wednesday, so W01 begins in December 2024
+		{"2025-W01", date(2024, 12, 30), date(2025, 1, 5), false},
+		// 2021 starts on a Friday, so its first days belong to 2020-W53
+		{"2021-W01", date(2021, 1, 4), date(2021, 1, 10), false},
+		{"2020-W53", date(2020, 12, 28), date(2021, 1, 3), false},
+		{"2026-W53", date(2026, 12, 28), date(2027, 1, 3), false},
+		{"2025-W53", "", "", true}, // 2025 has only 52 ISO weeks
+		{"2025-W00", "", "", true},
+		{"2025-03", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			start, end, err := ParseISOWeek(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISOWeek(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if FormatISO(start) != tt.start || FormatISO(end) != tt.end {
+				t.Errorf("ParseISOWeek(%q) = %s to %s, want %s to %s", tt.input, FormatISO(start), FormatISO(end), tt.start, tt.end)
+			}
+			if start.Weekday() != time.Monday {
+				t.Errorf("ParseISOWeek(%q) starts on %s, want Monday", tt.input, start.Weekday())
+			}
+		})
+	}
+}
+
+func TestISOWeekNamedPeriods(t *testing.T) {
+	// Wednesday; with Sunday-start weeks this-week differs from this-iso-week
+	now := time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)
+	periods := GetNamedPeriodsAt(now, time.Sunday)
+
+	tests := []struct {
+		name  string
+		start string
+		end   string
+	}{
+		{"this-iso-week", "2025-01-13", "2025-01-19"},
+		{"last-iso-week", "2025-01-06", "2025-01-12"},
+		{"this-week", "2025-01-12", "2025-01-18"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := periods[tt.name]
+			if FormatISO(p.StartDate) != tt.start || FormatISO(p.EndDate) != tt.end {
+				t.Errorf("%s = %s to %s, want %s to %s", tt.name, FormatISO(p.StartDate), FormatISO(p.EndDate), tt.start, tt.end)
+			}
+		})
+	}
+
+	start, end, err := ParseNamedPeriod("2025-W03")
+	if err != nil || FormatISO(start) != "2025-01-13" || FormatISO(end) != "2025-01-19" {
+		t.Errorf("ParseNamedPeriod(2025-W03) = %s to %s, %v", FormatISO(start), FormatISO(end), err)
+	}
+}
+
 func TestValidateDateRange(t *testing.T) {
 	now := time.Now()
 
@@ -178,4 +352,78 @@ func TestFormatFunctions(t *testing.T) {
 			t.Errorf("FormatISO() = %v, want %v", got, expected)
 		}
 	})
+
+	t.Run("FormatAge", func(t *testing.T) {
+		for d, expected := range map[time.Duration]string{
+			42 * time.Second:                  "42s",
+			90*time.Minute + 20*time.Second:   "1h30m",
+			3 * time.Hour:                     "3h",
+			50*time.Hour + 5*time.Minute + 31: "50h5m",
+		} {
+			if got := FormatAge(d); got != expected {
+				t.Errorf("FormatAge(%v) = %v, want %v", d, got, expected)
+			}
+		}
+	})
+}
+
+func TestSortedPeriodKeys(t *testing.T) {
+	periods := GetNamedPeriodsAt(time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC), time.Monday)
+	keys := SortedPeriodKeys(periods)
+	if len(keys) != len(periods) {
+		t.Fatalf("SortedPeriodKeys() returned %d keys, want %d", len(keys), len(periods))
+	}
+
+	// Same start: the longer period first
+	if keys[0] != "last-year" || keys[1] != "q1-2024" {
+		t.Errorf("first keys = %v, want last-year then q1-2024", keys[:2])
+	}
+	for i := 1; i < len(keys); i++ {
+		prev, cur := periods[keys[i-1]], periods[keys[i]]
+		if cur.StartDate.Before(prev.StartDate) {
+			t.Errorf("%s (%s) sorted after %s (%s)", keys[i], FormatISO(cur.StartDate), keys[i-1], FormatISO(prev.StartDate))
+		}
+	}
+	if again := SortedPeriodKeys(periods); strings.Join(again, ",") != strings.Join(keys, ",") {
+		t.Errorf("SortedPeriodKeys() order is not stable: %v vs %v", keys, again)
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tests := []struct {
+		name       string
+		start, end string
+		by         string
+		want       []string // name:start:end
+		wantErr    bool
+	}{
+		{"full year by quarter", "2025-01-01", "2025-12-31", "quarter",
+			[]string{"Q1 2025:2025-01-01:2025-03-31", "Q2 2025:2025-04-01:2025-06-30", "Q3 2025:2025-07-01:2025-09-30", "Q4 2025:2025-10-01:2025-12-31"}, false},
+		{"partial quarters are clipped", "2024-11-15", "2025-02-10", "Quarter",
+			[]string{"Q4 2024:2024-11-15:2024-12-31", "Q1 2025:2025-01-01:2025-02-10"}, false},
+		{"months", "2025-01-20", "2025-03-05", "month",
+			[]string{"January 2025:2025-01-20:2025-01-31", "February 2025:2025-02-01:2025-02-28", "March 2025:2025-03-01:2025-03-05"}, false},
+		{"single day", "2025-05-07", "2025-05-07", "month", []string{"May 2025:2025-05-07:2025-05-07"}, false},
+		{"invalid split", "2025-01-01", "2025-12-31", "week", nil, true},
+		{"reversed range", "2025-12-31", "2025-01-01", "quarter", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := SplitRange(date(tt.start), date(tt.end), tt.by)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, p := range periods {
+				got = append(got, p.Name+":"+FormatISO(p.StartDate)+":"+FormatISO(p.EndDate))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SplitRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
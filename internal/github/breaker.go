@@ -0,0 +1,90 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrCircuitOpen is returned without making a request once repeated failures
+// have tripped the client's circuit breaker
+var ErrCircuitOpen = errors.New("GitHub requests stopped after repeated failures")
+
+// circuitBreaker stops all further requests for the rest of the run after
+// threshold consecutive failures of the same class, so a sustained outage or
+// rate limit doesn't stack up slow retries across every reference
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	class     string // Class of the current failure streak
+	failures  int
+	tripped   error // Last failure once open; nil while closed
+}
+
+// allow returns ErrCircuitOpen once the breaker has tripped
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tripped != nil {
+		return fmt.Errorf("%w: %v", ErrCircuitOpen, b.tripped)
+	}
+	return nil
+}
+
+// record updates the failure streak with a request's outcome. Errors that
+// don't suggest an outage (e.g. 404) end the streak like a success.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tripped != nil {
+		return
+	}
+
+	class := failureClass(err)
+	if class == "" {
+		b.class, b.failures = "", 0
+		return
+	}
+	if class != b.class {
+		b.class, b.failures = class, 0
+	}
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.tripped = fmt.Errorf("%d consecutive %s failures, last: %w", b.failures, class, err)
+	}
+}
+
+// open returns why the breaker tripped, or nil while it is closed
+func (b *circuitBreaker) open() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tripped
+}
+
+// failureClass groups errors that indicate GitHub can't serve requests right
+// now; it returns "" for other errors
+func failureClass(err error) string {
+	var urlErr *url.Error
+	switch {
+	case err == nil:
+		return ""
+	case isRateLimitError(err):
+		return "rate limit"
+	case isSecondaryRateLimitError(err):
+		return "secondary rate limit"
+	case strings.Contains(err.Error(), "GitHub API returned status 5"):
+		return "server error"
+	case errors.As(err, &urlErr):
+		return "network"
+	default:
+		return ""
+	}
+}
+
+// CircuitOpen reports why GitHub requests were short-circuited for the rest of
+// the run, meaning the fetched data is partial; nil if they never were
+func (c *Client) CircuitOpen() error {
+	return c.breaker.open()
+}
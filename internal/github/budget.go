@@ -0,0 +1,48 @@
+package github
+
+import (
+	"strings"
+	"sync"
+)
+
+// repoBudget counts the requests made for each repository in a run, so
+// enhanced fetches can stop spending rate limit on one busy repository
+// (--repo-request-budget)
+type repoBudget struct {
+	limit int // 0 = no limit
+
+	mu    sync.Mutex
+	calls map[string]int // By lowercased owner/name
+}
+
+// countCall records an API request to url in the client's Stats and, for
+// repository endpoints, in the repository's request count
+func (c *Client) countCall(url string) {
+	c.counters.apiCalls.Add(1)
+
+	path, ok := strings.CutPrefix(strings.TrimPrefix(url, c.baseURL), "/repos/")
+	if !ok {
+		return
+	}
+	segments := strings.SplitN(path, "/", 3)
+	if len(segments) < 2 {
+		return
+	}
+	key := strings.ToLower(segments[0] + "/" + segments[1])
+
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	if c.budget.calls == nil {
+		c.budget.calls = make(map[string]int)
+	}
+	c.budget.calls[key]++
+}
+
+// repoBudgetSpent reports the requests made for owner/repo so far and whether
+// they have reached the per-repository budget
+func (c *Client) repoBudgetSpent(owner, repo string) (int, bool) {
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	calls := c.budget.calls[strings.ToLower(owner+"/"+repo)]
+	return calls, c.budget.limit > 0 && calls >= c.budget.limit
+}
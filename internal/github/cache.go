@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
 )
 
 // Cache handles caching of GitHub activity data
@@ -17,8 +22,25 @@ type Cache struct {
 	metadata     *CacheMetadata
 	metadataPath string
 	mu           sync.RWMutex
+	counters     *counters     // Hit/miss counters of the owning client, if any
+	allowExpired bool          // Serve expired entries (offline mode)
+	bypass       bool          // Serve nothing, so every lookup refetches (--no-cache)
+	maxAge       time.Duration // Entries served older than this are recorded in staleServed (--max-age)
+	staleServed  *cachelog.Log
+
+	// changed holds the metadata paths set or removed since the last save,
+	// cleared whether every entry was removed, and hits and misses the
+	// lookups not yet added to the persisted counts
+	changed map[string]bool
+	cleared bool
+	hits    int
+	misses  int
 }
 
+// metadataFileMu serializes the read-merge-write of metadata files by the
+// caches of this process
+var metadataFileMu sync.Mutex
+
 // CacheEntry represents a cached item with expiration
 type CacheEntry struct {
 	Data      *ComprehensiveUserActivity `json:"data"`
@@ -35,6 +57,10 @@ type PRCacheEntry struct {
 	Owner     string       `json:"owner"`
 	Repo      string       `json:"repo"`
 	Number    string       `json:"number"`
+
+	// Basic records that Data has only the PR's own fields, without reviews,
+	// files or diff; GetPR skips such entries
+	Basic bool `json:"basic,omitempty"`
 }
 
 // IssueCacheEntry represents a cached Issue
@@ -46,16 +72,26 @@ type IssueCacheEntry struct {
 	Number    string    `json:"number"`
 }
 
-// CacheMetadata tracks all cache entries with their expiration
+// UserCacheEntry represents a cached email to username resolution. The
+// email isn't stored; entries are named by its hash.
+type UserCacheEntry struct {
+	Username  string    `json:"username"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CacheMetadata tracks all cache entries with their expiration, and lookup
+// hits and misses across runs
 type CacheMetadata struct {
 	Entries map[string]CacheMetadataEntry `json:"entries"`
+	Hits    int                           `json:"hits"`
+	Misses  int                           `json:"misses"`
 }
 
 // CacheMetadataEntry represents metadata for a single cache entry
 type CacheMetadataEntry struct {
 	Created time.Time `json:"created"`
 	Expires time.Time `json:"expires"`
-	Type    string    `json:"type"` // "activity", "pr", "issue"
+	Type    string    `json:"type"` // "activity", "pr", "issue", "negative", "user"
 	Key     string    `json:"key"`  // Identifier (e.g., "owner/repo#123")
 }
 
@@ -75,8 +111,9 @@ func NewCache() (*Cache, error) {
 	activityDir := filepath.Join(cacheDir, "activity")
 	prsDir := filepath.Join(cacheDir, "prs")
 	issuesDir := filepath.Join(cacheDir, "issues")
+	usersDir := filepath.Join(cacheDir, "users")
 	
-	for _, dir := range []string{activityDir, prsDir, issuesDir} {
+	for _, dir := range []string{activityDir, prsDir, issuesDir, usersDir} {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return nil, err
 		}
@@ -120,17 +157,65 @@ func (c *Cache) loadMetadata() error {
 	return json.Unmarshal(data, c.metadata)
 }
 
-// saveMetadata saves cache metadata to disk
+// saveMetadata writes the entries this cache changed and its unsaved lookup
+// counts into the metadata on disk. The file is re-read first, so entries
+// and counts other caches saved since this one loaded are kept.
 func (c *Cache) saveMetadata() error {
+	metadataFileMu.Lock()
+	defer metadataFileMu.Unlock()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	data, err := json.MarshalIndent(c.metadata, "", "  ")
+	merged := &CacheMetadata{}
+	if data, err := os.ReadFile(c.metadataPath); err == nil {
+		_ = json.Unmarshal(data, merged)
+	}
+	if merged.Entries == nil || c.cleared {
+		merged.Entries = make(map[string]CacheMetadataEntry)
+	}
+	for path := range c.changed {
+		if entry, ok := c.metadata.Entries[path]; ok {
+			merged.Entries[path] = entry
+		} else {
+			delete(merged.Entries, path)
+		}
+	}
+	merged.Hits += c.hits
+	merged.Misses += c.misses
+
+	data, err := json.MarshalIndent(merged, "", "  ")
 	if err != nil {
 		return err
 	}
+	if err := os.WriteFile(c.metadataPath, data, 0644); err != nil {
+		return err
+	}
+
+	c.metadata = merged
+	c.changed, c.cleared = nil, false
+	c.hits, c.misses = 0, 0
+	return nil
+}
+
+// markChanged records that the metadata entry at path was set or removed;
+// the caller holds c.mu
+func (c *Cache) markChanged(path string) {
+	if c.changed == nil {
+		c.changed = make(map[string]bool)
+	}
+	c.changed[path] = true
+}
 
-	return os.WriteFile(c.metadataPath, data, 0644)
+// SaveCounts adds the lookups counted since the last save to the hit and
+// miss totals persisted in the metadata
+func (c *Cache) SaveCounts() error {
+	c.mu.RLock()
+	unsaved := c.hits > 0 || c.misses > 0
+	c.mu.RUnlock()
+	if !unsaved {
+		return nil
+	}
+	return c.saveMetadata()
 }
 
 // updateMetadata adds or updates a metadata entry
@@ -145,6 +230,7 @@ func (c *Cache) updateMetadata(path, entryType, key string, ttl time.Duration) {
 		Type:    entryType,
 		Key:     key,
 	}
+	c.markChanged(path)
 }
 
 // isExpired checks if a cache entry is expired based on metadata
@@ -157,11 +243,38 @@ func (c *Cache) isExpired(path string) bool {
 		return true
 	}
 
-	return time.Now().After(entry.Expires)
+	return !c.allowExpired && time.Now().After(entry.Expires)
+}
+
+// stale reports whether an entry written at timestamp is past ttl; nothing
+// is stale when expired entries are allowed
+func (c *Cache) stale(timestamp time.Time, ttl time.Duration) bool {
+	return !c.allowExpired && time.Since(timestamp) > ttl
+}
+
+// cacheDate normalizes a date to ISO (YYYY-MM-DD), so a range keys the same
+// cache entry whichever format it was given in. Dates that don't parse are
+// returned unchanged.
+func cacheDate(date string) string {
+	t, err := dateparse.ParseDate(date)
+	if err != nil {
+		return date
+	}
+	return dateparse.FormatISO(t)
+}
+
+// ActivityKey returns the metadata key of the user's activity for the date range
+func ActivityKey(username, startDate, endDate string) string {
+	return fmt.Sprintf("%s_%s_%s", username, cacheDate(startDate), cacheDate(endDate))
 }
 
 // Get retrieves cached data if it exists and is not expired
-func (c *Cache) Get(username, startDate, endDate string) (*ComprehensiveUserActivity, bool) {
+func (c *Cache) Get(username, startDate, endDate string) (_ *ComprehensiveUserActivity, found bool) {
+	if c.bypass {
+		return nil, false
+	}
+	defer func() { c.countLookup(found) }()
+	startDate, endDate = cacheDate(startDate), cacheDate(endDate)
 	cacheFile := filepath.Join(c.cacheDir, "activity", c.getCacheKey(username, startDate, endDate))
 	relativePath := filepath.Join("activity", c.getCacheKey(username, startDate, endDate))
 
@@ -182,21 +295,23 @@ func (c *Cache) Get(username, startDate, endDate string) (*ComprehensiveUserActi
 	}
 
 	// Double-check with embedded timestamp
-	if time.Since(entry.Timestamp) > c.ttl {
+	if c.stale(entry.Timestamp, c.ttl) {
 		_ = os.Remove(cacheFile)
 		return nil, false
 	}
 
 	// Verify it's the right data
-	if entry.Username != username || entry.StartDate != startDate || entry.EndDate != endDate {
+	if entry.Username != username || cacheDate(entry.StartDate) != startDate || cacheDate(entry.EndDate) != endDate {
 		return nil, false
 	}
 
+	c.staleServed.CheckAge(fmt.Sprintf("GitHub activity of %s from %s to %s", username, startDate, endDate), entry.Timestamp, c.maxAge)
 	return entry.Data, true
 }
 
 // Set stores data in the cache
 func (c *Cache) Set(username, startDate, endDate string, data *ComprehensiveUserActivity) error {
+	startDate, endDate = cacheDate(startDate), cacheDate(endDate)
 	entry := CacheEntry{
 		Data:      data,
 		Timestamp: time.Now(),
@@ -218,13 +333,27 @@ func (c *Cache) Set(username, startDate, endDate string, data *ComprehensiveUser
 	}
 
 	// Update metadata
-	key := fmt.Sprintf("%s_%s_%s", username, startDate, endDate)
-	c.updateMetadata(relativePath, "activity", key, c.ttl)
+	c.updateMetadata(relativePath, "activity", ActivityKey(username, startDate, endDate), c.ttl)
 	return c.saveMetadata()
 }
 
 // GetPR retrieves a cached Pull Request if it exists and is not expired (24-hour TTL)
 func (c *Cache) GetPR(owner, repo, number string) (*PullRequest, bool) {
+	return c.getPR(owner, repo, number, false)
+}
+
+// GetBasicPR retrieves a cached Pull Request like GetPR, but also matches
+// entries cached by SetBasicPR without enhanced context
+func (c *Cache) GetBasicPR(owner, repo, number string) (*PullRequest, bool) {
+	return c.getPR(owner, repo, number, true)
+}
+
+// getPR loads an unexpired PR entry, basic entries only if basic is set
+func (c *Cache) getPR(owner, repo, number string, basic bool) (_ *PullRequest, found bool) {
+	if c.bypass {
+		return nil, false
+	}
+	defer func() { c.countLookup(found) }()
 	filename := fmt.Sprintf("%s_%s_%s.json", owner, repo, number)
 	cacheFile := filepath.Join(c.cacheDir, "prs", filename)
 	relativePath := filepath.Join("prs", filename)
@@ -246,22 +375,38 @@ func (c *Cache) GetPR(owner, repo, number string) (*PullRequest, bool) {
 	}
 
 	// Double-check with embedded timestamp (24-hour TTL)
-	if time.Since(entry.Timestamp) > 24*time.Hour {
+	if c.stale(entry.Timestamp, 24*time.Hour) {
 		_ = os.Remove(cacheFile)
 		return nil, false
 	}
+	if entry.Basic && !basic {
+		return nil, false
+	}
 
+	c.staleServed.CheckAge(fmt.Sprintf("PR %s/%s#%s", owner, repo, number), entry.Timestamp, c.maxAge)
 	return entry.Data, true
 }
 
 // SetPR stores a Pull Request in the cache with 24-hour TTL
 func (c *Cache) SetPR(owner, repo, number string, data *PullRequest) error {
+	return c.setPR(owner, repo, number, data, false)
+}
+
+// SetBasicPR stores a Pull Request fetched without reviews, files or diff,
+// which only GetBasicPR serves
+func (c *Cache) SetBasicPR(owner, repo, number string, data *PullRequest) error {
+	return c.setPR(owner, repo, number, data, true)
+}
+
+// setPR writes a PR entry with 24-hour TTL
+func (c *Cache) setPR(owner, repo, number string, data *PullRequest, basic bool) error {
 	entry := PRCacheEntry{
 		Data:      data,
 		Timestamp: time.Now(),
 		Owner:     owner,
 		Repo:      repo,
 		Number:    number,
+		Basic:     basic,
 	}
 
 	jsonData, err := json.Marshal(entry)
@@ -284,7 +429,11 @@ func (c *Cache) SetPR(owner, repo, number string, data *PullRequest) error {
 }
 
 // GetIssue retrieves a cached Issue if it exists and is not expired (24-hour TTL)
-func (c *Cache) GetIssue(owner, repo, number string) (*Issue, bool) {
+func (c *Cache) GetIssue(owner, repo, number string) (_ *Issue, found bool) {
+	if c.bypass {
+		return nil, false
+	}
+	defer func() { c.countLookup(found) }()
 	filename := fmt.Sprintf("%s_%s_%s.json", owner, repo, number)
 	cacheFile := filepath.Join(c.cacheDir, "issues", filename)
 	relativePath := filepath.Join("issues", filename)
@@ -306,11 +455,12 @@ func (c *Cache) GetIssue(owner, repo, number string) (*Issue, bool) {
 	}
 
 	// Double-check with embedded timestamp (24-hour TTL)
-	if time.Since(entry.Timestamp) > 24*time.Hour {
+	if c.stale(entry.Timestamp, 24*time.Hour) {
 		_ = os.Remove(cacheFile)
 		return nil, false
 	}
 
+	c.staleServed.CheckAge(fmt.Sprintf("issue %s/%s#%s", owner, repo, number), entry.Timestamp, c.maxAge)
 	return entry.Data, true
 }
 
@@ -343,10 +493,91 @@ func (c *Cache) SetIssue(owner, repo, number string, data *Issue) error {
 	return c.saveMetadata()
 }
 
+// userPath returns the path of an email's username entry, named by the
+// email's hash so cache file names don't expose addresses. The org is part
+// of the key since github.org changes which user an email resolves to.
+func userPath(email, org string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email)) + "|" + strings.ToLower(org)))
+	return filepath.Join("users", fmt.Sprintf("%x.json", hash[:8]))
+}
+
+// GetUsername retrieves the cached GitHub username of an email, resolved with
+// github.org set to org, if it exists and is not expired (7-day TTL)
+func (c *Cache) GetUsername(email, org string) (_ string, found bool) {
+	if c.bypass {
+		return "", false
+	}
+	defer func() { c.countLookup(found) }()
+	relativePath := userPath(email, org)
+	cacheFile := filepath.Join(c.cacheDir, relativePath)
+
+	if c.isExpired(relativePath) {
+		_ = os.Remove(cacheFile)
+		return "", false
+	}
+
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return "", false
+	}
+
+	var entry UserCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Username == "" {
+		return "", false
+	}
+	if c.stale(entry.Timestamp, constants.DefaultUserCacheTTL) {
+		_ = os.Remove(cacheFile)
+		return "", false
+	}
+
+	return entry.Username, true
+}
+
+// SetUsername stores the GitHub username an email resolved to with github.org
+// set to org, with a 7-day TTL
+func (c *Cache) SetUsername(email, org, username string) error {
+	jsonData, err := json.Marshal(UserCacheEntry{Username: username, Timestamp: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	relativePath := userPath(email, org)
+	if err := os.WriteFile(filepath.Join(c.cacheDir, relativePath), jsonData, 0644); err != nil {
+		return err
+	}
+
+	c.updateMetadata(relativePath, "user", username, constants.DefaultUserCacheTTL)
+	return c.saveMetadata()
+}
+
+// negativePath returns the metadata path of a negative entry. Negative
+// entries live only in the metadata; there is no file at this path.
+func negativePath(refType, owner, repo, number string) string {
+	return filepath.Join("negative", fmt.Sprintf("%s_%s_%s_%s", refType, owner, repo, number))
+}
+
+// IsUnavailable reports whether a PR or issue ("pull" or "issues") recently
+// returned 404 or 403 and should not be refetched yet
+func (c *Cache) IsUnavailable(refType, owner, repo, number string) bool {
+	path := negativePath(refType, owner, repo, number)
+	c.mu.RLock()
+	_, exists := c.metadata.Entries[path]
+	c.mu.RUnlock()
+	return exists && !c.bypass && !c.isExpired(path)
+}
+
+// SetUnavailable records that a PR or issue returned 404 or 403. The short
+// TTL lets a later run retry, e.g. after access to a private repo is granted.
+func (c *Cache) SetUnavailable(refType, owner, repo, number string) error {
+	key := fmt.Sprintf("%s/%s#%s (%s)", owner, repo, number, refType)
+	c.updateMetadata(negativePath(refType, owner, repo, number), "negative", key, constants.DefaultNegativeCacheTTL)
+	return c.saveMetadata()
+}
+
 // Clear removes all cached entries
 func (c *Cache) Clear() error {
 	// Clear all subdirectories
-	for _, subdir := range []string{"activity", "prs", "issues"} {
+	for _, subdir := range []string{"activity", "prs", "issues", "users"} {
 		dirPath := filepath.Join(c.cacheDir, subdir)
 		entries, err := os.ReadDir(dirPath)
 		if err != nil {
@@ -363,6 +594,7 @@ func (c *Cache) Clear() error {
 	// Clear metadata
 	c.mu.Lock()
 	c.metadata.Entries = make(map[string]CacheMetadataEntry)
+	c.changed, c.cleared = nil, true
 	c.mu.Unlock()
 	
 	return c.saveMetadata()
@@ -370,34 +602,52 @@ func (c *Cache) Clear() error {
 
 // CleanExpired removes expired cache entries based on metadata
 func (c *Cache) CleanExpired() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	_, err := c.removeExpired(func(CacheMetadataEntry) bool { return true })
+	return err
+}
+
+// CleanExpiredKeys removes the expired entries of entryType ("activity", "pr"
+// or "issue") whose metadata key is in keys, leaving valid entries and other
+// keys untouched. It returns the number of entries removed.
+func (c *Cache) CleanExpiredKeys(entryType string, keys []string) (int, error) {
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	return c.removeExpired(func(entry CacheMetadataEntry) bool {
+		return entry.Type == entryType && wanted[entry.Key]
+	})
+}
 
+// removeExpired deletes the expired entries accepted by match and returns how many were removed
+func (c *Cache) removeExpired(match func(CacheMetadataEntry) bool) (int, error) {
+	c.mu.Lock()
 	now := time.Now()
 	toDelete := []string{}
 
-	// Find all expired entries in metadata
+	// Find all matching expired entries in metadata
 	for path, entry := range c.metadata.Entries {
-		if now.After(entry.Expires) {
+		if now.After(entry.Expires) && match(entry) {
 			toDelete = append(toDelete, path)
-			
+
 			// Delete the actual cache file
-			filePath := filepath.Join(c.cacheDir, path)
-			_ = os.Remove(filePath)
+			_ = os.Remove(filepath.Join(c.cacheDir, path))
 		}
 	}
 
 	// Remove from metadata
 	for _, path := range toDelete {
 		delete(c.metadata.Entries, path)
+		c.markChanged(path)
 	}
+	c.mu.Unlock()
 
 	// Save updated metadata if any entries were deleted
 	if len(toDelete) > 0 {
-		return c.saveMetadata()
+		return len(toDelete), c.saveMetadata()
 	}
 
-	return nil
+	return 0, nil
 }
 
 // GetCacheStats returns statistics about the cache
@@ -409,12 +659,18 @@ func (c *Cache) GetCacheStats() map[string]int {
 		"activity": 0,
 		"prs":      0,
 		"issues":   0,
+		"negative": 0,
+		"users":    0,
 		"total":    len(c.metadata.Entries),
+		"hits":     c.metadata.Hits + c.hits,
+		"misses":   c.metadata.Misses + c.misses,
 	}
 
+	// Entry types are singular; the stats keys are plural
+	statKeys := map[string]string{"activity": "activity", "pr": "prs", "issue": "issues", "negative": "negative", "user": "users"}
 	for _, entry := range c.metadata.Entries {
-		if count, ok := stats[entry.Type]; ok {
-			stats[entry.Type] = count + 1
+		if key, ok := statKeys[entry.Type]; ok {
+			stats[key]++
 		}
 	}
 
@@ -4,25 +4,185 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/httpclient"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/redact"
 )
 
 // Client wraps GitHub API functionality
 type Client struct {
-	baseURL    string
-	token      string
-	httpClient *http.Client
-	rateLimitRemaining int
-	rateLimitReset     time.Time
+	baseURL         string
+	token           string
+	emailMap        map[string]string
+	org             string
+	redactor        *redact.Redactor
+	fetchCommits    bool
+	excludeDraftPRs bool
+	includeBotPRs   bool
+	botLogins       map[string]bool
+	activityTypes   map[string]bool // Lowercased event types to keep; nil keeps all
+	maxReferences   int
+	apiVersion      string
+	pageSize        int
+	httpClient      *http.Client
+	log             logger.Logger
+	rateLimit       rateLimitState
+	scopesMu        sync.Mutex
+	tokenScopes     []string
+	scopesKnown     bool
+	maxWait         time.Duration
+	confirmWait     func(wait time.Duration) bool
+	breaker         circuitBreaker
+	counters        counters
+	cacheOnce       sync.Once
+	cache           *Cache // Opened on first use by openCache
+	cacheErr        error
+	onPage          PageFunc
+	inflight        singleflight.Group // Shares concurrent fetches of the same resource
+	offline         bool
+	misses          cachelog.Log
+	noCache         bool
+	maxAge          time.Duration
+	staleServed     cachelog.Log
+	budget          repoBudget
+	diffRedaction   diffRedaction
+	diffSizeLimit   int
+	patchSizeLimit  int
+
+	reviewCommentsLimit int
+	issueCommentsLimit  int
+	refreshExpiredOnly  bool
 }
 
 // Config holds GitHub client configuration
 type Config struct {
-	Token string // GitHub personal access token (optional for public repos)
-}
+	Token  string        // GitHub personal access token (optional for public repos)
+	Logger logger.Logger // Diagnostic logger (defaults to stderr)
+
+	// Transport for API requests (defaults to a proxy-aware transport; see httpclient.NewTransport)
+	Transport http.RoundTripper
+
+	// Timeout for each API request (defaults to constants.GitHubTimeout)
+	Timeout time.Duration
+
+	// ExcludeDraftPRs drops draft PRs from the user's activity (--include-draft-prs=false)
+	ExcludeDraftPRs bool
+
+	// IncludeBotPRs keeps PRs authored by bots (--include-bot-prs). By default
+	// they are dropped from the user's activity and from Jira references.
+	IncludeBotPRs bool
+
+	// BotLogins lists extra bot accounts (config github.bot_logins); logins
+	// ending in [bot] are always treated as bots
+	BotLogins []string
+
+	// ActivityTypes lists the event types kept in the user's activity
+	// (--github-activity-types); empty keeps DefaultActivityTypes and "all"
+	// keeps every type
+	ActivityTypes []string
+
+	// MaxReferences caps how many Jira-referenced PRs and issues are fetched
+	// (--max-references; 0 = no limit). PRs are fetched before issues.
+	MaxReferences int
+
+	// RepoRequestBudget caps the requests made for one repository in a run
+	// (--repo-request-budget; 0 = no limit). Once a repository reaches it,
+	// further PRs from it are fetched without reviews, files or diff.
+	RepoRequestBudget int
+
+	// RedactDiffs withholds the code of every repository (--redact-diffs):
+	// PR diffs aren't fetched and file patches are dropped before caching,
+	// keeping the file list and line counts. RedactDiffRepos does the same for
+	// the listed owner/name or owner/* repositories (github.redact_diff_repos).
+	RedactDiffs     bool
+	RedactDiffRepos []string
+
+	// DiffSizeLimit and PatchSizeLimit cap the bytes of each PR diff and of
+	// each changed file's patch kept for the model, cut at a line break
+	// (api.diff_size_limit, api.patch_size_limit); 0 uses the defaults
+	DiffSizeLimit  int
+	PatchSizeLimit int
+
+	// ReviewCommentsLimit and IssueCommentsLimit cap the review comments kept
+	// per PR, the most recent, and the comments kept per issue, the first
+	// (api.review_comments_limit, api.issue_comments_limit); 0 uses the defaults
+	ReviewCommentsLimit int
+	IssueCommentsLimit  int
+
+	// BaseURL overrides the GitHub API base URL (defaults to https://api.github.com)
+	BaseURL string
+
+	// APIVersion is sent as the X-GitHub-Api-Version header (--github-api-version;
+	// defaults to constants.GitHubAPIVersion)
+	APIVersion string
+
+	// FetchCommits also fetches raw commits, for repos that commit directly
+	// to the default branch without pull requests
+	FetchCommits bool
+
+	// EmailMap maps email addresses to GitHub usernames (config github.email_map),
+	// consulted before the search API for users whose email is private
+	EmailMap map[string]string
+
+	// Redactor, when set (--strip-pii-from-cache), masks secrets and PII in
+	// enhanced PR and issue content before it is cached or returned
+	Redactor *redact.Redactor
+
+	// Org, when set (config github.org), makes user lookups prefer members of
+	// this organization and reject matches outside it
+	Org string
+
+	// MaxWait caps how long a request waits for the rate limit to reset; longer
+	// waits fail with ErrRateLimitWaitExceeded instead (0 waits indefinitely)
+	MaxWait time.Duration
+
+	// ConfirmWait, if set, is asked whether to wait anyway when the reset is
+	// further away than MaxWait (e.g. an interactive prompt)
+	ConfirmWait func(wait time.Duration) bool
+
+	// BreakerThreshold is how many consecutive failures of the same class
+	// (rate limit, server error, network) stop all further requests for the
+	// run (defaults to constants.GitHubCircuitBreakerThreshold)
+	BreakerThreshold int
+
+	// OnPage, if set, is called after each page of the user's PRs, issues and
+	// commits is fetched, so long paginated searches can report progress
+	OnPage PageFunc
+
+	// Offline serves everything from the cache, including expired entries,
+	// and makes no requests (--offline); see OfflineMisses for what was missing
+	Offline bool
+
+	// NoCache fetches everything fresh instead of serving cached entries
+	// (--no-cache); what is fetched still refreshes the cache
+	NoCache bool
+
+	// MaxAge, if set, records cache entries served although older than it
+	// (--max-age), independently of their TTL; see StaleCacheEntries
+	MaxAge time.Duration
+
+	// RefreshExpiredOnly removes the expired cache entries of the user's
+	// activity and the PRs and issues about to be fetched up front, so they
+	// are refetched while valid entries keep being served (--refresh-expired-only)
+	RefreshExpiredOnly bool
+}
+
+// PageFunc reports a fetched search page: what is being fetched ("PRs",
+// "issues" or "commits"), the page number and the running total of items
+type PageFunc func(kind string, page, fetched int)
 
 // GitHubErrorResponse represents an error response from GitHub API
 type GitHubErrorResponse struct {
@@ -61,6 +221,8 @@ type PullRequest struct {
 	Title               string          `json:"title"`
 	Body                string          `json:"body"`
 	State               string          `json:"state"`
+	HTMLURL             string          `json:"html_url"`
+	Draft               bool            `json:"draft"`
 	User                User            `json:"user"`
 	CreatedAt           string          `json:"created_at"`
 	UpdatedAt           string          `json:"updated_at"`
@@ -69,25 +231,35 @@ type PullRequest struct {
 	Additions           int             `json:"additions"`
 	Deletions           int             `json:"deletions"`
 	ChangedFiles        int             `json:"changed_files"`
-	ReviewCommentsCount int             `json:"review_comments"` // This is a count from GitHub API
-	ReviewComments      []ReviewComment `json:"-"`               // Populated separately if enhanced context is enabled
-	FilesChanged        []FileChange    `json:"-"`               // Populated separately if enhanced context is enabled
-	CodeDiff            string          `json:"-"`               // Populated separately if enhanced context is enabled
+	ReviewCommentsCount int             `json:"review_comments"`         // This is a count from GitHub API
+	ReviewComments      []ReviewComment `json:"-"`                       // Populated separately if enhanced context is enabled
+	FilesChanged        []FileChange    `json:"-"`                       // Populated separately if enhanced context is enabled
+	CodeDiff            string          `json:"-"`                       // Populated separately if enhanced context is enabled
+	FetchWarnings       []string        `json:"fetchWarnings,omitempty"` // Enhanced-context fetches that failed
+	DiffsRedacted       bool            `json:"-"`                       // Diff and patches withheld (--redact-diffs)
+	BudgetLimited       bool            `json:"-"`                       // Fetched without reviews, files or diff (--repo-request-budget)
 }
 
 // Issue represents GitHub issue information
 type Issue struct {
-	Number        int            `json:"number"`
-	Title         string         `json:"title"`
-	Body          string         `json:"body"`
-	State         string         `json:"state"`
-	User          User           `json:"user"`
-	Labels        []Label        `json:"labels"`
-	CreatedAt     string         `json:"created_at"`
-	UpdatedAt     string         `json:"updated_at"`
-	ClosedAt      string         `json:"closed_at"`
-	CommentsCount int            `json:"comments"`           // Number of comments from basic API
-	Comments      []IssueComment `json:"-"`                  // Populated separately if enhanced context is enabled
+	Number        int              `json:"number"`
+	Title         string           `json:"title"`
+	Body          string           `json:"body"`
+	State         string           `json:"state"`
+	User          User             `json:"user"`
+	Labels        []Label          `json:"labels"`
+	CreatedAt     string           `json:"created_at"`
+	UpdatedAt     string           `json:"updated_at"`
+	ClosedAt      string           `json:"closed_at"`
+	CommentsCount int              `json:"comments"`               // Number of comments from basic API
+	Comments      []IssueComment   `json:"-"`                      // Populated separately if enhanced context is enabled
+	PullRequest   *PullRequestMeta `json:"pull_request,omitempty"` // Set when the issue is a pull request
+}
+
+// IsPullRequest reports whether the issue is a pull request: the issues API
+// serves PRs too, e.g. for a /issues/N link GitHub redirects to /pull/N
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
 }
 
 // User represents a GitHub user
@@ -111,10 +283,10 @@ type JiraIssue struct {
 
 // UserActivity represents a GitHub user's activity
 type UserActivity struct {
-	Type      string  `json:"type"`
-	CreatedAt string  `json:"created_at"`
-	Repo      Repo    `json:"repo"`
-	Payload   Payload `json:"payload"`
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at"`
+	Repo      Repo            `json:"repo"`
+	Payload   json.RawMessage `json:"payload"` // Decoded on demand by DecodePayload
 }
 
 // Repo represents a GitHub repository
@@ -125,11 +297,11 @@ type Repo struct {
 
 // Payload represents the payload of a GitHub event
 type Payload struct {
-	Action  string   `json:"action"`
-	Number  int      `json:"number"`
-	Ref     string   `json:"ref"`
-	Size    int      `json:"size"`
-	Commits []Commit `json:"commits"`
+	Action  string   `json:"action,omitempty"`
+	Number  int      `json:"number,omitempty"`
+	Ref     string   `json:"ref,omitempty"`
+	Size    int      `json:"size,omitempty"`
+	Commits []Commit `json:"commits,omitempty"`
 }
 
 // Commit represents a GitHub commit
@@ -196,12 +368,81 @@ type FileChange struct {
 
 // NewClient creates a new GitHub API client
 func NewClient(config Config) *Client {
+	log := config.Logger
+	if log == nil {
+		log = logger.Default(constants.VerbosityQuiet)
+	}
+	transport := config.Transport
+	if transport == nil {
+		transport = httpclient.DefaultTransport()
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = constants.GitHubTimeout
+	}
+
+	baseURL := strings.TrimSuffix(config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	apiVersion := config.APIVersion
+	if apiVersion == "" {
+		apiVersion = constants.GitHubAPIVersion
+	}
+	breakerThreshold := config.BreakerThreshold
+	if breakerThreshold <= 0 {
+		breakerThreshold = constants.GitHubCircuitBreakerThreshold
+	}
+	diffSizeLimit := config.DiffSizeLimit
+	if diffSizeLimit <= 0 {
+		diffSizeLimit = constants.DefaultDiffSizeLimit
+	}
+	patchSizeLimit := config.PatchSizeLimit
+	if patchSizeLimit <= 0 {
+		patchSizeLimit = constants.DefaultPatchSizeLimit
+	}
+	reviewCommentsLimit := config.ReviewCommentsLimit
+	if reviewCommentsLimit <= 0 {
+		reviewCommentsLimit = constants.DefaultReviewCommentsLimit
+	}
+	issueCommentsLimit := config.IssueCommentsLimit
+	if issueCommentsLimit <= 0 {
+		issueCommentsLimit = constants.DefaultIssueCommentsLimit
+	}
+
 	return &Client{
-		baseURL: "https://api.github.com",
-		token:   config.Token,
+		baseURL:             baseURL,
+		token:               config.Token,
+		emailMap:            normalizeEmailMap(config.EmailMap),
+		org:                 config.Org,
+		redactor:            config.Redactor,
+		fetchCommits:        config.FetchCommits,
+		excludeDraftPRs:     config.ExcludeDraftPRs,
+		includeBotPRs:       config.IncludeBotPRs,
+		botLogins:           normalizeBotLogins(config.BotLogins),
+		activityTypes:       normalizeActivityTypes(config.ActivityTypes),
+		maxReferences:       config.MaxReferences,
+		budget:              repoBudget{limit: config.RepoRequestBudget},
+		diffRedaction:       newDiffRedaction(config.RedactDiffs, config.RedactDiffRepos),
+		diffSizeLimit:       diffSizeLimit,
+		patchSizeLimit:      patchSizeLimit,
+		reviewCommentsLimit: reviewCommentsLimit,
+		issueCommentsLimit:  issueCommentsLimit,
+		apiVersion:          apiVersion,
+		pageSize:            100,
+		maxWait:             config.MaxWait,
+		confirmWait:         config.ConfirmWait,
+		breaker:             circuitBreaker{threshold: breakerThreshold},
+		offline:             config.Offline,
+		noCache:             config.NoCache,
+		refreshExpiredOnly:  config.RefreshExpiredOnly,
+		maxAge:              config.MaxAge,
+		onPage:              config.OnPage,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   timeout,
+			Transport: transport,
 		},
+		log: log,
 	}
 }
 
@@ -229,6 +470,18 @@ func (c *Client) ExtractGitHubReferences(text string) []GitHubReference {
 	return references
 }
 
+// IncompletePullRequests returns the referenced PRs whose enhanced context
+// (review comments, files or diff) could only be partially fetched
+func (gc *GitHubContext) IncompletePullRequests() []PullRequest {
+	var incomplete []PullRequest
+	for _, pr := range gc.PullRequests {
+		if len(pr.FetchWarnings) > 0 {
+			incomplete = append(incomplete, pr)
+		}
+	}
+	return incomplete
+}
+
 // FetchGitHubContextFromJiraIssues retrieves GitHub context for all references found in Jira issues
 func (c *Client) FetchGitHubContextFromJiraIssues(jiraIssues []JiraIssue) (*GitHubContext, error) {
 	context := &GitHubContext{
@@ -244,41 +497,119 @@ func (c *Client) FetchGitHubContextFromJiraIssues(jiraIssues []JiraIssue) (*GitH
 		context.References = append(context.References, refs...)
 	}
 
-	// Remove duplicates
-	context.References = c.deduplicateReferences(context.References)
+	// Remove duplicates, then order by relevance so a cap keeps the PRs
+	context.References = prioritizeReferences(c.deduplicateReferences(context.References))
+	toFetch := context.References
+	if c.maxReferences > 0 && len(toFetch) > c.maxReferences {
+		toFetch = toFetch[:c.maxReferences]
+		c.log.Infof("  ℹ Fetching details for %d of %d GitHub references (--max-references)\n", len(toFetch), len(context.References))
+	}
 
-	// Fetch details for each reference with enhanced context
-	for _, ref := range context.References {
+	// Fetch details for each reference with enhanced context, skipping
+	// references that were recently not found or not accessible
+	cache, _ := c.openCache()
+	if c.refreshExpiredOnly {
+		var prKeys, issueKeys []string
+		for _, ref := range toFetch {
+			key := fmt.Sprintf("%s/%s#%s", ref.Owner, ref.Repo, ref.Number)
+			if ref.Type == "issues" {
+				issueKeys = append(issueKeys, key)
+			}
+			// Linked issues may turn out to be pull requests
+			prKeys = append(prKeys, key)
+		}
+		c.refreshExpired(cache, "pr", prKeys)
+		c.refreshExpired(cache, "issue", issueKeys)
+	}
+	var botPRs int
+	reclassified := false
+	for i := range toFetch {
+		ref := &toFetch[i]
+		if cache != nil && cache.IsUnavailable(ref.Type, ref.Owner, ref.Repo, ref.Number) {
+			c.log.Infof("  ℹ Skipping %s (previously unavailable)\n", ref.URL)
+			continue
+		}
+		if ref.Type == "issues" {
+			issue, err := c.fetchEnhancedIssue(ref.Owner, ref.Repo, ref.Number)
+			if err != nil {
+				c.log.Warnf("Warning: failed to fetch issue %s: %v\n", ref.URL, err)
+				c.rememberUnavailable(cache, *ref, err)
+				continue
+			}
+			if !issue.IsPullRequest() {
+				context.Issues = append(context.Issues, *issue)
+				continue
+			}
+			// Linked as an issue but a pull request; fetch and count it as one
+			c.log.Infof("  ℹ %s is a pull request, fetching it as one\n", ref.URL)
+			ref.Type = "pull"
+			reclassified = true
+			if isReferencedPull(context.References, *ref) {
+				continue
+			}
+		}
 		if ref.Type == "pull" {
 			pr, err := c.fetchEnhancedPullRequest(ref.Owner, ref.Repo, ref.Number)
 			if err != nil {
-				fmt.Printf("Warning: failed to fetch PR %s: %v\n", ref.URL, err)
+				c.log.Warnf("Warning: failed to fetch PR %s: %v\n", ref.URL, err)
+				c.rememberUnavailable(cache, *ref, err)
 				continue
 			}
-			context.PullRequests = append(context.PullRequests, *pr)
-		} else if ref.Type == "issues" {
-			issue, err := c.fetchEnhancedIssue(ref.Owner, ref.Repo, ref.Number)
-			if err != nil {
-				fmt.Printf("Warning: failed to fetch issue %s: %v\n", ref.URL, err)
+			if !c.includeBotPRs && c.isBot(pr.User.Login) {
+				botPRs++
 				continue
 			}
-			context.Issues = append(context.Issues, *issue)
+			context.PullRequests = append(context.PullRequests, *pr)
 		}
 	}
+	if reclassified {
+		context.References = c.deduplicateReferences(context.References)
+	}
+	if botPRs > 0 {
+		c.log.Infof("  ℹ Excluded %d bot-authored PRs referenced in Jira (--include-bot-prs=false)\n", botPRs)
+	}
 
 	return context, nil
 }
 
+// isReferencedPull reports whether refs links the pull request ref under
+// another reference, e.g. both /issues/N and /pull/N of the same PR
+func isReferencedPull(refs []GitHubReference, ref GitHubReference) bool {
+	for _, other := range refs {
+		if other.Type == "pull" && other.URL != ref.URL &&
+			strings.EqualFold(other.Owner+"/"+other.Repo, ref.Owner+"/"+ref.Repo) && other.Number == ref.Number {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberUnavailable negatively caches a reference that returned 404 or 403,
+// so the next runs within the TTL don't spend rate limit on it
+func (c *Client) rememberUnavailable(cache *Cache, ref GitHubReference, err error) {
+	if cache == nil || !isUnavailableError(err) {
+		return
+	}
+	if err := cache.SetUnavailable(ref.Type, ref.Owner, ref.Repo, ref.Number); err != nil {
+		c.log.Debugf("  failed to cache unavailable %s: %v\n", ref.URL, err)
+	}
+}
+
 // makeGitHubRequest makes an HTTP request to GitHub API with retry logic for rate limits and public repos
 func (c *Client) makeGitHubRequest(url string, target interface{}) (interface{}, error) {
 	maxRetries := 3
 	baseDelay := 2 * time.Second
 	
 	for attempt := 0; attempt < maxRetries; attempt++ {
+		// Repeated failures stop requests for the rest of the run; don't wait to retry
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
 		// Add delay for retries with exponential backoff
 		if attempt > 0 {
 			delay := baseDelay * time.Duration(1<<uint(attempt-1)) // 2s, 4s, 8s
-			fmt.Printf("  Retrying in %v (attempt %d/%d)...\n", delay, attempt+1, maxRetries)
+			c.log.Warnf("  Retrying in %v (attempt %d/%d)...\n", delay, attempt+1, maxRetries)
 			time.Sleep(delay)
 		}
 
@@ -288,20 +619,20 @@ func (c *Client) makeGitHubRequest(url string, target interface{}) (interface{},
 			if err != nil {
 				// If we get 401 (unauthorized) with a token, retry without auth for public repos
 				if isUnauthorizedError(err) {
-					fmt.Printf("⚠ GitHub auth failed, retrying without token for public repo access...\n")
+					c.log.Warnf("⚠ GitHub auth failed, retrying without token for public repo access...\n")
 					return c.doGitHubRequest(url, false, target)
 				}
 				
 				// Check if it's a rate limit error - retry if not last attempt
 				if isRateLimitError(err) && attempt < maxRetries-1 {
-					fmt.Printf("⚠ %v\n", err)
+					c.log.Warnf("⚠ %v\n", err)
 					continue
 				}
 				
 				// Check if it's a secondary rate limit (abuse detection) - longer wait
 				if isSecondaryRateLimitError(err) && attempt < maxRetries-1 {
-					fmt.Printf("⚠ %v\n", err)
-					fmt.Printf("  Waiting 60s for secondary rate limit reset...\n")
+					c.log.Warnf("⚠ %v\n", err)
+					c.log.Printf("  Waiting 60s for secondary rate limit reset...\n")
 					time.Sleep(60 * time.Second)
 					continue
 				}
@@ -316,7 +647,7 @@ func (c *Client) makeGitHubRequest(url string, target interface{}) (interface{},
 		if err != nil {
 			// Retry on rate limit errors
 			if (isRateLimitError(err) || isSecondaryRateLimitError(err)) && attempt < maxRetries-1 {
-				fmt.Printf("⚠ %v\n", err)
+				c.log.Warnf("⚠ %v\n", err)
 				continue
 			}
 			return nil, err
@@ -327,13 +658,25 @@ func (c *Client) makeGitHubRequest(url string, target interface{}) (interface{},
 	return nil, fmt.Errorf("GitHub API request failed after %d retries", maxRetries)
 }
 
-// doGitHubRequest performs the actual HTTP request with rate limit handling
+// doGitHubRequest performs the actual HTTP request, recording its outcome
+// with the circuit breaker
 func (c *Client) doGitHubRequest(url string, useAuth bool, target interface{}) (interface{}, error) {
+	if err := c.offlineRequest("GET", url); err != nil {
+		return nil, err
+	}
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	result, err := c.sendGitHubRequest(url, useAuth, target)
+	c.breaker.record(err)
+	return result, err
+}
+
+// sendGitHubRequest performs the actual HTTP request with rate limit handling
+func (c *Client) sendGitHubRequest(url string, useAuth bool, target interface{}) (interface{}, error) {
 	// Check if we need to wait for rate limit reset
-	if !c.rateLimitReset.IsZero() && c.rateLimitRemaining <= 1 && time.Now().Before(c.rateLimitReset) {
-		waitTime := time.Until(c.rateLimitReset)
-		fmt.Printf("⚠ Rate limit exceeded. Waiting %v until reset...\n", waitTime.Round(time.Second))
-		time.Sleep(waitTime + time.Second) // Add 1 second buffer
+	if err := c.waitForRateLimit(); err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -345,7 +688,11 @@ func (c *Client) doGitHubRequest(url string, useAuth bool, target interface{}) (
 		req.Header.Set("Authorization", "token "+c.token)
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", c.apiVersion)
 
+	c.log.Debugf("  → GET %s\n", url)
+
+	c.countCall(url)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -354,12 +701,23 @@ func (c *Client) doGitHubRequest(url string, useAuth bool, target interface{}) (
 
 	// Update rate limit information from headers
 	c.updateRateLimitFromHeaders(resp)
+	if useAuth && c.token != "" {
+		c.recordTokenScopes(resp)
+	}
+
+	c.log.Debugf("  ← %d (rate limit remaining: %d)\n", resp.StatusCode, c.rateLimit.remainingRequests())
 
 	if resp.StatusCode != 200 {
 		return nil, c.handleErrorResponse(resp)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.log.Tracef("%s\n", body)
+
+	if err := c.decodeResponse(url, body, target); err != nil {
 		return nil, err
 	}
 
@@ -368,17 +726,20 @@ func (c *Client) doGitHubRequest(url string, useAuth bool, target interface{}) (
 
 // updateRateLimitFromHeaders updates the client's rate limit state from response headers
 func (c *Client) updateRateLimitFromHeaders(resp *http.Response) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+
 	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
 		var remainingVal int
 		if _, err := fmt.Sscanf(remaining, "%d", &remainingVal); err == nil {
-			c.rateLimitRemaining = remainingVal
+			c.rateLimit.remaining = remainingVal
 		}
 	}
-	
+
 	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
 		var resetTimestamp int64
 		if _, err := fmt.Sscanf(reset, "%d", &resetTimestamp); err == nil {
-			c.rateLimitReset = time.Unix(resetTimestamp, 0)
+			c.rateLimit.reset = time.Unix(resetTimestamp, 0)
 		}
 	}
 }
@@ -397,7 +758,7 @@ func (c *Client) handleErrorResponse(resp *http.Response) error {
 			if strings.Contains(strings.ToLower(errorResp.Message), "abuse") {
 				return fmt.Errorf("GitHub API abuse detection triggered (secondary rate limit): %s", errorResp.Message)
 			}
-			return fmt.Errorf("GitHub API access forbidden: %s", errorResp.Message)
+			return fmt.Errorf("GitHub API access forbidden: %s%s", errorResp.Message, c.forbiddenHint(resp))
 		}
 		return fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, errorResp.Message)
 	}
@@ -411,6 +772,18 @@ func isUnauthorizedError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "GitHub API returned status 401")
 }
 
+// isUnavailableError checks if an error means the resource doesn't exist or
+// isn't accessible (404, or 403 other than rate limiting)
+func isUnavailableError(err error) bool {
+	if err == nil || isRateLimitError(err) || isSecondaryRateLimitError(err) {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "GitHub API returned status 404") ||
+		strings.Contains(msg, "GitHub API returned status 403") ||
+		strings.Contains(msg, "GitHub API access forbidden")
+}
+
 // isRateLimitError checks if an error is a rate limit error
 func isRateLimitError(err error) bool {
 	if err == nil {
@@ -471,6 +844,16 @@ func (c *Client) deduplicateReferences(refs []GitHubReference) []GitHubReference
 	return unique
 }
 
+// prioritizeReferences orders references PRs first, then issues, keeping the
+// order they were mentioned in within each group. Jira issues arrive most
+// recently updated first, so the most recently mentioned references lead.
+func prioritizeReferences(refs []GitHubReference) []GitHubReference {
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].Type == "pull" && refs[j].Type != "pull"
+	})
+	return refs
+}
+
 // TestConnection tests GitHub API connectivity and displays rate limit status
 func (c *Client) TestConnection() error {
 	rateLimit, err := c.GetRateLimitStatus()
@@ -480,25 +863,43 @@ func (c *Client) TestConnection() error {
 
 	// Display rate limit information
 	if c.token != "" {
-		fmt.Printf("✓ GitHub API connection OK (authenticated)\n")
-		fmt.Printf("  Core API: %d/%d remaining (resets at %s)\n", 
+		c.log.Printf("✓ GitHub API connection OK (authenticated)\n")
+		c.log.Printf("  Core API: %d/%d remaining (resets at %s)\n", 
 			rateLimit.Resources.Core.Remaining, 
 			rateLimit.Resources.Core.Limit,
 			time.Unix(rateLimit.Resources.Core.Reset, 0).Format("15:04:05"))
-		fmt.Printf("  Search API: %d/%d remaining (resets at %s)\n", 
+		c.log.Printf("  Search API: %d/%d remaining (resets at %s)\n", 
 			rateLimit.Resources.Search.Remaining, 
 			rateLimit.Resources.Search.Limit,
 			time.Unix(rateLimit.Resources.Search.Reset, 0).Format("15:04:05"))
 	} else {
-		fmt.Printf("✓ GitHub API connection OK (unauthenticated - limited to 60 requests/hour)\n")
+		c.log.Printf("✓ GitHub API connection OK (unauthenticated - limited to 60 requests/hour)\n")
+	}
+
+	// Report token scopes and warn about missing ones
+	if c.token != "" {
+		report := c.tokenScopeReport()
+		switch {
+		case report.Known:
+			scopes := "none"
+			if len(report.Scopes) > 0 {
+				scopes = strings.Join(report.Scopes, ", ")
+			}
+			c.log.Printf("  Token scopes: %s\n", scopes)
+			for _, missing := range report.Missing {
+				c.log.Printf("⚠ Warning: token lacks scope %s\n", missing)
+			}
+		case report.FineGrained:
+			c.log.Printf("  Token type: fine-grained (repository permissions are not reported; 403s on a repo usually mean missing \"Pull requests: read\")\n")
+		}
 	}
 
 	// Warn if rate limits are low
 	if rateLimit.Resources.Core.Remaining < 10 {
-		fmt.Printf("⚠ Warning: Core API rate limit is low (%d remaining)\n", rateLimit.Resources.Core.Remaining)
+		c.log.Printf("⚠ Warning: Core API rate limit is low (%d remaining)\n", rateLimit.Resources.Core.Remaining)
 	}
 	if rateLimit.Resources.Search.Remaining < 5 {
-		fmt.Printf("⚠ Warning: Search API rate limit is low (%d remaining)\n", rateLimit.Resources.Search.Remaining)
+		c.log.Printf("⚠ Warning: Search API rate limit is low (%d remaining)\n", rateLimit.Resources.Search.Remaining)
 	}
 
 	return nil
@@ -517,13 +918,30 @@ func (c *Client) GetRateLimitStatus() (*RateLimitResponse, error) {
 	return &rateLimit, nil
 }
 
+// VerifyToken checks that GitHub accepts the configured token. Unlike other
+// requests it does not fall back to unauthenticated access on a 401.
+func (c *Client) VerifyToken() (*RateLimitResponse, error) {
+	if c.token == "" {
+		return nil, fmt.Errorf("no GitHub token configured")
+	}
+
+	var rateLimit RateLimitResponse
+	if _, err := c.doGitHubRequest(c.baseURL+"/rate_limit", true, &rateLimit); err != nil {
+		if isUnauthorizedError(err) {
+			return nil, fmt.Errorf("GitHub rejected the token (401): check that it is valid and has not expired")
+		}
+		return nil, err
+	}
+	return &rateLimit, nil
+}
+
 // SearchUserByEmail searches for a GitHub user by email address
 func (c *Client) SearchUserByEmail(email string) (string, error) {
 	// GitHub search API endpoint for users
-	url := fmt.Sprintf("%s/search/users?q=%s+in:email", c.baseURL, email)
+	reqURL := fmt.Sprintf("%s/search/users?q=%s+in:email", c.baseURL, url.QueryEscape(email))
 
 	var searchResult UserSearchResult
-	result, err := c.makeGitHubRequest(url, &searchResult)
+	result, err := c.makeGitHubRequest(reqURL, &searchResult)
 	if err != nil {
 		return "", err
 	}
@@ -533,8 +951,99 @@ func (c *Client) SearchUserByEmail(email string) (string, error) {
 		return "", fmt.Errorf("no GitHub user found with email %s (email may be private or user may use different email for GitHub)", email)
 	}
 
-	// Return the first match (most relevant)
-	return userSearchResult.Items[0].Login, nil
+	// Results are ordered by relevance; with github.org, prefer org members
+	logins := make([]string, 0, len(userSearchResult.Items))
+	for _, item := range userSearchResult.Items {
+		logins = append(logins, item.Login)
+	}
+	return c.chooseCandidate(email, logins)
+}
+
+// CommitSearchResult represents the search result structure for commits
+type CommitSearchResult struct {
+	Items []struct {
+		Author *User `json:"author"`
+	} `json:"items"`
+}
+
+// ResolveUsername finds the GitHub username for an email. It consults the
+// configured email map first, then earlier resolutions in the cache (7-day
+// TTL), then the user search API (which only matches public emails), and
+// finally falls back to the author of a commit made with that email, which
+// also works when the profile email is private.
+func (c *Client) ResolveUsername(email string) (string, error) {
+	if username, ok := c.emailMap[strings.ToLower(email)]; ok {
+		c.log.Infof("  ℹ Using github.email_map entry for %s: %s\n", email, username)
+		return username, nil
+	}
+	cache, _ := c.openCache()
+	if cache != nil {
+		if username, found := cache.GetUsername(email, c.org); found {
+			c.log.Debugf("  Using cached GitHub user for %s: %s\n", email, username)
+			return username, nil
+		}
+	}
+	if c.offline {
+		return "", c.misses.Miss(fmt.Sprintf("GitHub user for %s (set --github-username or github.email_map)", email))
+	}
+
+	username, err := c.searchUsername(email)
+	if err != nil {
+		return "", err
+	}
+	if cache != nil {
+		_ = cache.SetUsername(email, c.org, username)
+	}
+	return username, nil
+}
+
+// searchUsername resolves an email with the user search API, falling back to
+// commit authorship
+func (c *Client) searchUsername(email string) (string, error) {
+	username, searchErr := c.SearchUserByEmail(email)
+	if searchErr == nil {
+		return username, nil
+	}
+
+	username, err := c.SearchUserByCommitEmail(email)
+	if err != nil {
+		return "", fmt.Errorf("%w; commit author lookup also failed: %v (add the user to github.email_map to map them explicitly)", searchErr, err)
+	}
+	c.log.Infof("  ℹ Matched %s to GitHub user '%s' via commit authorship\n", email, username)
+	return username, nil
+}
+
+// SearchUserByCommitEmail finds the GitHub user who authored a commit with the given email
+func (c *Client) SearchUserByCommitEmail(email string) (string, error) {
+	reqURL := fmt.Sprintf("%s/search/commits?q=author-email:%s&sort=author-date&order=desc&per_page=10", c.baseURL, url.QueryEscape(email))
+
+	result, err := c.makeGitHubRequest(reqURL, &CommitSearchResult{})
+	if err != nil {
+		return "", err
+	}
+
+	// Commits whose email isn't linked to an account have a null author
+	var logins []string
+	seen := make(map[string]bool)
+	for _, item := range result.(*CommitSearchResult).Items {
+		if item.Author != nil && item.Author.Login != "" && !seen[item.Author.Login] {
+			seen[item.Author.Login] = true
+			logins = append(logins, item.Author.Login)
+		}
+	}
+	if len(logins) == 0 {
+		return "", fmt.Errorf("no commits linked to a GitHub account found for %s", email)
+	}
+	return c.chooseCandidate(email, logins)
+}
+
+// normalizeEmailMap lowercases email keys so lookups are case-insensitive
+func normalizeEmailMap(m map[string]string) map[string]string {
+	normalized := make(map[string]string, len(m))
+	for email, username := range m {
+		normalized[strings.ToLower(strings.TrimSpace(email))] = strings.TrimSpace(username)
+	}
+	return normalized
 }
 
 // FetchUserActivity retrieves a user's recent GitHub activity
@@ -547,7 +1056,9 @@ func (c *Client) FetchUserActivity(username string) ([]UserActivity, error) {
 		return nil, err
 	}
 
-	return *result.(*[]UserActivity), nil
+	activities = *result.(*[]UserActivity)
+	c.compactPayloads(activities)
+	return activities, nil
 }
 
 // PullRequestSearchResult represents the search result structure for PRs
@@ -579,6 +1090,7 @@ func (c *Client) FetchUserPullRequests(username string) ([]UserPullRequest, erro
 		}
 
 		allPRs = append(allPRs, prs...)
+		c.reportPage("PRs", page, len(allPRs))
 
 		// GitHub Search API has a limit of 1000 results (10 pages of 100)
 		// Also break if we got less than perPage results (indicates last page)
@@ -592,6 +1104,33 @@ func (c *Client) FetchUserPullRequests(username string) ([]UserPullRequest, erro
 	return allPRs, nil
 }
 
+// reportPage passes a fetched search page to the OnPage callback, if any
+func (c *Client) reportPage(kind string, page, fetched int) {
+	if c.onPage != nil {
+		c.onPage(kind, page, fetched)
+	}
+}
+
+// searchCountResult captures only the total hit count of a search query
+type searchCountResult struct {
+	TotalCount int `json:"total_count"`
+}
+
+// CountReviewedPullRequests returns how many other people's PRs the user reviewed
+// that were updated within the date range (YYYY-MM-DD)
+func (c *Client) CountReviewedPullRequests(username, startDate, endDate string) (int, error) {
+	login := url.QueryEscape(username)
+	reqURL := fmt.Sprintf("%s/search/issues?q=type:pr+reviewed-by:%s+-author:%s+updated:%s..%s&per_page=1",
+		c.baseURL, login, login, startDate, endDate)
+
+	result, err := c.makeGitHubRequest(reqURL, &searchCountResult{})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.(*searchCountResult).TotalCount, nil
+}
+
 // IssueSearchResult represents the search result structure for issues
 type IssueSearchResult struct {
 	Items []UserIssue `json:"items"`
@@ -621,6 +1160,7 @@ func (c *Client) FetchUserIssues(username string) ([]UserIssue, error) {
 		}
 
 		allIssues = append(allIssues, issues...)
+		c.reportPage("issues", page, len(allIssues))
 
 		// GitHub Search API has a limit of 1000 results (10 pages of 100)
 		// Also break if we got less than perPage results (indicates last page)
@@ -634,18 +1174,122 @@ func (c *Client) FetchUserIssues(username string) ([]UserIssue, error) {
 	return allIssues, nil
 }
 
+// UserCommitSearchResult represents the search result structure for commits with details
+type UserCommitSearchResult struct {
+	Items []UserCommit `json:"items"`
+}
+
+// FetchUserCommits retrieves commits authored by a user and committed within the
+// date range (YYYY-MM-DD) across all repos with pagination
+func (c *Client) FetchUserCommits(username, startDate, endDate string) ([]UserCommit, error) {
+	var allCommits []UserCommit
+	page := 1
+	perPage := 100
+	login := url.QueryEscape(username)
+
+	for {
+		// Search for commits authored by the user with pagination
+		reqURL := fmt.Sprintf("%s/search/commits?q=author:%s+committer-date:%s..%s&sort=committer-date&order=desc&per_page=%d&page=%d",
+			c.baseURL, login, startDate, endDate, perPage, page)
+
+		var searchResult UserCommitSearchResult
+
+		result, err := c.makeGitHubRequest(reqURL, &searchResult)
+		if err != nil {
+			return allCommits, err // Return what we have so far
+		}
+
+		commits := result.(*UserCommitSearchResult).Items
+		if len(commits) == 0 {
+			break // No more results
+		}
+
+		allCommits = append(allCommits, commits...)
+		c.reportPage("commits", page, len(allCommits))
+
+		// GitHub Search API has a limit of 1000 results (10 pages of 100)
+		// Also break if we got less than perPage results (indicates last page)
+		if len(commits) < perPage || page >= 10 {
+			break
+		}
+
+		page++
+	}
+
+	return allCommits, nil
+}
+
+// UserCommit represents a commit from search results
+type UserCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Date string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// Subject returns the first line of the commit message
+func (uc UserCommit) Subject() string {
+	subject, _, _ := strings.Cut(uc.Commit.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
 // UserPullRequest represents a PR from search results
 type UserPullRequest struct {
-	Number        int     `json:"number"`
-	Title         string  `json:"title"`
-	Body          string  `json:"body"`
-	State         string  `json:"state"`
-	CreatedAt     string  `json:"created_at"`
-	UpdatedAt     string  `json:"updated_at"`
-	HTMLURL       string  `json:"html_url"`
-	RepositoryURL string  `json:"repository_url"`
-	User          User    `json:"user"`
-	Labels        []Label `json:"labels"`
+	Number        int              `json:"number"`
+	Title         string           `json:"title"`
+	Body          string           `json:"body"`
+	State         string           `json:"state"`
+	CreatedAt     string           `json:"created_at"`
+	UpdatedAt     string           `json:"updated_at"`
+	HTMLURL       string           `json:"html_url"`
+	RepositoryURL string           `json:"repository_url"`
+	User          User             `json:"user"`
+	Labels        []Label          `json:"labels"`
+	Draft         bool             `json:"draft"`
+	PullRequest   *PullRequestMeta `json:"pull_request,omitempty"`
+	Stats         *PRStats         `json:"stats,omitempty"` // Set by EnhanceAuthoredPullRequests
+}
+
+// PullRequestMeta is the pull_request object returned with PR search results
+type PullRequestMeta struct {
+	MergedAt string `json:"merged_at"`
+}
+
+// IsMerged reports whether the PR was merged. Entries cached before merge
+// information was recorded fall back to treating closed PRs as merged.
+func (pr UserPullRequest) IsMerged() bool {
+	if pr.PullRequest == nil {
+		return pr.State == "closed"
+	}
+	return pr.PullRequest.MergedAt != ""
+}
+
+// Status returns "open", "merged" or "closed-unmerged"
+func (pr UserPullRequest) Status() string {
+	switch {
+	case pr.State == "open":
+		return "open"
+	case pr.IsMerged():
+		return "merged"
+	default:
+		return "closed-unmerged"
+	}
+}
+
+// RepoName returns the owner/repo of the PR
+func (pr UserPullRequest) RepoName() string {
+	parts := strings.Split(pr.RepositoryURL, "/")
+	if len(parts) < 2 || pr.RepositoryURL == "" {
+		return "unknown/repo"
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
 }
 
 // UserIssue represents an issue from search results
@@ -662,8 +1306,14 @@ type UserIssue struct {
 	Labels        []Label `json:"labels"`
 }
 
-// FilterActivityByDateRange filters user activity to a specific date range
+// FilterActivityByDateRange filters user activity to a specific date range,
+// keeping only the configured event types
 func (c *Client) FilterActivityByDateRange(activities []UserActivity, startDate, endDate string) []UserActivity {
+	return c.filterEventTypes(eventsInDateRange(activities, startDate, endDate))
+}
+
+// eventsInDateRange returns the events created within the date range
+func eventsInDateRange(activities []UserActivity, startDate, endDate string) []UserActivity {
 	start, err := time.Parse("2006-01-02", startDate)
 	if err != nil {
 		return activities // Return all if date parsing fails
@@ -691,8 +1341,8 @@ func (c *Client) FilterActivityByDateRange(activities []UserActivity, startDate,
 
 // FetchUserGitHubActivity searches for a user by email and fetches their activity
 func (c *Client) FetchUserGitHubActivity(email, startDate, endDate string) ([]UserActivity, string, error) {
-	// First, try to find the GitHub user by email
-	username, err := c.SearchUserByEmail(email)
+	// First, find the GitHub user by email (config map, search, then commit authorship)
+	username, err := c.ResolveUsername(email)
 	if err != nil {
 		return nil, "", err
 	}
@@ -709,15 +1359,35 @@ func (c *Client) FetchUserGitHubActivity(email, startDate, endDate string) ([]Us
 	return filtered, username, nil
 }
 
-// fetchEnhancedPullRequest retrieves detailed PR information including reviews, files, and diffs
+// fetchEnhancedPullRequest retrieves detailed PR information including reviews, files, and diffs.
+// Concurrent calls for the same PR share one fetch and cache write.
 func (c *Client) fetchEnhancedPullRequest(owner, repo, number string) (*PullRequest, error) {
+	v, err, _ := c.inflight.Do(fmt.Sprintf("pr:%s/%s#%s", owner, repo, number), func() (any, error) {
+		return c.loadEnhancedPullRequest(owner, repo, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pr := *v.(*PullRequest)
+	return &pr, nil
+}
+
+// loadEnhancedPullRequest does the cached fetch behind fetchEnhancedPullRequest
+func (c *Client) loadEnhancedPullRequest(owner, repo, number string) (*PullRequest, error) {
 	// Try to get from cache first (24-hour TTL)
-	cache, err := NewCache()
+	withhold := c.diffRedaction.applies(owner, repo)
+	cache, err := c.openCache()
 	if err == nil {
 		if cachedPR, found := cache.GetPR(owner, repo, number); found {
+			if withhold {
+				withholdCode(cachedPR)
+			}
 			return cachedPR, nil
 		}
 	}
+	if c.offline {
+		return nil, c.misses.Miss(fmt.Sprintf("PR %s/%s#%s", owner, repo, number))
+	}
 
 	// First fetch basic PR information
 	basicPR, err := c.fetchPullRequest(owner, repo, number)
@@ -728,10 +1398,20 @@ func (c *Client) fetchEnhancedPullRequest(owner, repo, number string) (*PullRequ
 	// Enhance with additional context
 	enhancedPR := *basicPR
 
+	// A repository that reached its request budget only gets basic PR data;
+	// the downgraded PR isn't cached, so a later run can fetch it in full
+	if calls, spent := c.repoBudgetSpent(owner, repo); spent {
+		c.log.Infof("  ℹ %s/%s used %d requests (--repo-request-budget %d); fetching PR #%s without reviews, files or diff\n", owner, repo, calls, c.budget.limit, number)
+		c.redactPullRequest(&enhancedPR)
+		enhancedPR.BudgetLimited = true
+		return &enhancedPR, nil
+	}
+
 	// Fetch review comments
 	reviewComments, err := c.fetchPRReviewComments(owner, repo, number)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch review comments for PR %s/%s#%s: %v\n", owner, repo, number, err)
+		c.log.Warnf("Warning: failed to fetch review comments for PR %s/%s#%s: %v\n", owner, repo, number, err)
+		enhancedPR.FetchWarnings = append(enhancedPR.FetchWarnings, fmt.Sprintf("review comments: %v", err))
 	} else {
 		enhancedPR.ReviewComments = reviewComments
 	}
@@ -739,36 +1419,57 @@ func (c *Client) fetchEnhancedPullRequest(owner, repo, number string) (*PullRequ
 	// Fetch files changed
 	filesChanged, err := c.fetchPRFiles(owner, repo, number)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch files for PR %s/%s#%s: %v\n", owner, repo, number, err)
+		c.log.Warnf("Warning: failed to fetch files for PR %s/%s#%s: %v\n", owner, repo, number, err)
+		enhancedPR.FetchWarnings = append(enhancedPR.FetchWarnings, fmt.Sprintf("files: %v", err))
 	} else {
 		enhancedPR.FilesChanged = filesChanged
 	}
 
-	// Fetch diff (truncated for AI processing)
-	diff, err := c.fetchPRDiff(owner, repo, number)
-	if err != nil {
-		fmt.Printf("Warning: failed to fetch diff for PR %s/%s#%s: %v\n", owner, repo, number, err)
+	// Fetch diff (truncated for AI processing), unless the repository's code is withheld
+	if withhold {
+		withholdCode(&enhancedPR)
+	} else if diff, err := c.fetchPRDiff(owner, repo, number); err != nil {
+		c.log.Warnf("Warning: failed to fetch diff for PR %s/%s#%s: %v\n", owner, repo, number, err)
+		enhancedPR.FetchWarnings = append(enhancedPR.FetchWarnings, fmt.Sprintf("diff: %v", err))
 	} else {
 		enhancedPR.CodeDiff = diff
 	}
 
-	// Cache the enhanced PR (24-hour TTL)
-	if cache != nil {
+	c.redactPullRequest(&enhancedPR)
+
+	// Cache the enhanced PR (24-hour TTL); incomplete PRs are refetched next run
+	if cache != nil && len(enhancedPR.FetchWarnings) == 0 {
 		_ = cache.SetPR(owner, repo, number, &enhancedPR)
 	}
 
 	return &enhancedPR, nil
 }
 
-// fetchEnhancedIssue retrieves detailed issue information including comments
+// fetchEnhancedIssue retrieves detailed issue information including comments.
+// Concurrent calls for the same issue share one fetch and cache write.
 func (c *Client) fetchEnhancedIssue(owner, repo, number string) (*Issue, error) {
+	v, err, _ := c.inflight.Do(fmt.Sprintf("issue:%s/%s#%s", owner, repo, number), func() (any, error) {
+		return c.loadEnhancedIssue(owner, repo, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	issue := *v.(*Issue)
+	return &issue, nil
+}
+
+// loadEnhancedIssue does the cached fetch behind fetchEnhancedIssue
+func (c *Client) loadEnhancedIssue(owner, repo, number string) (*Issue, error) {
 	// Try to get from cache first (24-hour TTL)
-	cache, err := NewCache()
+	cache, err := c.openCache()
 	if err == nil {
 		if cachedIssue, found := cache.GetIssue(owner, repo, number); found {
 			return cachedIssue, nil
 		}
 	}
+	if c.offline {
+		return nil, c.misses.Miss(fmt.Sprintf("issue %s/%s#%s", owner, repo, number))
+	}
 
 	// First fetch basic issue information
 	basicIssue, err := c.fetchIssue(owner, repo, number)
@@ -782,11 +1483,13 @@ func (c *Client) fetchEnhancedIssue(owner, repo, number string) (*Issue, error)
 	// Fetch issue comments
 	comments, err := c.fetchIssueComments(owner, repo, number)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch comments for issue %s/%s#%s: %v\n", owner, repo, number, err)
+		c.log.Warnf("Warning: failed to fetch comments for issue %s/%s#%s: %v\n", owner, repo, number, err)
 	} else {
 		enhancedIssue.Comments = comments
 	}
 
+	c.redactIssue(&enhancedIssue)
+
 	// Cache the enhanced issue (24-hour TTL)
 	if cache != nil {
 		_ = cache.SetIssue(owner, repo, number, &enhancedIssue)
@@ -799,17 +1502,14 @@ func (c *Client) fetchEnhancedIssue(owner, repo, number string) (*Issue, error)
 func (c *Client) fetchPRReviewComments(owner, repo, number string) ([]ReviewComment, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%s/comments", c.baseURL, owner, repo, number)
 
-	var comments []ReviewComment
-	result, err := c.makeGitHubRequest(url, &comments)
+	reviewComments, err := fetchAllPages[ReviewComment](c, url)
 	if err != nil {
 		return nil, err
 	}
 
-	reviewComments := *result.(*[]ReviewComment)
-
-	// Limit to most recent/relevant comments to avoid overwhelming AI
-	if len(reviewComments) > 20 {
-		reviewComments = reviewComments[:20]
+	// Limit to most recent comments (returned oldest first) to avoid overwhelming AI
+	if len(reviewComments) > c.reviewCommentsLimit {
+		reviewComments = reviewComments[len(reviewComments)-c.reviewCommentsLimit:]
 	}
 
 	return reviewComments, nil
@@ -819,14 +1519,11 @@ func (c *Client) fetchPRReviewComments(owner, repo, number string) ([]ReviewComm
 func (c *Client) fetchPRFiles(owner, repo, number string) ([]FileChange, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%s/files", c.baseURL, owner, repo, number)
 
-	var files []FileChange
-	result, err := c.makeGitHubRequest(url, &files)
+	fileChanges, err := fetchAllPages[FileChange](c, url)
 	if err != nil {
 		return nil, err
 	}
 
-	fileChanges := *result.(*[]FileChange)
-
 	// Analyze and categorize files
 	for i := range fileChanges {
 		fileChanges[i].FileType = c.categorizeFileType(fileChanges[i].Filename)
@@ -834,17 +1531,48 @@ func (c *Client) fetchPRFiles(owner, repo, number string) ([]FileChange, error)
 		fileChanges[i].IsDocFile = c.isDocumentationFile(fileChanges[i].Filename)
 
 		// Truncate large patches to avoid overwhelming AI
-		if len(fileChanges[i].Patch) > 2000 {
-			fileChanges[i].Patch = fileChanges[i].Patch[:2000] + "\n... (truncated)"
-		}
+		fileChanges[i].Patch = truncatePatch(fileChanges[i].Patch, c.patchSizeLimit)
 	}
 
 	return fileChanges, nil
 }
 
+// maxListPages bounds page iteration on list endpoints (GitHub lists at most
+// 3000 files per PR, i.e. 30 pages of 100)
+const maxListPages = 30
+
+// fetchAllPages fetches every page of a paginated list endpoint
+func fetchAllPages[T any](c *Client, url string) ([]T, error) {
+	var all []T
+	for page := 1; page <= maxListPages; page++ {
+		pageURL := fmt.Sprintf("%s?per_page=%d&page=%d", url, c.pageSize, page)
+
+		var items []T
+		result, err := c.makeGitHubRequest(pageURL, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		items = *result.(*[]T)
+		all = append(all, items...)
+
+		// A short page is the last page
+		if len(items) < c.pageSize {
+			break
+		}
+	}
+	return all, nil
+}
+
 // fetchPRDiff retrieves the full diff for a PR (truncated for AI processing)
 func (c *Client) fetchPRDiff(owner, repo, number string) (string, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%s", c.baseURL, owner, repo, number)
+	if err := c.offlineRequest("GET", url+" (diff)"); err != nil {
+		return "", err
+	}
+	if err := c.breaker.allow(); err != nil {
+		return "", err
+	}
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -855,7 +1583,11 @@ func (c *Client) fetchPRDiff(owner, repo, number string) (string, error) {
 		req.Header.Set("Authorization", "token "+c.token)
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3.diff")
+	req.Header.Set("X-GitHub-Api-Version", c.apiVersion)
 
+	c.log.Debugf("  → GET %s (diff)\n", url)
+
+	c.countCall(url)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", err
@@ -866,16 +1598,13 @@ func (c *Client) fetchPRDiff(owner, repo, number string) (string, error) {
 		return "", fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
-	// Read and truncate diff to manageable size for AI
-	diff := make([]byte, 5000) // Limit to 5KB
-	n, _ := resp.Body.Read(diff)
-	diffStr := string(diff[:n])
-
-	if n == 5000 {
-		diffStr += "\n... (diff truncated for AI processing)"
+	// Read the diff to measure it, then truncate it to a manageable size for AI
+	diff, err := io.ReadAll(io.LimitReader(resp.Body, maxDiffDownload))
+	if err != nil {
+		return "", fmt.Errorf("failed to read diff: %w", err)
 	}
 
-	return diffStr, nil
+	return truncateDiff(string(diff), c.diffSizeLimit, len(diff) < maxDiffDownload), nil
 }
 
 // fetchIssueComments retrieves comments for a GitHub issue
@@ -891,8 +1620,8 @@ func (c *Client) fetchIssueComments(owner, repo, number string) ([]IssueComment,
 	issueComments := *result.(*[]IssueComment)
 
 	// Limit to most recent comments to avoid overwhelming AI
-	if len(issueComments) > 10 {
-		issueComments = issueComments[:10]
+	if len(issueComments) > c.issueCommentsLimit {
+		issueComments = issueComments[:c.issueCommentsLimit]
 	}
 
 	return issueComments, nil
@@ -945,18 +1674,39 @@ func (c *Client) FetchComprehensiveUserActivity(username, startDate, endDate str
 	return c.FetchComprehensiveUserActivityWithCache(username, startDate, endDate, false)
 }
 
-// FetchComprehensiveUserActivityWithCache fetches user activity with optional verbose cache logging
+// FetchComprehensiveUserActivityWithCache fetches user activity with optional verbose cache logging.
+// Concurrent calls for the same user and date range share one fetch and cache write.
 func (c *Client) FetchComprehensiveUserActivityWithCache(username, startDate, endDate string, verbose bool) (*ComprehensiveUserActivity, error) {
+	v, err, _ := c.inflight.Do(fmt.Sprintf("activity:%s:%s:%s", username, startDate, endDate), func() (any, error) {
+		return c.loadComprehensiveUserActivity(username, startDate, endDate, verbose)
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity := *v.(*ComprehensiveUserActivity)
+	return &activity, nil
+}
+
+// loadComprehensiveUserActivity does the cached fetch behind FetchComprehensiveUserActivityWithCache
+func (c *Client) loadComprehensiveUserActivity(username, startDate, endDate string, verbose bool) (*ComprehensiveUserActivity, error) {
 	// Try to get from cache first
-	cache, err := NewCache()
+	cache, err := c.openCache()
 	if err == nil {
-		if cachedActivity, found := cache.Get(username, startDate, endDate); found {
+		c.refreshExpired(cache, "activity", []string{ActivityKey(username, startDate, endDate)})
+
+		// Offline, cached activity without commits is served rather than dropped
+		if cachedActivity, found := cache.Get(username, startDate, endDate); found && (!c.fetchCommits || cachedActivity.Commits != nil || c.offline) {
 			if verbose {
-				fmt.Printf("  ✓ Using cached GitHub activity (saves API rate limit)\n")
+				c.log.Printf("  ✓ Using cached GitHub activity (saves API rate limit)\n")
+			} else {
+				c.log.Infof("  ✓ Using cached GitHub activity (saves API rate limit)\n")
 			}
-			return cachedActivity, nil
+			return c.filterActivity(cachedActivity), nil
 		}
 	}
+	if c.offline {
+		return nil, c.misses.Miss(fmt.Sprintf("GitHub activity of %s from %s to %s", username, startDate, endDate))
+	}
 
 	activity := &ComprehensiveUserActivity{
 		Username: username,
@@ -965,15 +1715,15 @@ func (c *Client) FetchComprehensiveUserActivityWithCache(username, startDate, en
 	// Fetch traditional events
 	events, err := c.FetchUserActivity(username)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch user events: %v\n", err)
+		c.log.Warnf("Warning: failed to fetch user events: %v\n", err)
 	} else {
-		activity.Events = c.FilterActivityByDateRange(events, startDate, endDate)
+		activity.Events = eventsInDateRange(events, startDate, endDate)
 	}
 
 	// Fetch PRs created by user
 	prs, err := c.FetchUserPullRequests(username)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch user pull requests: %v\n", err)
+		c.log.Warnf("Warning: failed to fetch user pull requests: %v\n", err)
 	} else {
 		activity.PullRequests = c.FilterPullRequestsByDateRange(prs, startDate, endDate)
 	}
@@ -981,17 +1731,135 @@ func (c *Client) FetchComprehensiveUserActivityWithCache(username, startDate, en
 	// Fetch issues created by user
 	issues, err := c.FetchUserIssues(username)
 	if err != nil {
-		fmt.Printf("Warning: failed to fetch user issues: %v\n", err)
+		c.log.Warnf("Warning: failed to fetch user issues: %v\n", err)
 	} else {
 		activity.Issues = c.FilterIssuesByDateRange(issues, startDate, endDate)
 	}
 
-	// Cache the results
-	if cache != nil {
+	// Fetch commits authored by user (already date-scoped by the search query)
+	if c.fetchCommits {
+		commits, err := c.FetchUserCommits(username, startDate, endDate)
+		if err != nil {
+			c.log.Warnf("Warning: failed to fetch user commits: %v\n", err)
+		} else {
+			// Non-nil even when empty, so cached entries record that commits were fetched
+			activity.Commits = append([]UserCommit{}, commits...)
+		}
+	}
+
+	// Cache the results (drafts, bots and every event type included, so the cache serves any setting);
+	// partial results from a skipped rate limit wait or tripped breaker are not cached
+	c.redactActivity(activity)
+	if cache != nil && !c.rateLimit.waitDeclined() && c.breaker.open() == nil {
 		_ = cache.Set(username, startDate, endDate, activity)
 	}
 
-	return activity, nil
+	return c.filterActivity(activity), nil
+}
+
+// filterActivity applies the draft, bot and event type filters to the activity
+func (c *Client) filterActivity(activity *ComprehensiveUserActivity) *ComprehensiveUserActivity {
+	filtered := *c.filterBotPRs(c.filterDraftPRs(activity))
+	filtered.Events = c.filterEventTypes(activity.Events)
+	if excluded := len(activity.Events) - len(filtered.Events); excluded > 0 {
+		c.log.Infof("  ℹ Excluded %d GitHub events of other types (--github-activity-types)\n", excluded)
+	}
+	return &filtered
+}
+
+// filterDraftPRs returns the activity without draft PRs when drafts are excluded
+func (c *Client) filterDraftPRs(activity *ComprehensiveUserActivity) *ComprehensiveUserActivity {
+	if !c.excludeDraftPRs {
+		return activity
+	}
+
+	filtered := *activity
+	filtered.PullRequests = nil
+	for _, pr := range activity.PullRequests {
+		if !pr.Draft {
+			filtered.PullRequests = append(filtered.PullRequests, pr)
+		}
+	}
+	if excluded := len(activity.PullRequests) - len(filtered.PullRequests); excluded > 0 {
+		c.log.Infof("  ℹ Excluded %d draft PRs (--include-draft-prs=false)\n", excluded)
+	}
+	return &filtered
+}
+
+// filterBotPRs returns the activity without bot-authored PRs unless bots are included
+func (c *Client) filterBotPRs(activity *ComprehensiveUserActivity) *ComprehensiveUserActivity {
+	if c.includeBotPRs {
+		return activity
+	}
+
+	filtered := *activity
+	filtered.PullRequests = nil
+	for _, pr := range activity.PullRequests {
+		if !c.isBot(pr.User.Login) {
+			filtered.PullRequests = append(filtered.PullRequests, pr)
+		}
+	}
+	if excluded := len(activity.PullRequests) - len(filtered.PullRequests); excluded > 0 {
+		c.log.Infof("  ℹ Excluded %d bot-authored PRs (--include-bot-prs=false)\n", excluded)
+	}
+	return &filtered
+}
+
+// isBot reports whether a login is a GitHub App bot (e.g. dependabot[bot])
+// or one of the configured bot accounts
+func (c *Client) isBot(login string) bool {
+	login = strings.ToLower(login)
+	return strings.HasSuffix(login, "[bot]") || c.botLogins[login]
+}
+
+// normalizeBotLogins lowercases the configured bot logins into a set
+func normalizeBotLogins(logins []string) map[string]bool {
+	set := make(map[string]bool, len(logins))
+	for _, login := range logins {
+		if login = strings.ToLower(strings.TrimSpace(login)); login != "" {
+			set[login] = true
+		}
+	}
+	return set
+}
+
+// DefaultActivityTypes are the event types that count as work; stars, forks
+// and other events are dropped unless --github-activity-types includes them
+var DefaultActivityTypes = []string{"PushEvent", "PullRequestEvent", "IssuesEvent", "PullRequestReviewEvent"}
+
+// filterEventTypes returns the events of the configured types
+func (c *Client) filterEventTypes(events []UserActivity) []UserActivity {
+	if c.activityTypes == nil {
+		return events
+	}
+	var filtered []UserActivity
+	for _, event := range events {
+		if c.activityTypes[strings.ToLower(event.Type)] {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// normalizeActivityTypes lowercases the event types to keep into a set,
+// defaulting to DefaultActivityTypes; "all" keeps every type (nil set)
+func normalizeActivityTypes(types []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, eventType := range types {
+		eventType = strings.ToLower(strings.TrimSpace(eventType))
+		if eventType == "all" {
+			return nil
+		}
+		if eventType != "" {
+			set[eventType] = true
+		}
+	}
+	if len(set) == 0 {
+		for _, eventType := range DefaultActivityTypes {
+			set[strings.ToLower(eventType)] = true
+		}
+	}
+	return set
 }
 
 // ComprehensiveUserActivity holds all types of user activity
@@ -1000,6 +1868,7 @@ type ComprehensiveUserActivity struct {
 	Events       []UserActivity    `json:"events"`
 	PullRequests []UserPullRequest `json:"pull_requests"`
 	Issues       []UserIssue       `json:"issues"`
+	Commits      []UserCommit      `json:"commits"` // Only fetched in --commits mode
 }
 
 // FilterPullRequestsByDateRange filters PRs by date range
@@ -1058,12 +1927,28 @@ func (c *Client) FilterIssuesByDateRange(issues []UserIssue, startDate, endDate
 
 // Gist represents a GitHub Gist
 type Gist struct {
-	ID          string                 `json:"id"`
-	Description string                 `json:"description"`
-	Public      bool                   `json:"public"`
-	Files       map[string]GistFile    `json:"files"`
-	HTMLURL     string                 `json:"html_url"`
-	UpdatedAt   string                 `json:"updated_at"`
+	ID          string              `json:"id"`
+	Description string              `json:"description"`
+	Public      bool                `json:"public"`
+	Files       map[string]GistFile `json:"files"`
+	HTMLURL     string              `json:"html_url"`
+	UpdatedAt   string              `json:"updated_at"`
+	History     []GistRevision      `json:"history"`
+}
+
+// GistRevision is one entry of a Gist's revision history, newest first
+type GistRevision struct {
+	Version     string `json:"version"`
+	CommittedAt string `json:"committed_at"`
+}
+
+// Revision identifies the Gist's current content: the latest history
+// version, or the update time if the history is missing
+func (g *Gist) Revision() string {
+	if len(g.History) > 0 && g.History[0].Version != "" {
+		return g.History[0].Version
+	}
+	return g.UpdatedAt
 }
 
 // GistFile represents a file in a Gist
@@ -1078,8 +1963,8 @@ type GistFile struct {
 
 // GistUpdate represents the structure for updating a Gist
 type GistUpdate struct {
-	Description string                 `json:"description,omitempty"`
-	Files       map[string]GistFile    `json:"files"`
+	Description string              `json:"description,omitempty"`
+	Files       map[string]GistFile `json:"files"`
 }
 
 // GetGist retrieves a Gist by ID
@@ -1089,6 +1974,9 @@ func (c *Client) GetGist(gistID string) (*Gist, error) {
 	var gist Gist
 	result, err := c.makeGitHubRequest(url, &gist)
 	if err != nil {
+		if strings.Contains(err.Error(), "GitHub API returned status 404") {
+			return nil, fmt.Errorf("gist %s not found: check github.gist_url, and that the token belongs to the gist's owner if it is secret", gistID)
+		}
 		return nil, err
 	}
 
@@ -1098,6 +1986,9 @@ func (c *Client) GetGist(gistID string) (*Gist, error) {
 // UpdateGist updates a Gist's content
 func (c *Client) UpdateGist(gistID string, update GistUpdate) (*Gist, error) {
 	url := fmt.Sprintf("%s/gists/%s", c.baseURL, gistID)
+	if err := c.offlineRequest("PATCH", url); err != nil {
+		return nil, err
+	}
 
 	reqBody, err := json.Marshal(update)
 	if err != nil {
@@ -1115,8 +2006,13 @@ func (c *Client) UpdateGist(gistID string, update GistUpdate) (*Gist, error) {
 
 	req.Header.Set("Authorization", "token "+c.token)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", c.apiVersion)
 	req.Header.Set("Content-Type", "application/json")
 
+	c.log.Debugf("  → PATCH %s\n", url)
+	c.log.Tracef("%s\n", reqBody)
+
+	c.countCall(url)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -1135,29 +2031,26 @@ func (c *Client) UpdateGist(gistID string, update GistUpdate) (*Gist, error) {
 	return &gist, nil
 }
 
-// ExtractGistIDFromURL extracts the Gist ID from a GitHub Gist URL
+// gistIDPattern matches gist IDs, which are hexadecimal
+var gistIDPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// ExtractGistIDFromURL extracts the Gist ID from a GitHub Gist URL. It accepts
+// https://gist.github.com/username/abc123, https://gist.github.com/abc123,
+// username/abc123 and a bare abc123, ignoring any query, fragment, trailing
+// slash or .git suffix.
 func ExtractGistIDFromURL(gistURL string) (string, error) {
-	// Handle various Gist URL formats:
-	// https://gist.github.com/username/abc123
-	// https://gist.github.com/abc123
-	// abc123 (just the ID)
-	
-	if !strings.Contains(gistURL, "gist.github.com") && !strings.Contains(gistURL, "/") {
-		// Assume it's just the ID
-		return gistURL, nil
+	u, err := url.Parse(strings.TrimSpace(gistURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid gist URL '%s': %w", gistURL, err)
 	}
 
-	parts := strings.Split(gistURL, "/")
-	if len(parts) == 0 {
-		return "", fmt.Errorf("invalid gist URL format")
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", fmt.Errorf("could not extract gist ID from URL '%s'", gistURL)
 	}
-
-	// Get the last non-empty part
-	for i := len(parts) - 1; i >= 0; i-- {
-		if parts[i] != "" {
-			return parts[i], nil
-		}
+	id := strings.TrimSuffix(segments[len(segments)-1], ".git")
+	if !gistIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid gist ID '%s' in '%s': expected a hexadecimal ID like https://gist.github.com/username/aa5a315d61ae9438b18d", id, gistURL)
 	}
-
-	return "", fmt.Errorf("could not extract gist ID from URL")
+	return id, nil
 }
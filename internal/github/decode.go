@@ -0,0 +1,108 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// decodeBodyLogLimit caps the response body logged when it fails to decode
+const decodeBodyLogLimit = 2000
+
+// decodeResponse decodes a GitHub response body into target. On failure the
+// error names the field and JSON type that didn't match, rather than the
+// opaque "json: cannot unmarshal", and the body is logged at -v.
+func (c *Client) decodeResponse(url string, body []byte, target any) error {
+	err := json.Unmarshal(body, target)
+	if err == nil {
+		return nil
+	}
+
+	// Responses are often a single line, so cut at the limit, not a line break
+	logged := string(body)
+	if len(body) > decodeBodyLogLimit {
+		logged = fmt.Sprintf("%s\n... (%s total)", body[:decodeBodyLogLimit], formatByteSize(len(body)))
+	}
+	c.log.Infof("  ⚠ Undecodable response from %s:\n%s\n", url, logged)
+	return fmt.Errorf("failed to decode GitHub response from %s: %w", url, describeDecodeError(err))
+}
+
+// describeDecodeError rewords type mismatches and syntax errors with the
+// field path and offset where decoding failed
+func describeDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "(top level)"
+		}
+		return fmt.Errorf("field %s is a JSON %s, expected %s (offset %d)", field, typeErr.Value, typeErr.Type, typeErr.Offset)
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("invalid JSON at offset %d: %w", syntaxErr.Offset, err)
+	default:
+		return err
+	}
+}
+
+// DecodePayload decodes the event's payload, reading only the fields of its
+// event type: ref, size and commits for pushes, action and number for pull
+// request and issue events, and action for the rest. Payloads are decoded
+// lazily so an unusual payload of one event doesn't fail a whole events page.
+func (a UserActivity) DecodePayload() (Payload, error) {
+	if len(a.Payload) == 0 || string(a.Payload) == "null" {
+		return Payload{}, nil
+	}
+
+	var payload Payload
+	var err error
+	switch a.Type {
+	case "PushEvent":
+		var push struct {
+			Ref     string   `json:"ref"`
+			Size    int      `json:"size"`
+			Commits []Commit `json:"commits"`
+		}
+		err = json.Unmarshal(a.Payload, &push)
+		payload = Payload{Ref: push.Ref, Size: push.Size, Commits: push.Commits}
+	case "PullRequestEvent", "PullRequestReviewEvent", "PullRequestReviewCommentEvent", "IssuesEvent":
+		var numbered struct {
+			Action string `json:"action"`
+			Number int    `json:"number"`
+		}
+		err = json.Unmarshal(a.Payload, &numbered)
+		payload = Payload{Action: numbered.Action, Number: numbered.Number}
+	default:
+		var other struct {
+			Action string `json:"action"`
+		}
+		err = json.Unmarshal(a.Payload, &other)
+		payload = Payload{Action: other.Action}
+	}
+	if err != nil {
+		return Payload{}, fmt.Errorf("failed to decode %s payload in %s: %w", a.Type, a.Repo.Name, describeDecodeError(err))
+	}
+	return payload, nil
+}
+
+// compactPayloads replaces each event's raw payload with the fields
+// DecodePayload reads, so cached events don't keep whole payloads (with
+// commit author emails and PR bodies). Payloads that don't decode are
+// dropped with a warning, keeping the rest of the event.
+func (c *Client) compactPayloads(events []UserActivity) {
+	for i := range events {
+		payload, err := events[i].DecodePayload()
+		if err != nil {
+			c.log.Warnf("  ⚠ %v\n", err)
+			events[i].Payload = nil
+			continue
+		}
+		compact, err := json.Marshal(payload)
+		if err != nil {
+			events[i].Payload = nil
+			continue
+		}
+		events[i].Payload = compact
+	}
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PRKey identifies a pull request by repository and number
+type PRKey struct {
+	Repo   string // owner/repo, lowercased
+	Number int
+}
+
+// Key returns the identity of an authored PR
+func (pr UserPullRequest) Key() PRKey {
+	return PRKey{Repo: strings.ToLower(pr.RepoName()), Number: pr.Number}
+}
+
+// Key returns the identity of a referenced PR, parsed from its HTML URL
+// (https://github.com/owner/repo/pull/N). ok is false if the URL is unusable.
+func (pr PullRequest) Key() (key PRKey, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(pr.HTMLURL, "https://"), "/")
+	if len(parts) < 5 || parts[3] != "pull" {
+		return PRKey{}, false
+	}
+	number, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return PRKey{}, false
+	}
+	return PRKey{Repo: strings.ToLower(parts[1] + "/" + parts[2]), Number: number}, true
+}
+
+// PRRecord is one pull request after merging the authored search results with
+// the PRs referenced from Jira. Enhanced is the richer Jira-referenced record
+// (line counts, files, reviews) and is preferred when both are present.
+type PRRecord struct {
+	Key      PRKey
+	Authored *UserPullRequest
+	Enhanced *PullRequest
+}
+
+// RepoName returns the owner/repo of the PR as GitHub spells it
+func (r PRRecord) RepoName() string {
+	if r.Authored != nil {
+		return r.Authored.RepoName()
+	}
+	parts := strings.Split(strings.TrimPrefix(r.Enhanced.HTMLURL, "https://"), "/")
+	return parts[1] + "/" + parts[2]
+}
+
+// Status returns "open", "merged" or "closed-unmerged"
+func (r PRRecord) Status() string {
+	if r.Enhanced == nil {
+		return r.Authored.Status()
+	}
+	switch {
+	case r.Enhanced.State == "open":
+		return "open"
+	case r.Enhanced.MergedAt != "":
+		return "merged"
+	default:
+		return "closed-unmerged"
+	}
+}
+
+// UniquePullRequests merges the authored PRs and the Jira-referenced PRs by
+// repo and number, so a PR that is both authored and referenced is counted
+// once. Authored PRs come first in their original order, followed by PRs only
+// referenced from Jira.
+func UniquePullRequests(ctx *GitHubContext) []PRRecord {
+	if ctx == nil {
+		return nil
+	}
+
+	var records []PRRecord
+	index := make(map[PRKey]int)
+	if ctx.ComprehensiveActivity != nil {
+		for i := range ctx.ComprehensiveActivity.PullRequests {
+			pr := &ctx.ComprehensiveActivity.PullRequests[i]
+			key := pr.Key()
+			if _, ok := index[key]; ok {
+				continue
+			}
+			index[key] = len(records)
+			records = append(records, PRRecord{Key: key, Authored: pr})
+		}
+	}
+	for i := range ctx.PullRequests {
+		pr := &ctx.PullRequests[i]
+		key, ok := pr.Key()
+		if !ok {
+			continue
+		}
+		if j, ok := index[key]; ok {
+			records[j].Enhanced = pr
+			continue
+		}
+		index[key] = len(records)
+		records = append(records, PRRecord{Key: key, Enhanced: pr})
+	}
+	return records
+}
+
+// URL returns the PR's HTML URL
+func (r PRRecord) URL() string {
+	if r.Enhanced != nil {
+		return r.Enhanced.HTMLURL
+	}
+	return r.Authored.HTMLURL
+}
+
+// CycleTime returns the time from the PR's creation to its merge. ok is false
+// for PRs that aren't merged or whose timestamps can't be parsed.
+func (r PRRecord) CycleTime() (cycle time.Duration, ok bool) {
+	var created, merged string
+	switch {
+	case r.Enhanced != nil:
+		created, merged = r.Enhanced.CreatedAt, r.Enhanced.MergedAt
+	case r.Authored.PullRequest != nil:
+		created, merged = r.Authored.CreatedAt, r.Authored.PullRequest.MergedAt
+	}
+	start, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return 0, false
+	}
+	end, err := time.Parse(time.RFC3339, merged)
+	if err != nil || end.Before(start) {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
@@ -0,0 +1,47 @@
+package github
+
+import "strings"
+
+// diffRedaction selects the repositories whose code is withheld: diffs aren't
+// fetched and file patches are dropped, keeping the file list and stats
+type diffRedaction struct {
+	all   bool
+	repos map[string]bool // Lowercased owner/name, or owner/* for a whole owner
+}
+
+// newDiffRedaction withholds the code of every repository when all is set,
+// else of the listed owner/name or owner/* repositories
+func newDiffRedaction(all bool, repos []string) diffRedaction {
+	redaction := diffRedaction{all: all}
+	for _, repo := range repos {
+		if repo = strings.ToLower(strings.TrimSpace(repo)); repo != "" {
+			if redaction.repos == nil {
+				redaction.repos = make(map[string]bool)
+			}
+			redaction.repos[repo] = true
+		}
+	}
+	return redaction
+}
+
+// applies reports whether the code of owner/repo is withheld
+func (r diffRedaction) applies(owner, repo string) bool {
+	owner, repo = strings.ToLower(owner), strings.ToLower(repo)
+	return r.all || r.repos[owner+"/"+repo] || r.repos[owner+"/*"]
+}
+
+// withholdCode drops a PR's diff and file patches, keeping the files' names,
+// types and line counts, and marks the PR for the prompt
+func withholdCode(pr *PullRequest) {
+	pr.CodeDiff = ""
+	files := make([]FileChange, len(pr.FilesChanged))
+	for i, file := range pr.FilesChanged {
+		file.Patch = ""
+		files[i] = file
+	}
+	if pr.FilesChanged == nil {
+		files = nil
+	}
+	pr.FilesChanged = files
+	pr.DiffsRedacted = true
+}
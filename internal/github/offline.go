@@ -0,0 +1,25 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+)
+
+// ErrOffline is returned instead of making a request when the client is
+// offline and the data isn't in the cache
+var ErrOffline = cachelog.ErrOffline
+
+// offlineRequest blocks a request to url when the client is offline
+func (c *Client) offlineRequest(method, url string) error {
+	if !c.offline {
+		return nil
+	}
+	return c.misses.Miss(method + " " + strings.TrimPrefix(url, c.baseURL))
+}
+
+// OfflineMisses returns what an offline client could not serve from the
+// cache, once each in the order it was requested
+func (c *Client) OfflineMisses() []string {
+	return c.misses.Entries()
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxOrgCandidates caps the membership checks made for one user lookup
+const maxOrgCandidates = 5
+
+// chooseCandidate picks the GitHub user for an email from candidate logins,
+// most relevant first. Without github.org the first candidate is used, as
+// before; with it, the first org member is, and a lookup with no member among
+// the candidates fails rather than guess.
+func (c *Client) chooseCandidate(email string, logins []string) (string, error) {
+	if c.org == "" {
+		if len(logins) > 1 {
+			c.log.Infof("  ℹ %d GitHub users match %s; using the first, '%s' (set github.org to prefer organization members)\n", len(logins), email, logins[0])
+		}
+		return logins[0], nil
+	}
+
+	checked := logins[:min(len(logins), maxOrgCandidates)]
+	for i, login := range checked {
+		member, err := c.isOrgMember(c.org, login)
+		if err != nil {
+			c.log.Warnf("  Warning: could not verify that '%s' is a member of %s (%v); using the first match, '%s'\n", login, c.org, err, logins[0])
+			return logins[0], nil
+		}
+		if member {
+			c.log.Infof("  ℹ Chose GitHub user '%s' for %s: member of %s (candidate %d of %d)\n", login, email, c.org, i+1, len(logins))
+			return login, nil
+		}
+		c.log.Infof("  ℹ Skipping GitHub user '%s' for %s: not a member of %s\n", login, email, c.org)
+	}
+	return "", fmt.Errorf("none of the GitHub users matching %s (%s) is a member of %s (add the user to github.email_map to map them explicitly)", email, strings.Join(checked, ", "), c.org)
+}
+
+// isOrgMember reports whether login belongs to org. GitHub answers 204 for
+// members and 404 otherwise; for a token from outside the org it redirects to
+// the public membership check, which only sees public members.
+func (c *Client) isOrgMember(org, login string) (bool, error) {
+	reqURL := fmt.Sprintf("%s/orgs/%s/members/%s", c.baseURL, url.PathEscape(org), url.PathEscape(login))
+	if err := c.offlineRequest("GET", reqURL); err != nil {
+		return false, err
+	}
+	if err := c.breaker.allow(); err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return false, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", c.apiVersion)
+
+	c.log.Debugf("  → GET %s\n", reqURL)
+	c.countCall(reqURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.breaker.record(err)
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	c.updateRateLimitFromHeaders(resp)
+	c.log.Debugf("  ← %d (rate limit remaining: %d)\n", resp.StatusCode, c.rateLimit.remainingRequests())
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		c.breaker.record(nil)
+		return true, nil
+	case http.StatusNotFound:
+		c.breaker.record(nil)
+		return false, nil
+	default:
+		err := c.handleErrorResponse(resp)
+		c.breaker.record(err)
+		return false, err
+	}
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRateLimitWaitExceeded is returned instead of blocking when the rate limit
+// resets further in the future than the configured MaxWait
+var ErrRateLimitWaitExceeded = errors.New("GitHub rate limit reset is beyond --max-wait")
+
+// rateLimitState tracks the rate limit reported by the latest response and
+// whether a wait beyond MaxWait was declined. Concurrent fetches share it.
+type rateLimitState struct {
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+	declined  bool
+}
+
+// remainingRequests returns the remaining requests reported last
+func (r *rateLimitState) remainingRequests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remaining
+}
+
+// waitDeclined reports whether a wait beyond MaxWait was declined
+func (r *rateLimitState) waitDeclined() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.declined
+}
+
+// waitForRateLimit sleeps until the rate limit resets when it is exhausted.
+// Waits longer than maxWait fail fast unless confirmWait approves them; once
+// declined, later requests fail fast without asking again.
+func (c *Client) waitForRateLimit() error {
+	r := &c.rateLimit
+	r.mu.Lock()
+	if r.reset.IsZero() || r.remaining > 1 || !time.Now().Before(r.reset) {
+		r.mu.Unlock()
+		return nil
+	}
+
+	waitTime := time.Until(r.reset)
+	if c.maxWait > 0 && waitTime > c.maxWait {
+		// Asked under the lock, so concurrent fetches share one answer
+		if r.declined || c.confirmWait == nil || !c.confirmWait(waitTime) {
+			r.declined = true
+			r.mu.Unlock()
+			return fmt.Errorf("%w: resets in %v (max wait %v)", ErrRateLimitWaitExceeded, waitTime.Round(time.Second), c.maxWait)
+		}
+	}
+	r.mu.Unlock()
+
+	c.log.Printf("⚠ Rate limit exceeded. Waiting %v until reset...\n", waitTime.Round(time.Second))
+	time.Sleep(waitTime + time.Second) // Add 1 second buffer
+	return nil
+}
+
+// ResetRateLimitWait forgets a declined wait, so the next wait beyond MaxWait
+// is confirmed again, e.g. for the next member of a leaderboard run
+func (c *Client) ResetRateLimitWait() {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	c.rateLimit.declined = false
+}
+
+// RateLimitWaitExceeded reports whether requests were skipped because the rate
+// limit reset was beyond MaxWait, meaning the fetched data is partial
+func (c *Client) RateLimitWaitExceeded() bool {
+	return c.rateLimit.waitDeclined()
+}
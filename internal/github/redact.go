@@ -0,0 +1,64 @@
+package github
+
+import "encoding/json"
+
+// redactPullRequest masks secrets and PII in a PR's free text, diff and
+// patches when redaction is configured (see Config.Redactor)
+func (c *Client) redactPullRequest(pr *PullRequest) {
+	if c.redactor == nil {
+		return
+	}
+	pr.Title = c.redactor.String(pr.Title)
+	pr.Body = c.redactor.String(pr.Body)
+	pr.CodeDiff = c.redactor.String(pr.CodeDiff)
+	for i := range pr.ReviewComments {
+		pr.ReviewComments[i].Body = c.redactor.String(pr.ReviewComments[i].Body)
+	}
+	for i := range pr.FilesChanged {
+		pr.FilesChanged[i].Patch = c.redactor.String(pr.FilesChanged[i].Patch)
+	}
+}
+
+// redactIssue masks secrets and PII in an issue's free text and comments
+func (c *Client) redactIssue(issue *Issue) {
+	if c.redactor == nil {
+		return
+	}
+	issue.Title = c.redactor.String(issue.Title)
+	issue.Body = c.redactor.String(issue.Body)
+	for i := range issue.Comments {
+		issue.Comments[i].Body = c.redactor.String(issue.Comments[i].Body)
+	}
+}
+
+// redactActivity masks secrets and PII in the free text of a user's
+// activity: PR and issue titles and bodies, commit messages and the commit
+// messages of push events
+func (c *Client) redactActivity(activity *ComprehensiveUserActivity) {
+	if c.redactor == nil {
+		return
+	}
+	for i := range activity.PullRequests {
+		activity.PullRequests[i].Title = c.redactor.String(activity.PullRequests[i].Title)
+		activity.PullRequests[i].Body = c.redactor.String(activity.PullRequests[i].Body)
+	}
+	for i := range activity.Issues {
+		activity.Issues[i].Title = c.redactor.String(activity.Issues[i].Title)
+		activity.Issues[i].Body = c.redactor.String(activity.Issues[i].Body)
+	}
+	for i := range activity.Commits {
+		activity.Commits[i].Commit.Message = c.redactor.String(activity.Commits[i].Commit.Message)
+	}
+	for i := range activity.Events {
+		payload, err := activity.Events[i].DecodePayload()
+		if err != nil || len(payload.Commits) == 0 {
+			continue
+		}
+		for j := range payload.Commits {
+			payload.Commits[j].Message = c.redactor.String(payload.Commits[j].Message)
+		}
+		if redacted, err := json.Marshal(payload); err == nil {
+			activity.Events[i].Payload = redacted
+		}
+	}
+}
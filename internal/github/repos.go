@@ -0,0 +1,172 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RepoActivity summarizes a user's pull requests in one repository
+type RepoActivity struct {
+	Repo      string `json:"repo"`
+	Opened    int    `json:"prs_opened"`
+	Merged    int    `json:"prs_merged"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	// LineCounts is true when at least one PR had enhanced data with additions/deletions
+	LineCounts bool `json:"line_counts"`
+}
+
+// RepoBreakdown groups the user's PRs by repository, most active first. Line
+// counts come from enhanced data of PRs also referenced in Jira, since the
+// search API does not return them.
+func RepoBreakdown(ctx *GitHubContext) []RepoActivity {
+	if ctx == nil || ctx.ComprehensiveActivity == nil {
+		return nil
+	}
+
+	enhanced := make(map[string]PullRequest, len(ctx.PullRequests))
+	for _, pr := range ctx.PullRequests {
+		if pr.HTMLURL != "" {
+			enhanced[pr.HTMLURL] = pr
+		}
+	}
+
+	byRepo := make(map[string]*RepoActivity)
+	for _, pr := range ctx.ComprehensiveActivity.PullRequests {
+		name := pr.RepoName()
+		repo, ok := byRepo[name]
+		if !ok {
+			repo = &RepoActivity{Repo: name}
+			byRepo[name] = repo
+		}
+		repo.Opened++
+		if pr.IsMerged() {
+			repo.Merged++
+		}
+		if details, ok := enhanced[pr.HTMLURL]; ok {
+			repo.Additions += details.Additions
+			repo.Deletions += details.Deletions
+			repo.LineCounts = true
+		}
+	}
+
+	breakdown := make([]RepoActivity, 0, len(byRepo))
+	for _, repo := range byRepo {
+		breakdown = append(breakdown, *repo)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].Opened != breakdown[j].Opened {
+			return breakdown[i].Opened > breakdown[j].Opened
+		}
+		return breakdown[i].Repo < breakdown[j].Repo
+	})
+	return breakdown
+}
+
+// RepositoryActivity is everyone's merged PRs and closed issues in one
+// repository over a date range
+type RepositoryActivity struct {
+	Repo         string            `json:"repo"`
+	PullRequests []UserPullRequest `json:"pull_requests"`
+	Issues       []UserIssue       `json:"issues"`
+}
+
+// Contributor is one author's share of a repository's activity
+type Contributor struct {
+	Login     string `json:"login"`
+	PRsMerged int    `json:"prs_merged"`
+	// IssuesReported counts the closed issues the contributor opened; search
+	// results don't say who closed an issue
+	IssuesReported int `json:"issues_reported"`
+}
+
+// ParseRepo splits an "owner/name" repository argument
+func ParseRepo(repo string) (owner, name string, err error) {
+	owner, name, ok := strings.Cut(strings.TrimSpace(repo), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository '%s': expected owner/name", repo)
+	}
+	return owner, name, nil
+}
+
+// FetchRepositoryActivity retrieves the PRs merged and issues closed in a
+// repository within the date range (YYYY-MM-DD), across all authors
+func (c *Client) FetchRepositoryActivity(owner, name, startDate, endDate string) (*RepositoryActivity, error) {
+	repo := owner + "/" + name
+	prs, err := searchAll[UserPullRequest](c, fmt.Sprintf("repo:%s+is:pr+is:merged+merged:%s..%s", repo, startDate, endDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search merged pull requests: %w", err)
+	}
+	issues, err := searchAll[UserIssue](c, fmt.Sprintf("repo:%s+is:issue+is:closed+closed:%s..%s", repo, startDate, endDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search closed issues: %w", err)
+	}
+	return &RepositoryActivity{Repo: repo, PullRequests: prs, Issues: issues}, nil
+}
+
+// Contributors groups the activity by author, most merged PRs first
+func (a *RepositoryActivity) Contributors() []Contributor {
+	byLogin := make(map[string]*Contributor)
+	contributor := func(login string) *Contributor {
+		if login == "" {
+			login = "unknown"
+		}
+		entry, ok := byLogin[login]
+		if !ok {
+			entry = &Contributor{Login: login}
+			byLogin[login] = entry
+		}
+		return entry
+	}
+	for _, pr := range a.PullRequests {
+		contributor(pr.User.Login).PRsMerged++
+	}
+	for _, issue := range a.Issues {
+		contributor(issue.User.Login).IssuesReported++
+	}
+
+	contributors := make([]Contributor, 0, len(byLogin))
+	for _, entry := range byLogin {
+		contributors = append(contributors, *entry)
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].PRsMerged != contributors[j].PRsMerged {
+			return contributors[i].PRsMerged > contributors[j].PRsMerged
+		}
+		if contributors[i].IssuesReported != contributors[j].IssuesReported {
+			return contributors[i].IssuesReported > contributors[j].IssuesReported
+		}
+		return contributors[i].Login < contributors[j].Login
+	})
+	return contributors
+}
+
+// searchResult is one page of search/issues results
+type searchResult[T any] struct {
+	Items []T `json:"items"`
+}
+
+// searchAll runs a search/issues query and collects every page, up to the
+// search API's 1000 result limit
+func searchAll[T any](c *Client, query string) ([]T, error) {
+	var all []T
+	for page := 1; page <= 1000/c.pageSize; page++ {
+		url := fmt.Sprintf("%s/search/issues?q=%s&sort=created&order=desc&per_page=%d&page=%d",
+			c.baseURL, query, c.pageSize, page)
+
+		result, err := c.makeGitHubRequest(url, &searchResult[T]{})
+		if err != nil {
+			return nil, err
+		}
+
+		items := result.(*searchResult[T]).Items
+		all = append(all, items...)
+
+		// A short page is the last page
+		if len(items) < c.pageSize {
+			break
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,127 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// fineGrainedTokenPrefix identifies fine-grained personal access tokens
+const fineGrainedTokenPrefix = "github_pat_"
+
+// requiredScope describes a classic token scope perfdive relies on
+type requiredScope struct {
+	name    string
+	purpose string
+}
+
+// requiredScopes lists the classic token scopes used by perfdive features
+var requiredScopes = []requiredScope{
+	{name: "repo", purpose: "reading pull requests and issues in private repositories"},
+	{name: "gist", purpose: "appending highlights to a journal gist"},
+}
+
+// TokenScopes describes the permissions reported for the configured token
+type TokenScopes struct {
+	FineGrained bool     // Token is a fine-grained PAT (scopes are not reported in headers)
+	Known       bool     // Scopes were reported via the X-OAuth-Scopes header
+	Scopes      []string // Scopes granted to the token
+	Missing     []string // Required scopes that appear to be missing, with their purpose
+}
+
+// CheckTokenScopes reports which scopes the configured token has and which required ones appear missing
+func (c *Client) CheckTokenScopes() (*TokenScopes, error) {
+	if c.token == "" {
+		return nil, fmt.Errorf("no GitHub token configured")
+	}
+
+	// Any authenticated request returns the scope headers
+	if _, err := c.GetRateLimitStatus(); err != nil {
+		return nil, err
+	}
+
+	return c.tokenScopeReport(), nil
+}
+
+// tokenScopeReport builds a TokenScopes from the scopes seen on the last authenticated response
+func (c *Client) tokenScopeReport() *TokenScopes {
+	c.scopesMu.Lock()
+	defer c.scopesMu.Unlock()
+	result := &TokenScopes{
+		FineGrained: c.isFineGrainedToken(),
+		Known:       c.scopesKnown,
+		Scopes:      c.tokenScopes,
+	}
+	if !result.Known {
+		return result
+	}
+
+	for _, req := range requiredScopes {
+		if !hasScope(c.tokenScopes, req.name) {
+			result.Missing = append(result.Missing, fmt.Sprintf("'%s' (needed for %s)", req.name, req.purpose))
+		}
+	}
+	return result
+}
+
+// recordTokenScopes captures the token scopes from an authenticated response
+func (c *Client) recordTokenScopes(resp *http.Response) {
+	values, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		return
+	}
+	scopes := parseScopes(strings.Join(values, ","))
+	c.scopesMu.Lock()
+	defer c.scopesMu.Unlock()
+	c.scopesKnown = true
+	c.tokenScopes = scopes
+}
+
+// isFineGrainedToken reports whether the configured token is a fine-grained PAT
+func (c *Client) isFineGrainedToken() bool {
+	return strings.HasPrefix(c.token, fineGrainedTokenPrefix)
+}
+
+// forbiddenHint explains a non-rate-limit 403 in terms of token permissions
+func (c *Client) forbiddenHint(resp *http.Response) string {
+	if c.token == "" {
+		return ""
+	}
+
+	if accepted := parseScopes(resp.Header.Get("X-Accepted-OAuth-Scopes")); len(accepted) > 0 {
+		granted := "none"
+		if scopes := parseScopes(resp.Header.Get("X-OAuth-Scopes")); len(scopes) > 0 {
+			granted = strings.Join(scopes, ", ")
+		}
+		return fmt.Sprintf(" (endpoint accepts scopes: %s; token has: %s)", strings.Join(accepted, ", "), granted)
+	}
+
+	c.scopesMu.Lock()
+	scopesKnown := c.scopesKnown
+	c.scopesMu.Unlock()
+	if c.isFineGrainedToken() || !scopesKnown {
+		return " (fine-grained tokens need read access to \"Pull requests\", \"Issues\" and \"Contents\" on this repository, and the repository owner must allow fine-grained token access)"
+	}
+	return ""
+}
+
+// parseScopes splits a comma-separated scope header into scope names
+func parseScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// hasScope reports whether the named scope was granted
+func hasScope(scopes []string, name string) bool {
+	for _, scope := range scopes {
+		if scope == name {
+			return true
+		}
+	}
+	return false
+}
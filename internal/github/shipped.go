@@ -0,0 +1,89 @@
+package github
+
+// Unshipped records the pull requests and issues dropped by --shipped-only.
+// PRs are tracked by key, so a PR both authored and referenced from Jira is
+// counted once.
+type Unshipped struct {
+	prs           map[PRKey]string // Status ("open" or "closed-unmerged") by PR
+	ReferencedPRs int              // Dropped PRs that were referenced from Jira
+	OpenIssues    int
+}
+
+// KeepMerged returns the merged PRs of prs, recording the others
+func (u *Unshipped) KeepMerged(prs []UserPullRequest) []UserPullRequest {
+	var merged []UserPullRequest
+	for _, pr := range prs {
+		if status := pr.Status(); status != "merged" {
+			u.drop(pr.Key(), status)
+			continue
+		}
+		merged = append(merged, pr)
+	}
+	return merged
+}
+
+// KeepShipped drops the unmerged PRs and the open issues of ctx, both those
+// referenced from Jira and the user's own, recording them
+func (u *Unshipped) KeepShipped(ctx *GitHubContext) {
+	if ctx == nil {
+		return
+	}
+	var merged []PullRequest
+	for _, pr := range ctx.PullRequests {
+		if pr.MergedAt == "" {
+			status := "closed-unmerged"
+			if pr.State == "open" {
+				status = "open"
+			}
+			if key, ok := pr.Key(); ok {
+				u.drop(key, status)
+			}
+			u.ReferencedPRs++
+			continue
+		}
+		merged = append(merged, pr)
+	}
+	ctx.PullRequests = merged
+
+	var closed []Issue
+	for _, issue := range ctx.Issues {
+		if issue.State == "open" {
+			u.OpenIssues++
+			continue
+		}
+		closed = append(closed, issue)
+	}
+	ctx.Issues = closed
+
+	if activity := ctx.ComprehensiveActivity; activity != nil {
+		activity.PullRequests = u.KeepMerged(activity.PullRequests)
+		var closedIssues []UserIssue
+		for _, issue := range activity.Issues {
+			if issue.State == "open" {
+				u.OpenIssues++
+				continue
+			}
+			closedIssues = append(closedIssues, issue)
+		}
+		activity.Issues = closedIssues
+	}
+}
+
+// PRs returns the number of open and of closed-unmerged PRs dropped
+func (u *Unshipped) PRs() (open, closedUnmerged int) {
+	for _, status := range u.prs {
+		if status == "open" {
+			open++
+		} else {
+			closedUnmerged++
+		}
+	}
+	return open, closedUnmerged
+}
+
+func (u *Unshipped) drop(key PRKey, status string) {
+	if u.prs == nil {
+		u.prs = make(map[PRKey]string)
+	}
+	u.prs[key] = status
+}
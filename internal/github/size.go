@@ -0,0 +1,171 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+)
+
+// PRSize is a GitHub-style size label for a pull request
+type PRSize string
+
+const (
+	SizeXS PRSize = "XS"
+	SizeS  PRSize = "S"
+	SizeM  PRSize = "M"
+	SizeL  PRSize = "L"
+	SizeXL PRSize = "XL"
+)
+
+// PRSizes lists the sizes from largest to smallest
+var PRSizes = []PRSize{SizeXL, SizeL, SizeM, SizeS, SizeXS}
+
+// PRStats are the size fields of a PR, which search results don't include
+type PRStats struct {
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	ChangedFiles int `json:"changed_files"`
+}
+
+// Size buckets the PR by lines and files changed, taking the larger bucket
+func (s PRStats) Size() PRSize {
+	lines := s.Additions + s.Deletions
+	lineSize := sizeFor(lines, constants.PRSizeSmallLines, constants.PRSizeMediumLines, constants.PRSizeLargeLines, constants.PRSizeXLLines)
+	fileSize := sizeFor(s.ChangedFiles, constants.PRSizeSmallFiles, constants.PRSizeMediumFiles, constants.PRSizeLargeFiles, constants.PRSizeXLFiles)
+	for _, size := range PRSizes {
+		if size == lineSize || size == fileSize {
+			return size
+		}
+	}
+	return SizeXS
+}
+
+// sizeFor returns the bucket of n given the S, M, L and XL lower bounds
+func sizeFor(n, small, medium, large, xl int) PRSize {
+	switch {
+	case n >= xl:
+		return SizeXL
+	case n >= large:
+		return SizeL
+	case n >= medium:
+		return SizeM
+	case n >= small:
+		return SizeS
+	default:
+		return SizeXS
+	}
+}
+
+// Stats returns the PR's size fields, preferring the Jira-referenced record.
+// ok is false for authored PRs that were not enhanced with size data.
+func (r PRRecord) Stats() (stats PRStats, ok bool) {
+	if r.Enhanced != nil {
+		return PRStats{Additions: r.Enhanced.Additions, Deletions: r.Enhanced.Deletions, ChangedFiles: r.Enhanced.ChangedFiles}, true
+	}
+	if r.Authored != nil && r.Authored.Stats != nil {
+		return *r.Authored.Stats, true
+	}
+	return PRStats{}, false
+}
+
+// SizeDistribution counts PRs per size; unknown counts PRs without size data
+func SizeDistribution(records []PRRecord) (counts map[PRSize]int, unknown int) {
+	counts = make(map[PRSize]int)
+	for _, r := range records {
+		stats, ok := r.Stats()
+		if !ok {
+			unknown++
+			continue
+		}
+		counts[stats.Size()]++
+	}
+	return counts, unknown
+}
+
+// FormatSizeDistribution describes a distribution largest first, e.g. "3 L, 8 S"
+func FormatSizeDistribution(counts map[PRSize]int) string {
+	var parts []string
+	for _, size := range PRSizes {
+		if counts[size] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[size], size))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EnhanceAuthoredPullRequests fills in size data for authored PRs from search,
+// reusing the Jira-referenced and cached PRs before fetching the rest, which
+// are cached for later runs. PRs whose details can't be fetched are left
+// without size data.
+func (c *Client) EnhanceAuthoredPullRequests(ctx *GitHubContext) {
+	if ctx == nil || ctx.ComprehensiveActivity == nil {
+		return
+	}
+
+	referenced := make(map[PRKey]PullRequest)
+	for _, pr := range ctx.PullRequests {
+		if key, ok := pr.Key(); ok {
+			referenced[key] = pr
+		}
+	}
+	cache, _ := c.openCache()
+
+	prs := ctx.ComprehensiveActivity.PullRequests
+	if c.refreshExpiredOnly {
+		var keys []string
+		for _, pr := range prs {
+			if _, ok := referenced[pr.Key()]; !ok && pr.Stats == nil {
+				keys = append(keys, fmt.Sprintf("%s#%d", pr.RepoName(), pr.Number))
+			}
+		}
+		c.refreshExpired(cache, "pr", keys)
+	}
+	var fetched int
+	for i := range prs {
+		pr := &prs[i]
+		if pr.Stats != nil {
+			continue
+		}
+		if full, ok := referenced[pr.Key()]; ok {
+			pr.Stats = statsOf(&full)
+			continue
+		}
+
+		owner, repo, _ := strings.Cut(pr.RepoName(), "/")
+		number := strconv.Itoa(pr.Number)
+		if cache != nil {
+			if cached, found := cache.GetBasicPR(owner, repo, number); found {
+				pr.Stats = statsOf(cached)
+				continue
+			}
+		}
+		if c.rateLimit.waitDeclined() || c.breaker.open() != nil {
+			// Past --max-wait or GitHub failing: don't spend more requests on sizes
+			continue
+		}
+		full, err := c.fetchPullRequest(owner, repo, number)
+		if err != nil {
+			c.log.Warnf("Warning: failed to fetch size of PR %s#%d: %v\n", pr.RepoName(), pr.Number, err)
+			continue
+		}
+		pr.Stats = statsOf(full)
+		fetched++
+
+		// Only the PR itself was fetched; a basic entry doesn't stand in for
+		// the enhanced PR a Jira reference needs
+		if cache != nil {
+			c.redactPullRequest(full)
+			if err := cache.SetBasicPR(owner, repo, number, full); err != nil {
+				c.log.Debugf("  failed to cache PR %s#%d: %v\n", pr.RepoName(), pr.Number, err)
+			}
+		}
+	}
+	c.log.Debugf("  Fetched sizes for %d authored PRs\n", fetched)
+}
+
+// statsOf copies the size fields of a full PR
+func statsOf(pr *PullRequest) *PRStats {
+	return &PRStats{Additions: pr.Additions, Deletions: pr.Deletions, ChangedFiles: pr.ChangedFiles}
+}
@@ -0,0 +1,108 @@
+package github
+
+import "sync/atomic"
+
+// Stats counts a client's GitHub API requests and cache lookups
+type Stats struct {
+	APICalls    int64 `json:"apiCalls"`
+	CacheHits   int64 `json:"cacheHits"`
+	CacheMisses int64 `json:"cacheMisses"`
+}
+
+// HitRatio returns the share of cache lookups that were hits, or 0 if the
+// cache was never consulted
+func (s Stats) HitRatio() float64 {
+	lookups := s.CacheHits + s.CacheMisses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(lookups)
+}
+
+// counters accumulates Stats; a client shares its counters with the caches it opens
+type counters struct {
+	apiCalls    atomic.Int64
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+}
+
+// Stats returns the requests made and cache lookups done by the client so far
+func (c *Client) Stats() Stats {
+	return Stats{
+		APICalls:    c.counters.apiCalls.Load(),
+		CacheHits:   c.counters.cacheHits.Load(),
+		CacheMisses: c.counters.cacheMisses.Load(),
+	}
+}
+
+// openCache returns the client's cache, opened on first use, with lookups
+// counted in the client's Stats, expired entries served when the client is
+// offline and nothing served with NoCache. Every fetch shares it, so saving the metadata after one fetch
+// can't drop the entries another fetch cached.
+func (c *Client) openCache() (*Cache, error) {
+	c.cacheOnce.Do(func() {
+		c.cache, c.cacheErr = NewCache()
+		if c.cache != nil {
+			c.cache.counters = &c.counters
+			c.cache.allowExpired = c.offline
+			c.cache.bypass = c.noCache
+			c.cache.maxAge = c.maxAge
+			c.cache.staleServed = &c.staleServed
+		}
+	})
+	return c.cache, c.cacheErr
+}
+
+// refreshExpired removes the expired cache entries of entryType with the
+// given keys before they are looked up (--refresh-expired-only), so they are
+// refetched while valid entries keep being served
+func (c *Client) refreshExpired(cache *Cache, entryType string, keys []string) {
+	if !c.refreshExpiredOnly || cache == nil || len(keys) == 0 {
+		return
+	}
+	removed, err := cache.CleanExpiredKeys(entryType, keys)
+	if err != nil {
+		c.log.Warnf("Warning: failed to clean expired GitHub cache entries: %v\n", err)
+		return
+	}
+	if removed > 0 {
+		c.log.Infof("  ♻ Refreshing %d expired GitHub cache entries\n", removed)
+	}
+}
+
+// countLookup records a cache hit or miss in memory, to be persisted by the
+// next metadata save or SaveCounts, and, when the cache belongs to a client,
+// in the client's Stats
+func (c *Cache) countLookup(hit bool) {
+	c.mu.Lock()
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+
+	switch {
+	case c.counters == nil:
+	case hit:
+		c.counters.cacheHits.Add(1)
+	default:
+		c.counters.cacheMisses.Add(1)
+	}
+}
+
+// SaveCacheCounts persists the cache lookups counted since the last save, once
+// the client is done, so 'cache stats' reports hits and misses across runs
+func (c *Client) SaveCacheCounts() error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.SaveCounts()
+}
+
+// StaleCacheEntries returns the cache entries served although they were older
+// than Config.MaxAge, e.g. "PR owner/repo#12, cached 3h20m ago", once each in
+// the order they were first served
+func (c *Client) StaleCacheEntries() []string {
+	return c.staleServed.Entries()
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// gitObject is the object a git ref or annotated tag points at
+type gitObject struct {
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+}
+
+// ParseRepoTag splits an "owner/name@tag" argument such as
+// "redhat-best-practices-for-k8s/certsuite@v5.4.0"
+func ParseRepoTag(spec string) (owner, name, tag string, err error) {
+	repo, tag, ok := strings.Cut(strings.TrimSpace(spec), "@")
+	if !ok || tag == "" {
+		return "", "", "", fmt.Errorf("invalid tag '%s': expected owner/name@tag", spec)
+	}
+	owner, name, err = ParseRepo(repo)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid tag '%s': expected owner/name@tag", spec)
+	}
+	return owner, name, tag, nil
+}
+
+// TagDate returns the committer date of the commit a tag points at,
+// following annotated tags to their commit
+func (c *Client) TagDate(owner, name, tag string) (time.Time, error) {
+	repoURL := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, name)
+
+	var ref struct {
+		Object gitObject `json:"object"`
+	}
+	// git/ref (singular) matches the tag exactly; git/refs would also list tags it prefixes
+	if _, err := c.makeGitHubRequest(repoURL+"/git/ref/tags/"+escapeRefPath(tag), &ref); err != nil {
+		return time.Time{}, fmt.Errorf("failed to look up tag %s in %s/%s: %w", tag, owner, name, err)
+	}
+
+	object := ref.Object
+	if object.Type == "tag" {
+		var annotated struct {
+			Object gitObject `json:"object"`
+		}
+		if _, err := c.makeGitHubRequest(repoURL+"/git/tags/"+object.SHA, &annotated); err != nil {
+			return time.Time{}, fmt.Errorf("failed to read annotated tag %s: %w", tag, err)
+		}
+		object = annotated.Object
+	}
+	if object.Type != "commit" {
+		return time.Time{}, fmt.Errorf("tag %s points at a %s, not a commit", tag, object.Type)
+	}
+
+	var commit struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	}
+	if _, err := c.makeGitHubRequest(repoURL+"/git/commits/"+object.SHA, &commit); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the commit of tag %s: %w", tag, err)
+	}
+	return commit.Committer.Date, nil
+}
+
+// escapeRefPath escapes each segment of a ref name, keeping the slashes of
+// names like release/1.0 as path separators
+func escapeRefPath(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
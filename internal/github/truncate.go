@@ -0,0 +1,74 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDiffDownload caps how much of a PR diff is read to measure it; larger
+// diffs are reported as at least this size
+const maxDiffDownload = 10 << 20
+
+// truncateAtLine cuts s to at most limit bytes at the last line break within
+// the limit, so the model never sees a line sliced mid-token. If the first
+// line alone is longer than the limit, nothing is kept.
+func truncateAtLine(s string, limit int) (string, bool) {
+	if len(s) <= limit {
+		return s, false
+	}
+	if i := strings.LastIndexByte(s[:limit], '\n'); i >= 0 {
+		return s[:i], true
+	}
+	return "", true
+}
+
+// diffTruncationMarker starts the line truncateDiff ends a truncated diff with
+const diffTruncationMarker = "\n... (truncated: showing "
+
+// DiffTruncated reports whether the PR's diff was cut to the diff size limit
+func (pr PullRequest) DiffTruncated() bool {
+	return strings.Contains(pr.CodeDiff, diffTruncationMarker)
+}
+
+// truncateDiff truncates a unified diff for AI processing, ending with a
+// marker giving the real sizes and file count. complete is false when only
+// the first maxDiffDownload bytes were read.
+func truncateDiff(diff string, limit int, complete bool) string {
+	kept, truncated := truncateAtLine(diff, limit)
+	if !truncated {
+		return diff
+	}
+	total := formatByteSize(len(diff))
+	files := strconv.Itoa(strings.Count("\n"+diff, "\ndiff --git "))
+	if !complete {
+		total += "+"
+		files += "+"
+	}
+	return fmt.Sprintf("%s"+diffTruncationMarker+"%s of %s diff across %s files)", kept, formatByteSize(len(kept)), total, files)
+}
+
+// truncatePatch truncates one file's patch for AI processing, ending with a
+// marker giving the real sizes
+func truncatePatch(patch string, limit int) string {
+	kept, truncated := truncateAtLine(patch, limit)
+	if !truncated {
+		return patch
+	}
+	return fmt.Sprintf("%s\n... (truncated: showing %s of %s patch)", kept, formatByteSize(len(kept)), formatByteSize(len(patch)))
+}
+
+// formatByteSize formats n bytes as e.g. "512B", "5KB" or "1.5MB"
+func formatByteSize(n int) string {
+	format := func(v float64, unit string) string {
+		return strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0") + unit
+	}
+	switch {
+	case n < 1000:
+		return strconv.Itoa(n) + "B"
+	case n < 1000*1000:
+		return format(float64(n)/1000, "KB")
+	default:
+		return format(float64(n)/(1000*1000), "MB")
+	}
+}
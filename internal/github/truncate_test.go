@@ -0,0 +1,116 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestTruncateAtLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		limit         int
+		want          string
+		wantTruncated bool
+	}{
+		{name: "fits", input: "a\nb\n", limit: 4, want: "a\nb\n"},
+		{name: "cut mid-line backs off to the line break", input: "+foo\n+barbaz\n", limit: 8, want: "+foo", wantTruncated: true},
+		{name: "limit on a line break", input: "+foo\n+bar\n", limit: 5, want: "+foo", wantTruncated: true},
+		{name: "first line too long", input: "+averylongline\n", limit: 5, want: "", wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := truncateAtLine(tt.input, tt.limit)
+			if got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("truncateAtLine(%q, %d) = %q, %v; want %q, %v", tt.input, tt.limit, got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestFetchPRDiffTruncatesAtLineBoundary(t *testing.T) {
+	// 12 files of 3.5KB each, with line lengths that don't divide the limit
+	var sb strings.Builder
+	for file := 0; file < 12; file++ {
+		fmt.Fprintf(&sb, "diff --git a/f%d.go b/f%d.go\n", file, file)
+		for sb.Len() < (file+1)*3500 {
+			sb.WriteString("+\tvalue := compute(alpha, beta, gamma)\n")
+		}
+	}
+	diff := sb.String()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(diff))
+	}))
+	defer server.Close()
+	client := NewClient(Config{BaseURL: server.URL, Logger: logger.Nop()})
+
+	got, err := client.fetchPRDiff("o", "r", "1")
+	if err != nil {
+		t.Fatalf("fetchPRDiff() error = %v", err)
+	}
+	kept, marker, found := strings.Cut(got, "\n... (truncated: ")
+	if !found {
+		t.Fatalf("fetchPRDiff() = %q, want a truncation marker", got)
+	}
+	if len(kept) > constants.DefaultDiffSizeLimit || !strings.HasPrefix(diff, kept+"\n") {
+		t.Errorf("kept %d bytes not ending on a line boundary of the diff", len(kept))
+	}
+	if want := fmt.Sprintf("showing %s of 42KB diff across 12 files)", formatByteSize(len(kept))); marker != want {
+		t.Errorf("marker = %q, want %q", marker, want)
+	}
+}
+
+func TestTruncatePatch(t *testing.T) {
+	patch := "@@ -1,3 +1,3 @@\n" + strings.Repeat("-old line of code\n+new line of code\n", 100)
+	got := truncatePatch(patch, constants.DefaultPatchSizeLimit)
+
+	kept, marker, found := strings.Cut(got, "\n... (truncated: ")
+	if !found || !strings.HasPrefix(patch, kept+"\n") {
+		t.Fatalf("truncatePatch() = %q, want whole lines and a marker", got)
+	}
+	if want := fmt.Sprintf("showing %s of 3.6KB patch)", formatByteSize(len(kept))); marker != want {
+		t.Errorf("marker = %q, want %q", marker, want)
+	}
+	if short := "@@ -1 +1 @@\n-a\n+b"; truncatePatch(short, constants.DefaultPatchSizeLimit) != short {
+		t.Error("truncatePatch() changed a patch under the limit")
+	}
+}
+
+func TestConfiguredSizeLimits(t *testing.T) {
+	patch := "@@ -1,3 +1,3 @@\n" + strings.Repeat("-old line of code\n+new line of code\n", 20)
+	diff := "diff --git a/f.go b/f.go\n" + patch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/files") {
+			fmt.Fprintf(w, `[{"filename": "f.go", "patch": %q}]`, patch)
+			return
+		}
+		_, _ = w.Write([]byte(diff))
+	}))
+	defer server.Close()
+
+	// Both fit the defaults, but not the configured limits
+	client := NewClient(Config{BaseURL: server.URL, Logger: logger.Nop(), DiffSizeLimit: 200, PatchSizeLimit: 100})
+	got, err := client.fetchPRDiff("o", "r", "1")
+	if err != nil {
+		t.Fatalf("fetchPRDiff() error = %v", err)
+	}
+	if kept, _, found := strings.Cut(got, diffTruncationMarker); !found || len(kept) > 200 {
+		t.Errorf("fetchPRDiff() kept %d bytes (truncated %v), want at most api.diff_size_limit 200", len(kept), found)
+	}
+
+	files, err := client.fetchPRFiles("o", "r", "1")
+	if err != nil {
+		t.Fatalf("fetchPRFiles() error = %v", err)
+	}
+	if kept, _, found := strings.Cut(files[0].Patch, "\n... (truncated: "); len(files) != 1 || !found || len(kept) > 100 {
+		t.Errorf("patch kept %d bytes (truncated %v), want at most api.patch_size_limit 100", len(kept), found)
+	}
+}
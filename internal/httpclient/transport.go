@@ -0,0 +1,46 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// NewTransport creates an HTTP transport that honors HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY, and optionally trusts an extra root CA (PEM file) on top of
+// the system pool, for corporate TLS-intercepting proxies.
+func NewTransport(caCertPath string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if caCertPath == "" {
+		return transport, nil
+	}
+
+	pem, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid PEM certificates found in %s", caCertPath)
+	}
+
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return transport, nil
+}
+
+// DefaultTransport returns a proxy-aware transport without extra CAs
+func DefaultTransport() *http.Transport {
+	transport, _ := NewTransport("")
+	return transport
+}
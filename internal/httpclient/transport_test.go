@@ -0,0 +1,111 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewTransportProxyFromEnvironment(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	transport, err := NewTransport("")
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		wantProxy string
+	}{
+		{"proxied host", "https://api.github.com/rate_limit", "http://proxy.example.com:3128"},
+		{"NO_PROXY host", "https://internal.example.com/api", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			proxyURL, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy() error = %v", err)
+			}
+			got := ""
+			if proxyURL != nil {
+				got = proxyURL.String()
+			}
+			if got != tt.wantProxy {
+				t.Errorf("Proxy(%s) = %q, want %q", tt.url, got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestNewTransportCACert(t *testing.T) {
+	dir := t.TempDir()
+
+	validPath := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(validPath, selfSignedCAPEM(t), 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalidPath := filepath.Join(dir, "garbage.pem")
+	if err := os.WriteFile(invalidPath, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"valid CA", validPath, false},
+		{"invalid PEM", invalidPath, true},
+		{"missing file", filepath.Join(dir, "missing.pem"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransport(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTransport(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if !tt.wantErr && (transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil) {
+				t.Errorf("NewTransport(%q) did not configure RootCAs", tt.path)
+			}
+		})
+	}
+}
+
+// selfSignedCAPEM generates a throwaway CA certificate in PEM form
+func selfSignedCAPEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "perfdive test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,41 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AuthType selects how requests to Jira are authenticated
+type AuthType string
+
+const (
+	// AuthPAT sends the token as a bearer personal access token, as
+	// self-hosted Jira (e.g. issues.redhat.com) expects
+	AuthPAT AuthType = "pat"
+	// AuthBasic sends the username (the Atlassian account email) and an API
+	// token with HTTP Basic auth, as Jira Cloud (*.atlassian.net) expects
+	AuthBasic AuthType = "basic"
+)
+
+// ParseAuthType parses a --jira-auth-type value. An empty value picks basic
+// for Jira Cloud URLs and pat otherwise.
+func ParseAuthType(value, jiraURL string) (AuthType, error) {
+	switch auth := AuthType(strings.ToLower(strings.TrimSpace(value))); auth {
+	case "":
+		return defaultAuthType(jiraURL), nil
+	case AuthPAT, AuthBasic:
+		return auth, nil
+	default:
+		return "", fmt.Errorf("unknown Jira auth type '%s': supported types are pat, basic", value)
+	}
+}
+
+// defaultAuthType returns basic for Jira Cloud sites and pat otherwise
+func defaultAuthType(jiraURL string) AuthType {
+	u, err := url.Parse(jiraURL)
+	if err == nil && strings.HasSuffix(strings.ToLower(u.Hostname()), ".atlassian.net") {
+		return AuthBasic
+	}
+	return AuthPAT
+}
@@ -0,0 +1,239 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// basicClient talks to the Jira REST API with Basic auth, since jiracrawler
+// only supports bearer tokens. It returns the same Issue values. With bearer
+// set it sends the token as a PAT instead, for fields jiracrawler doesn't expose.
+type basicClient struct {
+	baseURL    string
+	username   string
+	token      string
+	bearer     bool
+	httpClient *http.Client
+}
+
+// basicPageSize is how many issues are requested per search page
+const basicPageSize = 100
+
+// basicMaxIssues caps a search without a limit, so a broad query can't page forever
+const basicMaxIssues = 1000
+
+// basicSearchFields are the fields requested from searches; enhanced fetches add more
+const basicSearchFields = "summary,description,status,priority,issuetype,project,assignee,reporter,creator,created,updated,resolutiondate"
+
+// restIssue is an issue as returned by the Jira REST API v2
+type restIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary        string    `json:"summary"`
+		Description    string    `json:"description"`
+		Status         Status    `json:"status"`
+		Priority       *Priority `json:"priority"`
+		IssueType      IssueType `json:"issuetype"`
+		Project        Project   `json:"project"`
+		Assignee       *User     `json:"assignee"`
+		Reporter       *User     `json:"reporter"`
+		Creator        *User     `json:"creator"`
+		Created        string    `json:"created"`
+		Updated        string    `json:"updated"`
+		ResolutionDate string    `json:"resolutiondate"`
+
+		// Requested by enhanced fetches only
+		Comment struct {
+			Comments []struct {
+				ID      string     `json:"id"`
+				Author  restAuthor `json:"author"`
+				Body    string     `json:"body"`
+				Created string     `json:"created"`
+				Updated string     `json:"updated"`
+			} `json:"comments"`
+		} `json:"comment"`
+		Labels     []string `json:"labels"`
+		Components []struct {
+			Name string `json:"name"`
+		} `json:"components"`
+		TimeTracking *TimeTracking `json:"timetracking"`
+	} `json:"fields"`
+	Changelog struct {
+		Histories []struct {
+			ID      string          `json:"id"`
+			Author  restAuthor      `json:"author"`
+			Created string          `json:"created"`
+			Items   []HistoryChange `json:"items"`
+		} `json:"histories"`
+	} `json:"changelog"`
+}
+
+// restAuthor is the author of a comment or history entry
+type restAuthor struct {
+	DisplayName string `json:"displayName"`
+}
+
+// search runs a JQL query, following pages up to maxResults issues
+// (0 = basicMaxIssues). It uses the search/jql endpoint Jira Cloud requires.
+func (b *basicClient) search(jql string, maxResults int) ([]Issue, error) {
+	if maxResults <= 0 {
+		maxResults = basicMaxIssues
+	}
+
+	var issues []Issue
+	pageToken := ""
+	for len(issues) < maxResults {
+		query := url.Values{
+			"jql":        {jql},
+			"fields":     {basicSearchFields},
+			"maxResults": {strconv.Itoa(min(basicPageSize, maxResults-len(issues)))},
+		}
+		if pageToken != "" {
+			query.Set("nextPageToken", pageToken)
+		}
+
+		var page struct {
+			Issues        []restIssue `json:"issues"`
+			NextPageToken string      `json:"nextPageToken"`
+			IsLast        bool        `json:"isLast"`
+		}
+		if err := b.get("/rest/api/2/search/jql?"+query.Encode(), &page); err != nil {
+			return nil, fmt.Errorf("executing JQL query: %w", err)
+		}
+		for _, issue := range page.Issues {
+			issues = append(issues, issue.toIssue())
+		}
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	return issues, nil
+}
+
+// fetchEnhancedIssue fetches one issue with comments, history, labels,
+// components and time tracking in a single request
+func (b *basicClient) fetchEnhancedIssue(key string) (*Issue, error) {
+	query := url.Values{
+		"fields": {basicSearchFields + ",comment,labels,components,timetracking"},
+		"expand": {"changelog"},
+	}
+	var raw restIssue
+	if err := b.get("/rest/api/2/issue/"+url.PathEscape(key)+"?"+query.Encode(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+
+	issue := raw.toIssue()
+	for _, c := range raw.Fields.Comment.Comments {
+		issue.Comments = append(issue.Comments, Comment{
+			ID:      c.ID,
+			Author:  c.Author.DisplayName,
+			Body:    c.Body,
+			Created: parseJiraTime(c.Created),
+			Updated: parseJiraTime(c.Updated),
+		})
+	}
+	for _, h := range raw.Changelog.Histories {
+		issue.History = append(issue.History, HistoryItem{
+			ID:      h.ID,
+			Author:  h.Author.DisplayName,
+			Created: parseJiraTime(h.Created),
+			Items:   h.Items,
+		})
+	}
+	issue.Labels = raw.Fields.Labels
+	for _, component := range raw.Fields.Components {
+		issue.Components = append(issue.Components, component.Name)
+	}
+	issue.TimeTracking = raw.Fields.TimeTracking
+	return &issue, nil
+}
+
+// myself returns the authenticated user, verifying the credentials
+func (b *basicClient) myself() (*UserInfo, error) {
+	var user User
+	if err := b.get("/rest/api/2/myself", &user); err != nil {
+		return nil, err
+	}
+	return &UserInfo{
+		Username:    b.username,
+		DisplayName: user.DisplayName,
+		Email:       user.EmailAddress,
+		Active:      user.Active,
+	}, nil
+}
+
+// get sends an authenticated GET request and decodes the JSON response into target
+func (b *basicClient) get(path string, target interface{}) error {
+	req, err := http.NewRequest("GET", b.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if b.bearer {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	} else {
+		req.SetBasicAuth(b.username, b.token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if resp.StatusCode == http.StatusUnauthorized && !b.bearer {
+			return fmt.Errorf("jira API returned status 401: check that jira.username is your Atlassian account email and the token is an API token")
+		}
+		return fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// toIssue converts the REST representation, formatting timestamps as
+// jiracrawler does (RFC 3339) so cached and fresh issues compare equal
+func (r restIssue) toIssue() Issue {
+	issue := Issue{
+		Key:         r.Key,
+		Summary:     r.Fields.Summary,
+		Description: r.Fields.Description,
+		Status:      r.Fields.Status,
+		IssueType:   r.Fields.IssueType,
+		Project:     r.Fields.Project,
+		Assignee:    r.Fields.Assignee,
+		Reporter:    r.Fields.Reporter,
+		Creator:     r.Fields.Creator,
+		Created:     formatJiraTime(r.Fields.Created),
+		Updated:     formatJiraTime(r.Fields.Updated),
+	}
+	if r.Fields.Priority != nil {
+		issue.Priority = *r.Fields.Priority
+	}
+	if r.Fields.ResolutionDate != "" {
+		issue.Resolved = formatJiraTime(r.Fields.ResolutionDate)
+	}
+	return issue
+}
+
+// parseJiraTime parses a Jira timestamp, returning the zero time if it is malformed
+func parseJiraTime(value string) time.Time {
+	t, _ := ParseTime(value)
+	return t
+}
+
+// formatJiraTime reformats a Jira timestamp as RFC 3339, keeping unparseable values as-is
+func formatJiraTime(value string) string {
+	t, ok := ParseTime(value)
+	if !ok {
+		return value
+	}
+	return t.Format(time.RFC3339)
+}
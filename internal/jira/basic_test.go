@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestParseAuthType(t *testing.T) {
+	tests := []struct {
+		value   string
+		url     string
+		want    AuthType
+		wantErr bool
+	}{
+		{value: "", url: "https://issues.redhat.com", want: AuthPAT},
+		{value: "", url: "https://example.atlassian.net", want: AuthBasic},
+		{value: "PAT", url: "https://example.atlassian.net", want: AuthPAT},
+		{value: " basic ", url: "https://issues.redhat.com", want: AuthBasic},
+		{value: "oauth", url: "https://issues.redhat.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+"@"+tt.url, func(t *testing.T) {
+			got, err := ParseAuthType(tt.value, tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAuthType(%q, %q) error = %v, wantErr %v", tt.value, tt.url, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAuthType(%q, %q) = %q, want %q", tt.value, tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasicAuthClient(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "api-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			_, _ = w.Write([]byte(`{"displayName": "Me", "emailAddress": "me@example.com", "active": true}`))
+		case "/rest/api/2/search/jql":
+			if r.URL.Query().Get("nextPageToken") == "" {
+				_, _ = w.Write([]byte(`{"issues": [{"key": "CNF-1", "fields": {"summary": "First", "updated": "2025-01-02T10:00:00.000+0000"}}], "nextPageToken": "p2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"issues": [{"key": "CNF-2", "fields": {"summary": "Second", "updated": "2025-01-03T10:00:00+0000"}}], "isLast": true}`))
+		case "/rest/api/2/issue/CNF-1":
+			_, _ = w.Write([]byte(`{"key": "CNF-1", "fields": {"summary": "First", "updated": "2025-01-02T10:00:00.000+0000",
+				"labels": ["perf"], "comment": {"comments": [{"id": "1", "author": {"displayName": "Reviewer"}, "body": "looks good", "created": "2025-01-02T11:00:00.5+0000"}]}},
+				"changelog": {"histories": [{"id": "9", "author": {"displayName": "Me"}, "created": "2025-01-02T12:00:00.000+0000", "items": [{"field": "status", "fromString": "New", "toString": "Done"}]}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{URL: server.URL, Username: "me@example.com", Token: "api-token", Logger: logger.Nop(), AuthType: AuthBasic})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() error = %v", err)
+	}
+
+	issues, err := client.searchJQL(`assignee = "me@example.com"`, 0)
+	if err != nil {
+		t.Fatalf("searchJQL() error = %v", err)
+	}
+	// Timestamps convert whatever their number of fractional digits
+	if len(issues) != 2 || issues[1].Key != "CNF-2" || issues[0].Updated != "2025-01-02T10:00:00Z" || issues[1].Updated != "2025-01-03T10:00:00Z" {
+		t.Errorf("searchJQL() = %+v, want CNF-1 and CNF-2 across both pages with RFC 3339 times", issues)
+	}
+
+	issue, err := client.GetIssueByKey("CNF-1", true)
+	if err != nil {
+		t.Fatalf("GetIssueByKey() error = %v", err)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Author != "Reviewer" || len(issue.History) != 1 || len(issue.Labels) != 1 {
+		t.Errorf("GetIssueByKey() = %+v, want one comment, one history entry and one label", issue)
+	} else if issue.Comments[0].Created.IsZero() {
+		t.Errorf("comment created = %v, want the timestamp with one fractional digit parsed", issue.Comments[0].Created)
+	}
+
+	bad, _ := NewClient(Config{URL: server.URL, Username: "me@example.com", Token: "wrong", Logger: logger.Nop(), AuthType: AuthBasic})
+	if err := bad.TestConnection(); err == nil {
+		t.Error("TestConnection() with a bad token succeeded, want error")
+	}
+}
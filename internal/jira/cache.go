@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
 )
 
 // Cache handles caching of Jira issues
@@ -16,18 +21,47 @@ type Cache struct {
 	metadata     *CacheMetadata
 	metadataPath string
 	mu           sync.RWMutex
+	log          logger.Logger // Diagnostic logger of the owning client (defaults to stderr)
+
+	// allowExpired serves expired entries instead of discarding them (--offline)
+	allowExpired bool
+
+	// bypass serves nothing, so every lookup refetches (--no-cache)
+	bypass bool
+
+	// maxAge records entries served older than it in staleServed (--max-age)
+	maxAge      time.Duration
+	staleServed *cachelog.Log
+
+	// changed holds the metadata files set or removed since the last save,
+	// cleared whether every entry was removed, and hits and misses the
+	// lookups not yet added to the persisted counts
+	changed map[string]bool
+	cleared bool
+	hits    int
+	misses  int
 }
 
+// metadataFileMu serializes the read-merge-write of the metadata file by the
+// caches of this process
+var metadataFileMu sync.Mutex
+
 // IssueCacheEntry represents a cached Jira issue
 type IssueCacheEntry struct {
 	Data      *Issue    `json:"data"`
 	Timestamp time.Time `json:"timestamp"`
 	IssueKey  string    `json:"issue_key"`
+
+	// Enhanced records whether Data includes comments, history and other enhanced context
+	Enhanced bool `json:"enhanced"`
 }
 
-// CacheMetadata tracks all cache entries with their expiration
+// CacheMetadata tracks all cache entries with their expiration, and lookup
+// hits and misses across runs
 type CacheMetadata struct {
 	Entries map[string]CacheMetadataEntry `json:"entries"`
+	Hits    int                           `json:"hits"`
+	Misses  int                           `json:"misses"`
 }
 
 // CacheMetadataEntry represents metadata for a single cache entry
@@ -56,6 +90,7 @@ func NewCache() (*Cache, error) {
 		cacheDir:     cacheDir,
 		ttl:          24 * time.Hour, // 24-hour cache for Jira issues
 		metadataPath: metadataPath,
+		log:          logger.Default(constants.VerbosityQuiet),
 		metadata:     &CacheMetadata{Entries: make(map[string]CacheMetadataEntry)},
 	}
 
@@ -81,17 +116,65 @@ func (c *Cache) loadMetadata() error {
 	return json.Unmarshal(data, c.metadata)
 }
 
-// saveMetadata saves cache metadata to disk
+// saveMetadata writes the entries this cache changed and its unsaved lookup
+// counts into the metadata on disk. The file is re-read first, so entries
+// and counts other caches saved since this one loaded are kept.
 func (c *Cache) saveMetadata() error {
+	metadataFileMu.Lock()
+	defer metadataFileMu.Unlock()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	data, err := json.MarshalIndent(c.metadata, "", "  ")
+	merged := &CacheMetadata{}
+	if data, err := os.ReadFile(c.metadataPath); err == nil {
+		_ = json.Unmarshal(data, merged)
+	}
+	if merged.Entries == nil || c.cleared {
+		merged.Entries = make(map[string]CacheMetadataEntry)
+	}
+	for filename := range c.changed {
+		if entry, ok := c.metadata.Entries[filename]; ok {
+			merged.Entries[filename] = entry
+		} else {
+			delete(merged.Entries, filename)
+		}
+	}
+	merged.Hits += c.hits
+	merged.Misses += c.misses
+
+	data, err := json.MarshalIndent(merged, "", "  ")
 	if err != nil {
 		return err
 	}
+	if err := os.WriteFile(c.metadataPath, data, 0644); err != nil {
+		return err
+	}
+
+	c.metadata = merged
+	c.changed, c.cleared = nil, false
+	c.hits, c.misses = 0, 0
+	return nil
+}
+
+// markChanged records that the metadata entry of filename was set or
+// removed; the caller holds c.mu
+func (c *Cache) markChanged(filename string) {
+	if c.changed == nil {
+		c.changed = make(map[string]bool)
+	}
+	c.changed[filename] = true
+}
 
-	return os.WriteFile(c.metadataPath, data, 0644)
+// SaveCounts adds the lookups counted since the last save to the hit and
+// miss totals persisted in the metadata
+func (c *Cache) SaveCounts() error {
+	c.mu.RLock()
+	unsaved := c.hits > 0 || c.misses > 0
+	c.mu.RUnlock()
+	if !unsaved {
+		return nil
+	}
+	return c.saveMetadata()
 }
 
 // updateMetadata adds or updates a metadata entry
@@ -106,6 +189,7 @@ func (c *Cache) updateMetadata(filename, issueKey string) {
 		Type:    "issue",
 		Key:     issueKey,
 	}
+	c.markChanged(filename)
 }
 
 // isExpired checks if a cache entry is expired based on metadata
@@ -118,7 +202,7 @@ func (c *Cache) isExpired(filename string) bool {
 		return true
 	}
 
-	return time.Now().After(entry.Expires)
+	return !c.allowExpired && time.Now().After(entry.Expires)
 }
 
 // getCacheFilename generates a cache filename for a Jira issue
@@ -131,6 +215,48 @@ func (c *Cache) getCacheFilename(issueKey string) string {
 
 // GetIssue retrieves a cached Jira issue if it exists and is not expired (24-hour TTL)
 func (c *Cache) GetIssue(issueKey string) (*Issue, bool) {
+	if c.bypass {
+		return nil, false
+	}
+	entry, found := c.getEntry(issueKey)
+	c.countLookup(found)
+	if !found {
+		return nil, false
+	}
+	c.staleServed.CheckAge("Jira issue "+issueKey, entry.Timestamp, c.maxAge)
+	return entry.Data, true
+}
+
+// GetIssueWithContext retrieves a cached Jira issue like GetIssue, but when
+// enhancedContext is requested only entries cached with enhanced context match
+func (c *Cache) GetIssueWithContext(issueKey string, enhancedContext bool) (*Issue, bool) {
+	if c.bypass {
+		return nil, false
+	}
+	entry, found := c.getEntry(issueKey)
+	hit := found && (!enhancedContext || entry.Enhanced)
+	c.countLookup(hit)
+	if !hit {
+		return nil, false
+	}
+	c.staleServed.CheckAge("Jira issue "+issueKey, entry.Timestamp, c.maxAge)
+	return entry.Data, true
+}
+
+// countLookup records a cache hit or miss in memory, to be persisted by the
+// next metadata save or SaveCounts
+func (c *Cache) countLookup(hit bool) {
+	c.mu.Lock()
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+}
+
+// getEntry loads an unexpired cache entry
+func (c *Cache) getEntry(issueKey string) (*IssueCacheEntry, bool) {
 	filename := c.getCacheFilename(issueKey)
 	cacheFile := filepath.Join(c.cacheDir, filename)
 
@@ -151,16 +277,22 @@ func (c *Cache) GetIssue(issueKey string) (*Issue, bool) {
 	}
 
 	// Double-check with embedded timestamp (24-hour TTL)
-	if time.Since(entry.Timestamp) > 24*time.Hour {
+	if !c.allowExpired && time.Since(entry.Timestamp) > 24*time.Hour {
 		_ = os.Remove(cacheFile)
 		return nil, false
 	}
 
-	return entry.Data, true
+	return &entry, true
 }
 
 // SetIssue stores a Jira issue in the cache with 24-hour TTL
 func (c *Cache) SetIssue(issue *Issue) error {
+	return c.SetIssueWithContext(issue, false)
+}
+
+// SetIssueWithContext stores a Jira issue, recording whether it was fetched
+// with enhanced context
+func (c *Cache) SetIssueWithContext(issue *Issue, enhancedContext bool) error {
 	if issue == nil || issue.Key == "" {
 		return fmt.Errorf("invalid issue: missing key")
 	}
@@ -169,6 +301,7 @@ func (c *Cache) SetIssue(issue *Issue) error {
 		Data:      issue,
 		Timestamp: time.Now(),
 		IssueKey:  issue.Key,
+		Enhanced:  enhancedContext,
 	}
 
 	jsonData, err := json.Marshal(entry)
@@ -204,12 +337,34 @@ func (c *Cache) GetIssues(issueKeys []string) (map[string]*Issue, []string) {
 	return cached, missing
 }
 
+// Entries returns every cached issue entry, sorted by issue key, for listing
+// issues without a Jira search (--offline)
+func (c *Cache) Entries() []*IssueCacheEntry {
+	c.mu.RLock()
+	var keys []string
+	for _, entry := range c.metadata.Entries {
+		if entry.Type == "issue" {
+			keys = append(keys, entry.Key)
+		}
+	}
+	c.mu.RUnlock()
+	sort.Strings(keys)
+
+	var entries []*IssueCacheEntry
+	for _, key := range keys {
+		if entry, found := c.getEntry(key); found {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 // SetIssues stores multiple Jira issues in the cache
 func (c *Cache) SetIssues(issues []Issue) error {
 	for i := range issues {
 		if err := c.SetIssue(&issues[i]); err != nil {
 			// Log error but continue with other issues
-			fmt.Printf("Warning: failed to cache issue %s: %v\n", issues[i].Key, err)
+			c.log.Warnf("Warning: failed to cache issue %s: %v\n", issues[i].Key, err)
 		}
 	}
 	return nil
@@ -231,6 +386,7 @@ func (c *Cache) Clear() error {
 	// Clear metadata
 	c.mu.Lock()
 	c.metadata.Entries = make(map[string]CacheMetadataEntry)
+	c.changed, c.cleared = nil, true
 	c.mu.Unlock()
 	
 	return c.saveMetadata()
@@ -238,34 +394,52 @@ func (c *Cache) Clear() error {
 
 // CleanExpired removes expired cache entries based on metadata
 func (c *Cache) CleanExpired() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	_, err := c.removeExpired(func(CacheMetadataEntry) bool { return true })
+	return err
+}
 
+// CleanExpiredIssues removes the expired entries of the given issue keys,
+// leaving valid entries and other issues untouched. It returns the number of
+// entries removed.
+func (c *Cache) CleanExpiredIssues(issueKeys []string) (int, error) {
+	wanted := make(map[string]bool, len(issueKeys))
+	for _, key := range issueKeys {
+		wanted[key] = true
+	}
+	return c.removeExpired(func(entry CacheMetadataEntry) bool {
+		return wanted[entry.Key]
+	})
+}
+
+// removeExpired deletes the expired entries accepted by match and returns how many were removed
+func (c *Cache) removeExpired(match func(CacheMetadataEntry) bool) (int, error) {
+	c.mu.Lock()
 	now := time.Now()
 	toDelete := []string{}
 
-	// Find all expired entries in metadata
+	// Find all matching expired entries in metadata
 	for filename, entry := range c.metadata.Entries {
-		if now.After(entry.Expires) {
+		if now.After(entry.Expires) && match(entry) {
 			toDelete = append(toDelete, filename)
-			
+
 			// Delete the actual cache file
-			filePath := filepath.Join(c.cacheDir, filename)
-			_ = os.Remove(filePath)
+			_ = os.Remove(filepath.Join(c.cacheDir, filename))
 		}
 	}
 
 	// Remove from metadata
 	for _, filename := range toDelete {
 		delete(c.metadata.Entries, filename)
+		c.markChanged(filename)
 	}
+	c.mu.Unlock()
 
 	// Save updated metadata if any entries were deleted
 	if len(toDelete) > 0 {
-		return c.saveMetadata()
+		return len(toDelete), c.saveMetadata()
 	}
 
-	return nil
+	return 0, nil
 }
 
 // GetCacheStats returns statistics about the cache
@@ -274,8 +448,10 @@ func (c *Cache) GetCacheStats() map[string]interface{} {
 	defer c.mu.RUnlock()
 
 	stats := map[string]interface{}{
-		"total": len(c.metadata.Entries),
-		"ttl":   "24 hours",
+		"total":  len(c.metadata.Entries),
+		"ttl":    "24 hours",
+		"hits":   c.metadata.Hits + c.hits,
+		"misses": c.metadata.Misses + c.misses,
 	}
 
 	return stats
@@ -0,0 +1,95 @@
+package jira
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+func TestCacheGetIssueWithContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	if err := cache.SetIssueWithContext(&Issue{Key: "CNF-1"}, false); err != nil {
+		t.Fatalf("SetIssueWithContext() error = %v", err)
+	}
+	if _, found := cache.GetIssueWithContext("CNF-1", false); !found {
+		t.Error("basic entry not served for a basic request")
+	}
+	if _, found := cache.GetIssueWithContext("CNF-1", true); found {
+		t.Error("basic entry served for an enhanced request")
+	}
+
+	if err := cache.SetIssueWithContext(&Issue{Key: "CNF-1"}, true); err != nil {
+		t.Fatalf("SetIssueWithContext() error = %v", err)
+	}
+	for _, enhanced := range []bool{false, true} {
+		if _, found := cache.GetIssueWithContext("CNF-1", enhanced); !found {
+			t.Errorf("enhanced entry not served for enhanced=%v", enhanced)
+		}
+	}
+}
+
+func TestCacheRecordsStaleIssuesOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	var stale cachelog.Log
+	cache.maxAge, cache.staleServed = time.Hour, &stale
+
+	for _, key := range []string{"CNF-1", "CNF-2"} {
+		if err := cache.SetIssueWithContext(&Issue{Key: key}, true); err != nil {
+			t.Fatalf("SetIssueWithContext() error = %v", err)
+		}
+	}
+	// Backdate CNF-1 past --max-age but within the cache TTL
+	old, err := json.Marshal(IssueCacheEntry{Data: &Issue{Key: "CNF-1"}, Timestamp: time.Now().Add(-3*time.Hour - 20*time.Minute), IssueKey: "CNF-1", Enhanced: true})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cache.cacheDir, "CNF-1.json"), old, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cache.GetIssue("CNF-1")
+	cache.GetIssueWithContext("CNF-1", true)
+	cache.GetIssueWithContext("CNF-2", true)
+
+	want := []string{"Jira issue CNF-1, cached 3h20m ago"}
+	if got := stale.Entries(); !slices.Equal(got, want) {
+		t.Errorf("stale entries = %q, want %q", got, want)
+	}
+}
+
+func TestSetIssuesWarnsThroughLogger(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	var log strings.Builder
+	cache.log = logger.New(&log, constants.VerbosityProgress)
+
+	if err := cache.SetIssues([]Issue{{Key: "CNF-1"}, {}}); err != nil {
+		t.Fatalf("SetIssues() error = %v", err)
+	}
+	if _, found := cache.GetIssue("CNF-1"); !found {
+		t.Error("CNF-1 not cached")
+	}
+	if want := "Warning: failed to cache issue : invalid issue: missing key\n"; log.String() != want {
+		t.Errorf("log = %q, want %q", log.String(), want)
+	}
+}
@@ -2,14 +2,34 @@ package jira
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sebrandon1/jiracrawler/lib"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/httpclient"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/redact"
 )
 
 // Client wraps the jiracrawler functionality
 type Client struct {
 	config Config
+	log    logger.Logger
+	basic  *basicClient // Set for AuthBasic, which jiracrawler doesn't support
+	rest   *basicClient // Direct REST access for fields jiracrawler doesn't expose
+
+	misses cachelog.Log // What an offline client couldn't serve from the cache
+
+	staleServed cachelog.Log
+
+	cacheOnce sync.Once
+	cache     *Cache // Opened on first use by openCache
+	cacheErr  error
 }
 
 // Config holds the configuration for Jira client
@@ -17,6 +37,38 @@ type Config struct {
 	URL      string
 	Username string
 	Token    string
+	Logger   logger.Logger // Diagnostic logger (defaults to stderr)
+
+	// Role selects which issues are fetched for a user (defaults to assignee)
+	Role Role
+
+	// AuthType selects bearer PAT or Basic auth (defaults by URL; see ParseAuthType)
+	AuthType AuthType
+
+	// Transport for direct REST requests: Basic auth and targeted fetches of
+	// fields jiracrawler lacks (defaults to a proxy-aware transport)
+	Transport http.RoundTripper
+
+	// Redactor, when set (--strip-pii-from-cache), masks secrets and PII in
+	// fetched issue text before it is cached or returned
+	Redactor *redact.Redactor
+
+	// Offline lists and serves issues only from the cache, including expired
+	// entries, and makes no requests (--offline); credentials aren't required
+	Offline bool
+
+	// NoCache fetches every issue fresh instead of serving cached entries
+	// (--no-cache); what is fetched still refreshes the cache
+	NoCache bool
+
+	// MaxAge, if set, records cached issues served although older than it
+	// (--max-age), independently of their TTL; see StaleCacheEntries
+	MaxAge time.Duration
+
+	// RefreshExpiredOnly removes the expired cache entries of the issues
+	// about to be looked up, so they are refetched while valid entries keep
+	// being served (--refresh-expired-only)
+	RefreshExpiredOnly bool
 }
 
 // Re-export jiracrawler types for convenience
@@ -28,20 +80,134 @@ type (
 	TimeTracking     = lib.TimeTracking
 	IssuePermissions = lib.IssuePermissions
 	EnhancedFields   = lib.EnhancedFields
+	Status           = lib.Status
+	Priority         = lib.Priority
+	Project          = lib.Project
+	IssueType        = lib.IssueType
+	User             = lib.User
+)
+
+// jiracrawler entry points, replaced in tests
+var (
+	listAssignedIssues = lib.FetchUserIssuesInDateRange
+	listIssuesWithJQL  = lib.FetchIssuesWithJQL
+	fetchEnhancedIssue = func(jiraURL, apikey, issueKey string, verbose bool) (*Issue, error) {
+		client, err := lib.NewJiraClient(jiraURL, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("creating Jira client: %w", err)
+		}
+		return lib.FetchIssueWithEnhancedContext(client, jiraURL, issueKey, apikey, verbose)
+	}
 )
 
 // NewClient creates a new Jira client with authentication
 func NewClient(config Config) (*Client, error) {
-	if config.URL == "" || config.Username == "" || config.Token == "" {
+	if !config.Offline && (config.URL == "" || config.Username == "" || config.Token == "") {
 		return nil, fmt.Errorf("jira URL, username, and token are required")
 	}
 
-	return &Client{
+	log := config.Logger
+	if log == nil {
+		log = logger.Default(constants.VerbosityQuiet)
+	}
+	if config.Role == "" {
+		config.Role = RoleAssignee
+	}
+	if config.AuthType == "" {
+		config.AuthType = defaultAuthType(config.URL)
+	}
+
+	client := &Client{
 		config: config,
-	}, nil
+		log:    log,
+	}
+	transport := config.Transport
+	if transport == nil {
+		transport = httpclient.DefaultTransport()
+	}
+	client.rest = &basicClient{
+		baseURL:    strings.TrimSuffix(config.URL, "/"),
+		username:   config.Username,
+		token:      config.Token,
+		bearer:     config.AuthType != AuthBasic,
+		httpClient: &http.Client{Timeout: constants.JiraTimeout, Transport: transport},
+	}
+	if config.AuthType == AuthBasic {
+		client.basic = client.rest
+	}
+	return client, nil
+}
+
+// listAssigned lists issues assigned to the user that were updated in the date range
+func (c *Client) listAssigned(email, startDate, endDate string) ([]Issue, error) {
+	var issues []Issue
+	var err error
+	if c.basic != nil {
+		issues, err = c.basic.search(roleJQL(RoleAssignee, email, startDate, endDate), 0)
+	} else {
+		var result *lib.UserUpdatesResult
+		result, err = listAssignedIssues(c.config.URL, c.config.Username, c.config.Token, email, startDate, endDate)
+		if result != nil {
+			issues = result.Issues
+		}
+	}
+	c.redactIssues(issues)
+	return issues, err
+}
+
+// searchJQL runs a JQL query, returning at most maxResults issues (0 = no limit)
+func (c *Client) searchJQL(jql string, maxResults int) ([]Issue, error) {
+	var issues []Issue
+	if c.basic != nil {
+		var err error
+		if issues, err = c.basic.search(jql, maxResults); err != nil {
+			return nil, err
+		}
+	} else {
+		result, err := listIssuesWithJQL(c.config.URL, c.config.Token, jql, maxResults)
+		if err != nil {
+			return nil, err
+		}
+		issues = result.Issues
+	}
+	c.redactIssues(issues)
+	return issues, nil
+}
+
+// fetchEnhanced fetches one issue with comments, history and other enhanced context
+func (c *Client) fetchEnhanced(key string, verbose bool) (*Issue, error) {
+	var issue *Issue
+	var err error
+	if c.basic != nil {
+		issue, err = c.basic.fetchEnhancedIssue(key)
+	} else {
+		issue, err = fetchEnhancedIssue(c.config.URL, c.config.Token, key, verbose)
+	}
+	if issue != nil {
+		issues := []Issue{*issue}
+		c.redactIssues(issues)
+		issue = &issues[0]
+	}
+	return issue, err
+}
+
+// redactIssues masks secrets and PII in the issues' summaries, descriptions
+// and comments when a Redactor is configured
+func (c *Client) redactIssues(issues []Issue) {
+	r := c.config.Redactor
+	if r == nil {
+		return
+	}
+	for i := range issues {
+		issues[i].Summary = r.String(issues[i].Summary)
+		issues[i].Description = r.String(issues[i].Description)
+		for j := range issues[i].Comments {
+			issues[i].Comments[j].Body = r.String(issues[i].Comments[j].Body)
+		}
+	}
 }
 
-// GetUserIssuesInDateRange retrieves issues assigned to a user within a date range
+// GetUserIssuesInDateRange retrieves issues related to a user by the configured role within a date range
 func (c *Client) GetUserIssuesInDateRange(email, startDate, endDate string) ([]Issue, error) {
 	return c.GetUserIssuesInDateRangeWithContext(email, startDate, endDate, false, false)
 }
@@ -63,55 +229,126 @@ func (c *Client) GetUserIssuesInDateRangeWithContext(email, startDate, endDate s
 	startDateFormatted := start.Format("2006-01-02")
 	endDateFormatted := end.Format("2006-01-02")
 
-	// Initialize cache
-	cache, cacheErr := NewCache()
-	
-	// Use jiracrawler's enhanced function
-	result, err := lib.FetchUserIssuesInDateRangeWithContext(
-		c.config.URL,
-		c.config.Username,
-		c.config.Token,
-		email,
-		startDateFormatted,
-		endDateFormatted,
-		enhancedContext,
-		verbose,
-	)
+	cache, cacheErr := c.openCache()
+	if c.config.Offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("failed to open the Jira cache: %w", cacheErr)
+		}
+		issues := c.cachedUserIssues(cache, email, start, end, enhancedContext)
+		if verbose {
+			c.log.Printf("  ✓ Jira cache: %d cached issues (offline)\n", len(issues))
+		}
+		return issues, nil
+	}
 
+	// List the matching issues without enhanced context; comments and history
+	// are fetched below only for issues the cache cannot serve
+	var issues []Issue
+	if c.config.Role == RoleAssignee {
+		issues, err = c.listAssigned(email, startDateFormatted, endDateFormatted)
+	} else {
+		issues, err = c.fetchIssuesByRole(email, startDateFormatted, endDateFormatted)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issues from Jira: %w", err)
 	}
 
-	if result == nil {
-		return nil, fmt.Errorf("failed to fetch issues from Jira")
-	}
-
-	// If cache is available, try to use cached versions of issues
-	if cacheErr == nil && cache != nil {
-		cachedCount := 0
-		freshCount := 0
-		
-		for i := range result.Issues {
-			issue := &result.Issues[i]
-			
-			// Check if we have a cached version
-			if cachedIssue, found := cache.GetIssue(issue.Key); found {
-				// Use cached version (it has full enhanced context if it was cached with it)
-				result.Issues[i] = *cachedIssue
+	if cacheErr != nil {
+		c.log.Warnf("Warning: Jira cache unavailable: %v\n", cacheErr)
+		cache = nil
+	}
+	keys := make([]string, len(issues))
+	for i := range issues {
+		keys[i] = issues[i].Key
+	}
+	c.refreshExpired(cache, keys)
+
+	cachedCount, freshCount := 0, 0
+	for i := range issues {
+		issue := &issues[i]
+
+		// Serve the cached copy if it has the requested context and the issue
+		// has not been updated since it was cached
+		if cache != nil {
+			if cachedIssue, found := cache.GetIssueWithContext(issue.Key, enhancedContext); found && cachedIssue.Updated == issue.Updated {
+				issues[i] = *cachedIssue
 				cachedCount++
+				continue
+			}
+		}
+
+		enhanced := false
+		if enhancedContext {
+			// Same pacing as jiracrawler to avoid 429 errors
+			if freshCount > 0 {
+				time.Sleep(100 * time.Millisecond)
+			}
+			if details, err := c.fetchEnhanced(issue.Key, verbose); err != nil {
+				c.log.Warnf("Warning: failed to enhance issue %s: %v\n", issue.Key, err)
 			} else {
-				// Cache the newly fetched issue
-				_ = cache.SetIssue(issue)
-				freshCount++
+				issues[i] = *details
+				enhanced = true
 			}
 		}
-		
-		if verbose && (cachedCount > 0 || freshCount > 0) {
-			fmt.Printf("  ✓ Jira cache: %d cached, %d fresh (saves API calls)\n", cachedCount, freshCount)
+		freshCount++
+
+		if cache != nil {
+			if err := cache.SetIssueWithContext(&issues[i], enhanced); err != nil {
+				c.log.Warnf("Warning: failed to cache Jira issue %s: %v\n", issue.Key, err)
+			}
 		}
 	}
 
-	return result.Issues, nil
+	if verbose && cache != nil && (cachedCount > 0 || freshCount > 0) {
+		c.log.Printf("  ✓ Jira cache: %d cached, %d fresh (saves API calls)\n", cachedCount, freshCount)
+	}
+
+	return issues, nil
+}
+
+// GetIssueByKey retrieves a single issue by key (e.g. CNF-18498). A cached copy
+// is served if it has at least the requested context; otherwise the issue is
+// fetched from Jira and cached.
+func (c *Client) GetIssueByKey(key string, enhancedContext bool) (*Issue, error) {
+	cache, cacheErr := c.openCache()
+	if cacheErr == nil {
+		c.refreshExpired(cache, []string{key})
+		if issue, found := cache.GetIssueWithContext(key, enhancedContext); found {
+			c.log.Infof("  ✓ Using cached Jira issue %s\n", key)
+			return issue, nil
+		}
+	}
+	if c.config.Offline {
+		return nil, c.misses.Miss(fmt.Sprintf("Jira issue %s", key))
+	}
+
+	issue, err := c.fetchIssue(key, enhancedContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue %s from Jira: %w", key, err)
+	}
+
+	if cacheErr == nil {
+		if err := cache.SetIssueWithContext(issue, enhancedContext); err != nil {
+			c.log.Warnf("Warning: failed to cache Jira issue %s: %v\n", key, err)
+		}
+	}
+	return issue, nil
+}
+
+// fetchIssue fetches one issue from Jira, with comments and history if requested
+func (c *Client) fetchIssue(key string, enhancedContext bool) (*Issue, error) {
+	if enhancedContext {
+		return c.fetchEnhanced(key, c.log.Level() >= constants.VerbosityProgress)
+	}
+
+	issues, err := c.searchJQL("key = "+jqlString(key), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("issue not found")
+	}
+	return &issues[0], nil
 }
 
 // UserInfo represents information about the authenticated user
@@ -125,6 +362,14 @@ type UserInfo struct {
 // VerifyAuthentication checks if the authentication is working and returns user info
 // Now uses jiracrawler's function
 func (c *Client) VerifyAuthentication() (*UserInfo, error) {
+	if c.basic != nil {
+		userInfo, err := c.basic.myself()
+		if err != nil {
+			return nil, fmt.Errorf("authentication failed - could not connect to Jira: %w", err)
+		}
+		return userInfo, nil
+	}
+
 	// Use jiracrawler to verify by attempting to fetch issues for a minimal date range
 	// jiracrawler handles authentication internally
 	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
@@ -161,12 +406,16 @@ func (c *Client) VerifyAuthentication() (*UserInfo, error) {
 func (c *Client) TestConnection() error {
 	// Try to verify authentication
 	userInfo, err := c.VerifyAuthentication()
+	if err != nil && c.basic != nil {
+		// Basic auth is verified directly against /myself, so a failure is conclusive
+		return err
+	}
 	if err != nil {
-		fmt.Printf("  Warning: Could not verify user details (%v)\n", err)
-		fmt.Printf("  Note: Enhanced context (comments, history) may be limited\n")
+		c.log.Printf("  Warning: Could not verify user details (%v)\n", err)
+		c.log.Printf("  Note: Enhanced context (comments, history) may be limited\n")
 		return nil // Non-fatal - jiracrawler might still work
 	}
 
-	fmt.Printf("  Authenticated as: %s (%s)\n", userInfo.DisplayName, userInfo.Email)
+	c.log.Printf("  Authenticated as: %s (%s)\n", userInfo.DisplayName, userInfo.Email)
 	return nil
 }
@@ -0,0 +1,226 @@
+package jira
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+)
+
+// stubJiracrawler serves issues from the given list and counts enhanced fetches
+func stubJiracrawler(t *testing.T, issues *[]Issue) *int {
+	t.Helper()
+	origList, origEnhanced := listAssignedIssues, fetchEnhancedIssue
+	var enhancedFetches int
+	listAssignedIssues = func(jiraURL, jiraUser, apikey, assignee, startDate, endDate string) (*lib.UserUpdatesResult, error) {
+		return &lib.UserUpdatesResult{User: assignee, Issues: append([]Issue(nil), *issues...)}, nil
+	}
+	fetchEnhancedIssue = func(jiraURL, apikey, issueKey string, verbose bool) (*Issue, error) {
+		enhancedFetches++
+		for _, issue := range *issues {
+			if issue.Key == issueKey {
+				issue.Comments = []Comment{{Body: "looks good"}}
+				return &issue, nil
+			}
+		}
+		return nil, errors.New("not found")
+	}
+	t.Cleanup(func() {
+		listAssignedIssues, fetchEnhancedIssue = origList, origEnhanced
+	})
+	return &enhancedFetches
+}
+
+func TestGetUserIssuesPopulatesCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []Issue{
+		{Key: "CNF-1", Updated: "2025-01-02T10:00:00.000+0000"},
+		{Key: "CNF-2", Updated: "2025-01-03T10:00:00.000+0000"},
+	}
+	enhancedFetches := stubJiracrawler(t, &issues)
+
+	client, err := NewClient(Config{URL: "https://jira.example.com", Username: "u", Token: "t"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	fetch := func() []Issue {
+		t.Helper()
+		got, err := client.GetUserIssuesInDateRangeWithContext("a@example.com", "01-01-2025", "01-31-2025", true, false)
+		if err != nil {
+			t.Fatalf("GetUserIssuesInDateRangeWithContext() error = %v", err)
+		}
+		return got
+	}
+
+	got := fetch()
+	if *enhancedFetches != 2 || len(got[0].Comments) != 1 {
+		t.Fatalf("first fetch: %d enhanced fetches, comments %v", *enhancedFetches, got[0].Comments)
+	}
+
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if total := cache.GetCacheStats()["total"]; total != 2 {
+		t.Errorf("cache has %v entries after fetch, want 2", total)
+	}
+	if _, found := cache.GetIssueWithContext("CNF-2", true); !found {
+		t.Error("CNF-2 not cached with enhanced context")
+	}
+
+	// A repeat run serves both issues from the cache
+	if got = fetch(); *enhancedFetches != 2 || len(got[1].Comments) != 1 {
+		t.Errorf("repeat fetch: %d enhanced fetches, want 2 (served from cache)", *enhancedFetches)
+	}
+
+	// An issue updated since it was cached is refetched
+	issues[1].Updated = "2025-01-04T10:00:00.000+0000"
+	fetch()
+	if *enhancedFetches != 3 {
+		t.Errorf("after update: %d enhanced fetches, want 3", *enhancedFetches)
+	}
+}
+
+func TestRefreshExpiredOnlyRefetchesExpiredIssues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []Issue{
+		{Key: "CNF-1", Updated: "2025-01-02T10:00:00.000+0000"},
+		{Key: "CNF-2", Updated: "2025-01-03T10:00:00.000+0000"},
+	}
+	enhancedFetches := stubJiracrawler(t, &issues)
+
+	fetch := func(refresh bool) {
+		t.Helper()
+		client, err := NewClient(Config{URL: "https://jira.example.com", Username: "u", Token: "t", Logger: logger.Nop(), RefreshExpiredOnly: refresh})
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		if _, err := client.GetUserIssuesInDateRangeWithContext("a@example.com", "01-01-2025", "01-31-2025", true, false); err != nil {
+			t.Fatalf("GetUserIssuesInDateRangeWithContext() error = %v", err)
+		}
+	}
+	fetch(false)
+
+	// Expire CNF-2, which the run lists, and CNF-9, which it doesn't
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if err := cache.SetIssue(&Issue{Key: "CNF-9"}); err != nil {
+		t.Fatalf("SetIssue() error = %v", err)
+	}
+	cache.mu.Lock()
+	for _, key := range []string{"CNF-2", "CNF-9"} {
+		filename := cache.getCacheFilename(key)
+		entry := cache.metadata.Entries[filename]
+		entry.Expires = time.Now().Add(-time.Hour)
+		cache.metadata.Entries[filename] = entry
+		cache.markChanged(filename)
+	}
+	cache.mu.Unlock()
+	if err := cache.saveMetadata(); err != nil {
+		t.Fatalf("saveMetadata() error = %v", err)
+	}
+
+	fetch(true)
+	if *enhancedFetches != 3 {
+		t.Errorf("%d enhanced fetches, want 3 (CNF-2 refetched, CNF-1 served from the cache)", *enhancedFetches)
+	}
+	reopened, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if entry, ok := reopened.metadata.Entries[reopened.getCacheFilename("CNF-9")]; !ok || time.Now().Before(entry.Expires) {
+		t.Errorf("CNF-9 entry = %+v (present %v), want it kept and still expired", entry, ok)
+	}
+	if entry := reopened.metadata.Entries[reopened.getCacheFilename("CNF-2")]; time.Now().After(entry.Expires) {
+		t.Errorf("CNF-2 entry = %+v, want it refreshed", entry)
+	}
+}
+
+func TestCacheCountsLookups(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	// Another run's cache, loaded before this run writes anything
+	other, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	cache.GetIssue("CNF-1") // miss
+	if err := cache.SetIssue(&Issue{Key: "CNF-1"}); err != nil {
+		t.Fatalf("SetIssue() error = %v", err)
+	}
+	cache.GetIssue("CNF-1")                  // hit
+	cache.GetIssueWithContext("CNF-1", true) // cached without enhanced context: miss
+
+	// The counts persist for the next run once saved, and the other run's
+	// save keeps this run's entry and counts
+	if err := cache.SaveCounts(); err != nil {
+		t.Fatalf("SaveCounts() error = %v", err)
+	}
+	if err := other.SetIssue(&Issue{Key: "CNF-2"}); err != nil {
+		t.Fatalf("SetIssue() error = %v", err)
+	}
+	reopened, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	stats := reopened.GetCacheStats()
+	if stats["hits"] != 1 || stats["misses"] != 2 || stats["total"] != 2 {
+		t.Errorf("hits = %v, misses = %v, total = %v; want 1, 2 and both runs' entries", stats["hits"], stats["misses"], stats["total"])
+	}
+}
+
+func TestOfflineListsCachedIssues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	issues := []Issue{
+		{Key: "CNF-1", Updated: "2025-01-02T10:00:00.000+0000", Assignee: &User{EmailAddress: "A@example.com"}},
+		{Key: "CNF-2", Updated: "2025-01-20T10:00:00.000+0000", Assignee: &User{EmailAddress: "a@example.com"}},
+		{Key: "CNF-3", Updated: "2025-01-05T10:00:00.000+0000", Assignee: &User{EmailAddress: "b@example.com"}},
+		{Key: "CNF-4", Updated: "2025-02-05T10:00:00.000+0000", Assignee: &User{EmailAddress: "a@example.com"}},
+	}
+	cache, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	for i := range issues {
+		if err := cache.SetIssueWithContext(&issues[i], i != 0); err != nil {
+			t.Fatalf("SetIssueWithContext() error = %v", err)
+		}
+	}
+	enhancedFetches := stubJiracrawler(t, &issues)
+
+	client, err := NewClient(Config{Offline: true})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	got, err := client.GetUserIssuesInDateRangeWithContext("a@example.com", "01-01-2025", "01-31-2025", true, false)
+	if err != nil {
+		t.Fatalf("GetUserIssuesInDateRangeWithContext() error = %v", err)
+	}
+
+	var keys []string
+	for _, issue := range got {
+		keys = append(keys, issue.Key)
+	}
+	if want := []string{"CNF-2", "CNF-1"}; !slices.Equal(keys, want) {
+		t.Errorf("offline issues = %v, want %v (the user's, newest first)", keys, want)
+	}
+	if *enhancedFetches != 0 {
+		t.Errorf("offline client made %d enhanced fetches, want 0", *enhancedFetches)
+	}
+	if want := []string{"comments and history of Jira issue CNF-1"}; !slices.Equal(client.OfflineMisses(), want) {
+		t.Errorf("OfflineMisses() = %q, want %q", client.OfflineMisses(), want)
+	}
+	if _, err := client.GetIssueByKey("CNF-9", false); !errors.Is(err, ErrOffline) {
+		t.Errorf("GetIssueByKey() error = %v, want ErrOffline", err)
+	}
+}
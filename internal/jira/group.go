@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupBy selects how issues are grouped in the summary prompt and metrics
+type GroupBy string
+
+const (
+	// GroupByProject groups issues by the project prefix of their key
+	GroupByProject GroupBy = "project"
+	// GroupByComponent groups issues by their Jira components
+	GroupByComponent GroupBy = "component"
+	// GroupByLabel groups issues by their labels
+	GroupByLabel GroupBy = "label"
+)
+
+// Buckets for issues without any component or label
+const (
+	NoComponent = "No component"
+	NoLabel     = "No label"
+)
+
+// ParseGroupBy parses a --jira-group-by value; an empty value means project
+func ParseGroupBy(value string) (GroupBy, error) {
+	switch by := GroupBy(strings.ToLower(strings.TrimSpace(value))); by {
+	case "":
+		return GroupByProject, nil
+	case GroupByProject, GroupByComponent, GroupByLabel:
+		return by, nil
+	default:
+		return "", fmt.Errorf("unknown Jira grouping '%s': supported groupings are project, component, label", value)
+	}
+}
+
+// Groups returns the groups an issue belongs to. An issue with several
+// components or labels belongs to each of them, in the order Jira lists them;
+// one with none goes in the NoComponent or NoLabel bucket.
+func (by GroupBy) Groups(issue Issue) []string {
+	switch by {
+	case GroupByComponent:
+		return groupsOrBucket(issue.Components, NoComponent)
+	case GroupByLabel:
+		return groupsOrBucket(issue.Labels, NoLabel)
+	default:
+		return []string{ProjectFromKey(issue.Key)}
+	}
+}
+
+// groupsOrBucket returns the distinct non-empty names, or the bucket if there are none
+func groupsOrBucket(names []string, bucket string) []string {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			groups = append(groups, name)
+		}
+	}
+	if len(groups) == 0 {
+		return []string{bucket}
+	}
+	return groups
+}
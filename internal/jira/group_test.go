@@ -0,0 +1,34 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupByGroups(t *testing.T) {
+	issue := Issue{Key: "CNF-1", Components: []string{"networking", "operator", "networking"}, Labels: []string{" "}}
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "", want: []string{"CNF"}},
+		{value: "Component", want: []string{"networking", "operator"}},
+		{value: "label", want: []string{NoLabel}},
+	}
+	for _, tt := range tests {
+		by, err := ParseGroupBy(tt.value)
+		if err != nil {
+			t.Fatalf("ParseGroupBy(%q) error = %v", tt.value, err)
+		}
+		if got := by.Groups(issue); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s groups = %v, want %v", by, got, tt.want)
+		}
+	}
+	if got := GroupByComponent.Groups(Issue{Key: "CNF-2"}); !reflect.DeepEqual(got, []string{NoComponent}) {
+		t.Errorf("groups without components = %v, want the %q bucket", got, NoComponent)
+	}
+
+	if _, err := ParseGroupBy("assignee"); err == nil {
+		t.Error("ParseGroupBy(\"assignee\") succeeded, want an error")
+	}
+}
@@ -0,0 +1,125 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/cachelog"
+)
+
+// ErrOffline is returned instead of querying Jira when the client is offline
+// and the data isn't in the cache
+var ErrOffline = cachelog.ErrOffline
+
+// openCache returns the client's issue cache, opened on first use, logging
+// through the client's logger, serving expired entries when offline and
+// nothing with NoCache
+func (c *Client) openCache() (*Cache, error) {
+	c.cacheOnce.Do(func() {
+		c.cache, c.cacheErr = NewCache()
+		if c.cache != nil {
+			c.cache.log = c.log
+			c.cache.allowExpired = c.config.Offline
+			c.cache.bypass = c.config.NoCache
+			c.cache.maxAge = c.config.MaxAge
+			c.cache.staleServed = &c.staleServed
+		}
+	})
+	return c.cache, c.cacheErr
+}
+
+// refreshExpired removes the expired cache entries of the issues before they
+// are looked up (--refresh-expired-only), so they are refetched while valid
+// entries keep being served
+func (c *Client) refreshExpired(cache *Cache, issueKeys []string) {
+	if !c.config.RefreshExpiredOnly || cache == nil || len(issueKeys) == 0 {
+		return
+	}
+	removed, err := cache.CleanExpiredIssues(issueKeys)
+	if err != nil {
+		c.log.Warnf("Warning: failed to clean expired Jira cache entries: %v\n", err)
+		return
+	}
+	if removed > 0 {
+		c.log.Infof("  ♻ Refreshing %d expired Jira cache entries\n", removed)
+	}
+}
+
+// SaveCacheCounts persists the cache lookups counted since the last save, once
+// the client is done, so 'cache stats' reports hits and misses across runs
+func (c *Client) SaveCacheCounts() error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.SaveCounts()
+}
+
+// OfflineMisses returns what an offline client could not serve from the
+// cache, once each in the order it was requested
+func (c *Client) OfflineMisses() []string {
+	return c.misses.Entries()
+}
+
+// StaleCacheEntries returns the cached issues served although they were
+// older than Config.MaxAge, e.g. "Jira issue CNF-12, cached 3h20m ago", once
+// each in the order they were first served
+func (c *Client) StaleCacheEntries() []string {
+	return c.staleServed.Entries()
+}
+
+// cachedUserIssues lists the cached issues related to the user by role that
+// were updated between start and end (inclusive dates), most recently updated
+// first like the Jira search. Watchers aren't cached, so contributor matches
+// the assignee or reporter only.
+func (c *Client) cachedUserIssues(cache *Cache, email string, start, end time.Time, enhancedContext bool) []Issue {
+	var issues []Issue
+	var updatedAt []time.Time
+	for _, entry := range cache.Entries() {
+		issue := entry.Data
+		if issue == nil || !c.relatedByRole(*issue, email) {
+			continue
+		}
+		updated, ok := ParseTime(issue.Updated)
+		if !ok || updated.Before(start) || !updated.Before(end.AddDate(0, 0, 1)) {
+			continue
+		}
+		if enhancedContext && !entry.Enhanced {
+			_ = c.misses.Miss(fmt.Sprintf("comments and history of Jira issue %s", issue.Key))
+		}
+		issues = append(issues, *issue)
+		updatedAt = append(updatedAt, updated)
+	}
+	sort.Sort(byUpdatedDesc{issues, updatedAt})
+	return issues
+}
+
+// byUpdatedDesc sorts issues by their parsed update times, newest first
+type byUpdatedDesc struct {
+	issues  []Issue
+	updated []time.Time
+}
+
+func (s byUpdatedDesc) Len() int           { return len(s.issues) }
+func (s byUpdatedDesc) Less(i, j int) bool { return s.updated[i].After(s.updated[j]) }
+func (s byUpdatedDesc) Swap(i, j int) {
+	s.issues[i], s.issues[j] = s.issues[j], s.issues[i]
+	s.updated[i], s.updated[j] = s.updated[j], s.updated[i]
+}
+
+// relatedByRole reports whether the issue is assigned to or reported by the
+// user, as the configured role requires
+func (c *Client) relatedByRole(issue Issue, email string) bool {
+	is := func(user *User) bool {
+		return user != nil && strings.EqualFold(user.EmailAddress, email)
+	}
+	switch c.config.Role {
+	case RoleReporter:
+		return is(issue.Reporter)
+	case RoleContributor:
+		return is(issue.Assignee) || is(issue.Reporter)
+	default:
+		return is(issue.Assignee)
+	}
+}
@@ -0,0 +1,43 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// defaultProjectKeyPattern matches standard issue keys such as CNF-18498
+const defaultProjectKeyPattern = `^([A-Z][A-Z0-9]+)-\d+$`
+
+// projectKeyPattern captures the project of an issue key in its first group
+var projectKeyPattern = regexp.MustCompile(defaultProjectKeyPattern)
+
+// SetProjectKeyPattern replaces the pattern ProjectFromKey uses, for Jira
+// instances with non-standard key schemes (config jira.project_key_pattern).
+// The first capture group is the project; an empty pattern restores the default.
+func SetProjectKeyPattern(pattern string) error {
+	if pattern == "" {
+		pattern = defaultProjectKeyPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid jira.project_key_pattern: %w", err)
+	}
+	if re.NumSubexp() < 1 {
+		return fmt.Errorf("invalid jira.project_key_pattern %q: it needs a capture group for the project", pattern)
+	}
+	projectKeyPattern = re
+	return nil
+}
+
+// ProjectFromKey returns the project of an issue key, e.g. "CNF" for
+// "CNF-18498". Keys the pattern doesn't match are returned whole rather than
+// mis-split, so they are grouped on their own; an empty key is "UNKNOWN".
+func ProjectFromKey(key string) string {
+	if key == "" {
+		return "UNKNOWN"
+	}
+	if match := projectKeyPattern.FindStringSubmatch(key); match != nil && match[1] != "" {
+		return match[1]
+	}
+	return key
+}
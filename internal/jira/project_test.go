@@ -0,0 +1,47 @@
+package jira
+
+import "testing"
+
+func TestProjectFromKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "CNF-18498", want: "CNF"},
+		{key: "OCPBUGS-45703", want: "OCPBUGS"},
+		{key: "K8S2-7", want: "K8S2"},
+		{key: "MY-PROJ-12", want: "MY-PROJ-12"},
+		{key: "cnf-1", want: "cnf-1"},
+		{key: "2024-15", want: "2024-15"},
+		{key: "CNF", want: "CNF"},
+		{key: "CNF-", want: "CNF-"},
+		{key: "", want: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := ProjectFromKey(tt.key); got != tt.want {
+				t.Errorf("ProjectFromKey(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetProjectKeyPattern(t *testing.T) {
+	t.Cleanup(func() { _ = SetProjectKeyPattern("") })
+
+	if err := SetProjectKeyPattern(`^([A-Z]+-[A-Z]+)-\d+$`); err != nil {
+		t.Fatalf("SetProjectKeyPattern() error = %v", err)
+	}
+	if got := ProjectFromKey("MY-PROJ-12"); got != "MY-PROJ" {
+		t.Errorf("ProjectFromKey() with a custom pattern = %q, want MY-PROJ", got)
+	}
+	for _, pattern := range []string{`^[A-Z]+-\d+$`, `^([A-Z]+`} {
+		if err := SetProjectKeyPattern(pattern); err == nil {
+			t.Errorf("SetProjectKeyPattern(%q) succeeded, want an error", pattern)
+		}
+	}
+	if err := SetProjectKeyPattern(""); err != nil || ProjectFromKey("CNF-1") != "CNF" {
+		t.Errorf("SetProjectKeyPattern(\"\") did not restore the default pattern")
+	}
+}
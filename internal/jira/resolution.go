@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// resolutionBatchSize is how many issue keys are looked up per search
+const resolutionBatchSize = 100
+
+// Resolutions returns the resolution name ("Done", "Won't Do", "Duplicate",
+// ...) of each resolved issue, keyed by issue key. jiracrawler doesn't expose
+// the field, so it is looked up with a targeted search for just that field.
+func (c *Client) Resolutions(issues []Issue) (map[string]string, error) {
+	var keys []string
+	for _, issue := range issues {
+		if issue.Resolved != "" {
+			keys = append(keys, issue.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return map[string]string{}, nil
+	}
+	if c.config.Offline {
+		return nil, c.misses.Miss(fmt.Sprintf("resolutions of %d resolved Jira issues", len(keys)))
+	}
+	return c.rest.resolutions(keys)
+}
+
+// resolutions looks up the resolution field of the given issues in batches.
+// Jira Cloud (Basic auth) requires the search/jql endpoint; Server and Data
+// Center (bearer PATs) only have the older search endpoint.
+func (b *basicClient) resolutions(keys []string) (map[string]string, error) {
+	path := "/rest/api/2/search/jql"
+	if b.bearer {
+		path = "/rest/api/2/search"
+	}
+
+	resolutions := make(map[string]string, len(keys))
+	for start := 0; start < len(keys); start += resolutionBatchSize {
+		batch := keys[start:min(start+resolutionBatchSize, len(keys))]
+		query := url.Values{
+			"jql":        {"key in (" + strings.Join(batch, ",") + ")"},
+			"fields":     {"resolution"},
+			"maxResults": {strconv.Itoa(len(batch))},
+		}
+
+		var page struct {
+			Issues []struct {
+				Key    string `json:"key"`
+				Fields struct {
+					Resolution *struct {
+						Name string `json:"name"`
+					} `json:"resolution"`
+				} `json:"fields"`
+			} `json:"issues"`
+		}
+		if err := b.get(path+"?"+query.Encode(), &page); err != nil {
+			return nil, fmt.Errorf("fetching issue resolutions: %w", err)
+		}
+		for _, issue := range page.Issues {
+			if issue.Fields.Resolution != nil && issue.Fields.Resolution.Name != "" {
+				resolutions[issue.Key] = issue.Fields.Resolution.Name
+			}
+		}
+	}
+	return resolutions, nil
+}
+
+// FilterByResolution keeps unresolved issues and resolved issues whose
+// resolution is one of allowed (case-insensitive). Resolved issues with an
+// unknown resolution are dropped. An empty allowed list keeps every issue.
+func FilterByResolution(issues []Issue, resolutions map[string]string, allowed []string) []Issue {
+	if len(allowed) == 0 {
+		return issues
+	}
+	var filtered []Issue
+	for _, issue := range issues {
+		if issue.Resolved == "" || matchesAny(resolutions[issue.Key], allowed) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// ParseResolutionFilter parses a comma-separated --jira-resolution value such
+// as "Done,Fixed" into resolution names
+func ParseResolutionFilter(spec string) []string {
+	var names []string
+	for _, name := range strings.Split(spec, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// matchesAny reports whether name equals one of the values, ignoring case
+func matchesAny(name string, values []string) bool {
+	for _, value := range values {
+		if name != "" && strings.EqualFold(name, value) {
+			return true
+		}
+	}
+	return false
+}
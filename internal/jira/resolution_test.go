@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolutionsAndFilter(t *testing.T) {
+	var gotJQL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Self-hosted Jira: bearer PAT against the older search endpoint
+		if r.URL.Path != "/rest/api/2/search" || r.Header.Get("Authorization") != "Bearer pat" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotJQL = r.URL.Query().Get("jql")
+		_, _ = w.Write([]byte(`{"issues": [
+			{"key": "CNF-1", "fields": {"resolution": {"name": "Done"}}},
+			{"key": "CNF-2", "fields": {"resolution": {"name": "Won't Do"}}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{URL: server.URL, Username: "me", Token: "pat", AuthType: AuthPAT})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := []Issue{
+		{Key: "CNF-1", Resolved: "2025-01-02T10:00:00Z"},
+		{Key: "CNF-2", Resolved: "2025-01-03T10:00:00Z"},
+		{Key: "CNF-3"},
+	}
+	resolutions, err := client.Resolutions(issues)
+	if err != nil {
+		t.Fatalf("Resolutions() error = %v", err)
+	}
+	if gotJQL != "key in (CNF-1,CNF-2)" {
+		t.Errorf("JQL = %q, want only the resolved issues", gotJQL)
+	}
+	if resolutions["CNF-1"] != "Done" || resolutions["CNF-2"] != "Won't Do" || len(resolutions) != 2 {
+		t.Errorf("Resolutions() = %v", resolutions)
+	}
+
+	filtered := FilterByResolution(issues, resolutions, ParseResolutionFilter(" done , Fixed"))
+	var keys []string
+	for _, issue := range filtered {
+		keys = append(keys, issue.Key)
+	}
+	if len(keys) != 2 || keys[0] != "CNF-1" || keys[1] != "CNF-3" {
+		t.Errorf("FilterByResolution() kept %v, want [CNF-1 CNF-3]", keys)
+	}
+	if got := FilterByResolution(issues, resolutions, nil); len(got) != len(issues) {
+		t.Errorf("FilterByResolution() without a filter kept %d of %d issues", len(got), len(issues))
+	}
+}
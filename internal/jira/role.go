@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role selects how a user must be related to a Jira issue for it to be fetched
+type Role string
+
+const (
+	// RoleAssignee fetches issues assigned to the user ("work owned")
+	RoleAssignee Role = "assignee"
+	// RoleReporter fetches issues the user reported
+	RoleReporter Role = "reporter"
+	// RoleContributor fetches issues the user is assigned to, reported, or
+	// watches. Jira adds commenters as watchers by default, so this also
+	// covers issues the user commented on ("work done").
+	RoleContributor Role = "contributor"
+)
+
+// ParseRole parses a --jira-role value; an empty value means assignee
+func ParseRole(value string) (Role, error) {
+	switch role := Role(strings.ToLower(strings.TrimSpace(value))); role {
+	case "":
+		return RoleAssignee, nil
+	case RoleAssignee, RoleReporter, RoleContributor:
+		return role, nil
+	default:
+		return "", fmt.Errorf("unknown Jira role '%s': supported roles are assignee, reporter, contributor", value)
+	}
+}
+
+// Description returns a short phrase for output headers, e.g. "reported by"
+func (r Role) Description() string {
+	switch r {
+	case RoleReporter:
+		return "reported by"
+	case RoleContributor:
+		return "assigned to, reported or watched by"
+	default:
+		return "assigned to"
+	}
+}
+
+// jqlEscaper escapes the characters that end or escape a quoted JQL string
+var jqlEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// jqlString quotes value as a JQL string literal
+func jqlString(value string) string {
+	return `"` + jqlEscaper.Replace(value) + `"`
+}
+
+// roleJQL builds the JQL for issues related to the user by role that were
+// updated in the date range. Dates are in YYYY-MM-DD format.
+func roleJQL(role Role, email, startDate, endDate string) string {
+	user := jqlString(email)
+	var clause string
+	switch role {
+	case RoleReporter:
+		clause = "reporter=" + user
+	case RoleContributor:
+		clause = fmt.Sprintf("(assignee=%s OR reporter=%s OR watcher=%s)", user, user, user)
+	default:
+		clause = "assignee=" + user
+	}
+	// Match jiracrawler's assignee query: all statuses, most recently updated first
+	return fmt.Sprintf("%s AND updated >= \"%s\" AND updated <= \"%s\" AND (resolution is empty OR resolution is not empty) ORDER BY updated DESC", clause, startDate, endDate)
+}
+
+// fetchIssuesByRole runs the role JQL directly, since jiracrawler's date range
+// helpers only query by assignee
+func (c *Client) fetchIssuesByRole(email, startDate, endDate string) ([]Issue, error) {
+	jql := roleJQL(c.config.Role, email, startDate, endDate)
+	c.log.Debugf("  Jira JQL: %s\n", jql)
+
+	return c.searchJQL(jql, 0)
+}
@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Role
+		wantErr bool
+	}{
+		{input: "", want: RoleAssignee},
+		{input: "assignee", want: RoleAssignee},
+		{input: "Reporter", want: RoleReporter},
+		{input: " contributor ", want: RoleContributor},
+		{input: "watcher", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRole(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRole(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRole(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleJQL(t *testing.T) {
+	tests := []struct {
+		role       Role
+		wantPrefix string
+	}{
+		{role: RoleAssignee, wantPrefix: `assignee="a@example.com" AND`},
+		{role: RoleReporter, wantPrefix: `reporter="a@example.com" AND`},
+		{role: RoleContributor, wantPrefix: `(assignee="a@example.com" OR reporter="a@example.com" OR watcher="a@example.com") AND`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			got := roleJQL(tt.role, "a@example.com", "2025-01-01", "2025-01-31")
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("roleJQL() = %q, want prefix %q", got, tt.wantPrefix)
+			}
+			if !strings.Contains(got, `updated >= "2025-01-01" AND updated <= "2025-01-31"`) {
+				t.Errorf("roleJQL() = %q, missing date range", got)
+			}
+		})
+	}
+
+	// Quotes and backslashes in the email can't end the string early
+	got := roleJQL(RoleAssignee, `a" OR assignee is not empty OR x="\`, "2025-01-01", "2025-01-31")
+	if want := `assignee="a\" OR assignee is not empty OR x=\"\\" AND`; !strings.HasPrefix(got, want) {
+		t.Errorf("roleJQL() = %q, want prefix %q", got, want)
+	}
+}
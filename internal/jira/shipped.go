@@ -0,0 +1,18 @@
+package jira
+
+// ShippedStatuses are the statuses of issues that count as delivered work
+// for --shipped-only
+var ShippedStatuses = []string{"Done", "Closed"}
+
+// FilterShipped keeps the issues in one of ShippedStatuses (case-insensitive)
+// and returns them with the number of issues dropped
+func FilterShipped(issues []Issue) (shipped []Issue, excluded int) {
+	for _, issue := range issues {
+		if matchesAny(issue.Status.Name, ShippedStatuses) {
+			shipped = append(shipped, issue)
+		} else {
+			excluded++
+		}
+	}
+	return shipped, excluded
+}
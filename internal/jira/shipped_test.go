@@ -0,0 +1,19 @@
+package jira
+
+import "testing"
+
+func TestFilterShipped(t *testing.T) {
+	issues := []Issue{
+		{Key: "CNF-1", Status: Status{Name: "Done"}},
+		{Key: "CNF-2", Status: Status{Name: "In Progress"}},
+		{Key: "CNF-3", Status: Status{Name: "closed"}},
+		{Key: "CNF-4", Status: Status{Name: "Review"}},
+	}
+	shipped, excluded := FilterShipped(issues)
+	if len(shipped) != 2 || shipped[0].Key != "CNF-1" || shipped[1].Key != "CNF-3" {
+		t.Errorf("FilterShipped() kept %v, want CNF-1 and CNF-3", shipped)
+	}
+	if excluded != 2 {
+		t.Errorf("FilterShipped() excluded = %d, want 2", excluded)
+	}
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"sort"
+	"time"
+)
+
+// StatusTransition is one change of an issue's status, from its history
+type StatusTransition struct {
+	From   string
+	To     string
+	At     time.Time
+	Author string
+}
+
+// StatusTimeline returns the issue's status transitions, oldest first. It is
+// empty when the issue's history wasn't fetched or its status never changed.
+func StatusTimeline(issue Issue) []StatusTransition {
+	var transitions []StatusTransition
+	for _, item := range issue.History {
+		for _, change := range item.Items {
+			if change.Field == "status" {
+				transitions = append(transitions, StatusTransition{From: change.FromString, To: change.ToString, At: item.Created, Author: item.Author})
+			}
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].At.Before(transitions[j].At) })
+	return transitions
+}
+
+// TimeInStatus returns how long the issue spent in each status, from its
+// creation through its transitions. The current status counts up to now
+// while the issue is open; the final status of a resolved issue isn't counted.
+func TimeInStatus(issue Issue, now time.Time) map[string]time.Duration {
+	transitions := StatusTimeline(issue)
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	durations := make(map[string]time.Duration)
+	if created, ok := ParseTime(issue.Created); ok && transitions[0].At.After(created) {
+		durations[transitions[0].From] += transitions[0].At.Sub(created)
+	}
+	for i := 1; i < len(transitions); i++ {
+		durations[transitions[i-1].To] += transitions[i].At.Sub(transitions[i-1].At)
+	}
+	if last := transitions[len(transitions)-1]; issue.Resolved == "" && now.After(last.At) {
+		durations[last.To] += now.Sub(last.At)
+	}
+	return durations
+}
+
+// CycleTime returns the time from the issue's first status change (work
+// starting) to its resolution. It reports false for unresolved issues and
+// issues without a status history.
+func CycleTime(issue Issue) (time.Duration, bool) {
+	resolved, ok := ParseTime(issue.Resolved)
+	if !ok {
+		return 0, false
+	}
+	transitions := StatusTimeline(issue)
+	if len(transitions) == 0 || resolved.Before(transitions[0].At) {
+		return 0, false
+	}
+	return resolved.Sub(transitions[0].At), true
+}
+
+// ProjectCycleTime is the average cycle time of a project's resolved issues
+type ProjectCycleTime struct {
+	Project string
+	Average time.Duration
+	Issues  int // Resolved issues with a status history
+}
+
+// AverageCycleTimeByProject returns the average cycle time per project, by project key
+func AverageCycleTimeByProject(issues []Issue) []ProjectCycleTime {
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if cycle, ok := CycleTime(issue); ok {
+			project := ProjectFromKey(issue.Key)
+			totals[project] += cycle
+			counts[project]++
+		}
+	}
+
+	projects := make([]ProjectCycleTime, 0, len(counts))
+	for project, count := range counts {
+		projects = append(projects, ProjectCycleTime{Project: project, Average: totals[project] / time.Duration(count), Issues: count})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Project < projects[j].Project })
+	return projects
+}
+
+// issueTimeLayouts are the forms issue timestamps arrive in: the raw REST
+// format, with any number of fractional digits or none, and RFC 3339 as
+// jiracrawler and the issue cache store them
+var issueTimeLayouts = []string{"2006-01-02T15:04:05.999-0700", time.RFC3339Nano}
+
+// ParseTime parses an issue timestamp such as Created or Resolved, reporting
+// false if it is in none of the forms Jira timestamps arrive in. GitHub's
+// RFC 3339 timestamps parse too.
+func ParseTime(value string) (time.Time, bool) {
+	for _, layout := range issueTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"testing"
+	"time"
+)
+
+// day returns midnight UTC of the given January 2025 day
+func day(d int) time.Time {
+	return time.Date(2025, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+// statusChange is a history entry moving an issue from one status to another
+func statusChange(at time.Time, from, to string) HistoryItem {
+	return HistoryItem{Created: at, Items: []HistoryChange{{Field: "status", FromString: from, ToString: to}}}
+}
+
+func TestStatusTimelineAndCycleTime(t *testing.T) {
+	resolved := Issue{
+		Key:      "CNF-1",
+		Created:  day(1).Format(time.RFC3339),
+		Resolved: day(9).Format(time.RFC3339),
+		// Out of order, with an unrelated change mixed in
+		History: []HistoryItem{
+			statusChange(day(9), "In Progress", "Done"),
+			{Created: day(3), Items: []HistoryChange{{Field: "assignee", ToString: "dev"}}},
+			statusChange(day(2), "To Do", "In Progress"),
+		},
+	}
+
+	timeline := StatusTimeline(resolved)
+	if len(timeline) != 2 || timeline[0].To != "In Progress" || timeline[1].To != "Done" {
+		t.Fatalf("StatusTimeline() = %+v, want To Do → In Progress → Done", timeline)
+	}
+
+	inStatus := TimeInStatus(resolved, day(20))
+	if inStatus["To Do"] != 24*time.Hour || inStatus["In Progress"] != 7*24*time.Hour || inStatus["Done"] != 0 {
+		t.Errorf("TimeInStatus() = %v, want 1 day To Do and 7 days In Progress", inStatus)
+	}
+	if cycle, ok := CycleTime(resolved); !ok || cycle != 7*24*time.Hour {
+		t.Errorf("CycleTime() = %v, %v, want 7 days", cycle, ok)
+	}
+
+	open := Issue{Key: "CNF-2", Created: "2025-01-01T00:00:00.000+0000", History: []HistoryItem{statusChange(day(5), "To Do", "In Progress")}}
+	if inStatus := TimeInStatus(open, day(8)); inStatus["To Do"] != 4*24*time.Hour || inStatus["In Progress"] != 3*24*time.Hour {
+		t.Errorf("TimeInStatus() of an open issue = %v, want the current status counted up to now", inStatus)
+	}
+	if _, ok := CycleTime(open); ok {
+		t.Error("CycleTime() of an open issue reported a cycle time")
+	}
+	if _, ok := CycleTime(Issue{Key: "CNF-3", Resolved: day(4).Format(time.RFC3339)}); ok {
+		t.Error("CycleTime() without history reported a cycle time")
+	}
+
+	other := Issue{Key: "OCPBUGS-1", Resolved: day(6).Format(time.RFC3339), History: []HistoryItem{statusChange(day(3), "New", "Done")}}
+	second := Issue{Key: "CNF-4", Resolved: day(4).Format(time.RFC3339), History: []HistoryItem{statusChange(day(1), "To Do", "Done")}}
+	got := AverageCycleTimeByProject([]Issue{resolved, open, other, second})
+	want := []ProjectCycleTime{
+		{Project: "CNF", Average: 5 * 24 * time.Hour, Issues: 2},
+		{Project: "OCPBUGS", Average: 3 * 24 * time.Hour, Issues: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AverageCycleTimeByProject() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AverageCycleTimeByProject()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2025, time.January, 6, 9, 30, 0, 0, time.UTC)
+	for _, value := range []string{
+		"2025-01-06T09:30:00.000+0000",
+		"2025-01-06T09:30:00+0000",
+		"2025-01-06T09:30:00.5+0000",
+		"2025-01-06T11:30:00.000+0200",
+		"2025-01-06T09:30:00Z",
+	} {
+		got, ok := ParseTime(value)
+		if !ok || !got.Truncate(time.Second).Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	for _, value := range []string{"", "2025-01-06", "not a time"} {
+		if got, ok := ParseTime(value); ok {
+			t.Errorf("ParseTime(%q) = %v, want false", value, got)
+		}
+	}
+}
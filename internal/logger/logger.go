@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+)
+
+// Logger is the minimal diagnostic logging interface used by the API clients
+// and commands. Diagnostics never go to stdout so machine-readable output
+// (e.g. --output json) stays clean.
+type Logger interface {
+	// Printf logs a notice that is shown unless output is silenced (e.g. rate limit waits)
+	Printf(format string, args ...any)
+	// Warnf logs a non-fatal problem, shown at verbosity level 1 (-v) or higher
+	Warnf(format string, args ...any)
+	// Infof logs high-level progress, shown at verbosity level 1 (-v) or higher
+	Infof(format string, args ...any)
+	// Debugf logs per-request information, shown at verbosity level 2 (-vv) or higher
+	Debugf(format string, args ...any)
+	// Tracef logs full request/response bodies, shown at verbosity level 3 (-vvv)
+	Tracef(format string, args ...any)
+	// Level returns the configured verbosity level
+	Level() int
+}
+
+// writerLogger writes log lines to an io.Writer, filtered by verbosity level
+type writerLogger struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	level   int
+	palette color.Palette
+}
+
+// New creates a logger that writes to w, showing messages up to the given
+// verbosity level. Status markers are colored when w is a terminal.
+func New(w io.Writer, level int) Logger {
+	return &writerLogger{writer: w, level: level, palette: color.For(w)}
+}
+
+// Default creates a logger that writes to stderr at the given verbosity level
+func Default(level int) Logger {
+	return New(os.Stderr, level)
+}
+
+func (l *writerLogger) logf(level int, format string, args ...any) {
+	if l.level < level {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprint(l.writer, l.palette.Markers(fmt.Sprintf(format, args...)))
+}
+
+func (l *writerLogger) Printf(format string, args ...any) {
+	l.logf(constants.VerbosityQuiet, format, args...)
+}
+
+func (l *writerLogger) Warnf(format string, args ...any) {
+	l.logf(constants.VerbosityProgress, format, args...)
+}
+
+func (l *writerLogger) Infof(format string, args ...any) {
+	l.logf(constants.VerbosityProgress, format, args...)
+}
+
+func (l *writerLogger) Debugf(format string, args ...any) {
+	l.logf(constants.VerbosityRequests, format, args...)
+}
+
+func (l *writerLogger) Tracef(format string, args ...any) {
+	l.logf(constants.VerbosityBodies, format, args...)
+}
+
+func (l *writerLogger) Level() int {
+	return l.level
+}
+
+// nopLogger discards all output (quiet mode)
+type nopLogger struct{}
+
+// Nop returns a logger that discards everything
+func Nop() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Printf(string, ...any) {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Tracef(string, ...any) {}
+func (nopLogger) Level() int            { return constants.VerbosityQuiet }
@@ -0,0 +1,78 @@
+// Package metrics derives activity statistics from fetched Jira and GitHub data
+package metrics
+
+import (
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+)
+
+// MonthlyCounts is one calendar month of activity
+type MonthlyCounts struct {
+	Month        string `json:"month"` // YYYY-MM
+	PRsCreated   int    `json:"prsCreated"`
+	PRsMerged    int    `json:"prsMerged"`
+	JiraCreated  int    `json:"jiraCreated"`
+	JiraResolved int    `json:"jiraResolved"`
+}
+
+// Monthly buckets activity into calendar months from the start date to the end
+// date (both inclusive), including empty months so charts have a continuous
+// axis. PRs are counted in the month they were created and merged, Jira issues
+// in the month they were created and resolved; events outside the range are ignored.
+func Monthly(start, end time.Time, prs []github.UserPullRequest, issues []jira.Issue) []MonthlyCounts {
+	loc := start.Location()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	var series []MonthlyCounts
+	index := make(map[string]int)
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, loc); month.Before(end); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		index[key] = len(series)
+		series = append(series, MonthlyCounts{Month: key})
+	}
+
+	// bucket returns the month entry for timestamp s, or nil if it is unparseable or out of range
+	bucket := func(s string) *MonthlyCounts {
+		t, ok := jira.ParseTime(s)
+		if !ok || t.Before(start) || !t.Before(end) {
+			return nil
+		}
+		if i, ok := index[t.In(loc).Format("2006-01")]; ok {
+			return &series[i]
+		}
+		return nil
+	}
+
+	for _, pr := range prs {
+		if m := bucket(pr.CreatedAt); m != nil {
+			m.PRsCreated++
+		}
+		if m := bucket(mergedAt(pr)); m != nil {
+			m.PRsMerged++
+		}
+	}
+	for _, issue := range issues {
+		if m := bucket(issue.Created); m != nil {
+			m.JiraCreated++
+		}
+		if m := bucket(issue.Resolved); m != nil {
+			m.JiraResolved++
+		}
+	}
+	return series
+}
+
+// mergedAt returns when a PR was merged, or "" if it was not. Cached entries
+// without merge details fall back to the last update of a merged PR.
+func mergedAt(pr github.UserPullRequest) string {
+	switch {
+	case !pr.IsMerged():
+		return ""
+	case pr.PullRequest != nil:
+		return pr.PullRequest.MergedAt
+	default:
+		return pr.UpdatedAt
+	}
+}
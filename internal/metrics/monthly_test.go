@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+)
+
+func TestMonthly(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	prs := []github.UserPullRequest{
+		// Created in January, merged in March
+		{CreatedAt: "2025-01-20T10:00:00Z", State: "closed", PullRequest: &github.PullRequestMeta{MergedAt: "2025-03-02T10:00:00Z"}},
+		// Created on the end date, still open
+		{CreatedAt: "2025-03-31T18:00:00Z", State: "open", PullRequest: &github.PullRequestMeta{}},
+		// Created before the range, closed without merging
+		{CreatedAt: "2024-12-01T10:00:00Z", State: "closed", PullRequest: &github.PullRequestMeta{}},
+	}
+	issues := []jira.Issue{
+		{Created: "2025-02-03T09:00:00.000+0000", Resolved: "2025-02-20T09:00:00.000+0000"},
+		{Created: "2024-11-01T09:00:00.000+0000", Resolved: "2025-03-05T09:00:00.000+0000"},
+		{Created: "2025-01-10T09:00:00.000+0000"}, // before the start date
+	}
+
+	want := []MonthlyCounts{
+		{Month: "2025-01", PRsCreated: 1},
+		{Month: "2025-02", JiraCreated: 1, JiraResolved: 1},
+		{Month: "2025-03", PRsCreated: 1, PRsMerged: 1, JiraResolved: 1},
+	}
+	got := Monthly(start, end, prs, issues)
+	if len(got) != len(want) {
+		t.Fatalf("got %d months, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("month %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
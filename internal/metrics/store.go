@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+
+	// Pure-Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// Run is the activity counts recorded for one user and period
+type Run struct {
+	Email       string    `json:"email"`
+	Period      string    `json:"period"` // YYYY-MM-DD/YYYY-MM-DD
+	PRsCreated  int       `json:"prsCreated"`
+	PRsMerged   int       `json:"prsMerged"`
+	JiraCreated int       `json:"jiraCreated"`
+	JiraUpdated int       `json:"jiraUpdated"`
+	RecordedAt  time.Time `json:"recordedAt"`
+}
+
+// NewRun counts a user's activity for a period. Jira issues created before
+// the start date count as updated, matching the highlight stats.
+func NewRun(email string, start, end time.Time, prs []github.UserPullRequest, issues []jira.Issue) Run {
+	run := Run{
+		Email:      email,
+		Period:     Period(start, end),
+		PRsCreated: len(prs),
+		RecordedAt: time.Now(),
+	}
+	for _, pr := range prs {
+		if pr.IsMerged() {
+			run.PRsMerged++
+		}
+	}
+	for _, issue := range issues {
+		if created, ok := jira.ParseTime(issue.Created); ok && !created.Before(start) {
+			run.JiraCreated++
+		} else {
+			run.JiraUpdated++
+		}
+	}
+	return run
+}
+
+// Period formats a date range as an ISO 8601 interval, e.g. 2025-01-01/2025-01-31
+func Period(start, end time.Time) string {
+	return start.Format("2006-01-02") + "/" + end.Format("2006-01-02")
+}
+
+const storeSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	email        TEXT NOT NULL,
+	period       TEXT NOT NULL,
+	prs_created  INTEGER NOT NULL,
+	prs_merged   INTEGER NOT NULL,
+	jira_created INTEGER NOT NULL,
+	jira_updated INTEGER NOT NULL,
+	timestamp    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS runs_email_period ON runs (email, period);
+`
+
+// Store persists run metrics in a local SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultStorePath returns ~/.perfdive/metrics.db
+func DefaultStorePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".perfdive", "metrics.db"), nil
+}
+
+// OpenStore opens the metrics database at path, creating it if needed
+func OpenStore(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metrics store: %w", err)
+	}
+	if _, err := db.Exec(storeSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize metrics store: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record appends a run
+func (s *Store) Record(run Run) error {
+	_, err := s.db.Exec(
+		`INSERT INTO runs (email, period, prs_created, prs_merged, jira_created, jira_updated, timestamp) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		strings.ToLower(run.Email), run.Period, run.PRsCreated, run.PRsMerged, run.JiraCreated, run.JiraUpdated, run.RecordedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record metrics: %w", err)
+	}
+	return nil
+}
+
+// Last returns the user's n most recent periods, oldest first. A period
+// recorded more than once is reported from its latest run.
+func (s *Store) Last(email string, n int) ([]Run, error) {
+	rows, err := s.db.Query(`
+		SELECT email, period, prs_created, prs_merged, jira_created, jira_updated, timestamp
+		FROM runs r
+		WHERE email = ? AND id = (SELECT MAX(id) FROM runs WHERE email = r.email AND period = r.period)
+		ORDER BY period DESC
+		LIMIT ?`, strings.ToLower(email), n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metrics: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var recorded string
+		if err := rows.Scan(&run.Email, &run.Period, &run.PRsCreated, &run.PRsMerged, &run.JiraCreated, &run.JiraUpdated, &recorded); err != nil {
+			return nil, fmt.Errorf("failed to read metrics: %w", err)
+		}
+		run.RecordedAt, _ = time.Parse(time.RFC3339, recorded)
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	// Reverse into chronological order for trend display
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs, nil
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreLast(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "metrics.db"))
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	records := []Run{
+		{Email: "a@example.com", Period: "2025-01-01/2025-01-31", PRsCreated: 1},
+		{Email: "a@example.com", Period: "2025-02-01/2025-02-28", PRsCreated: 2},
+		{Email: "A@example.com", Period: "2025-03-01/2025-03-31", PRsCreated: 3},
+		// A re-run of February replaces the earlier row in trends
+		{Email: "a@example.com", Period: "2025-02-01/2025-02-28", PRsCreated: 5},
+		{Email: "b@example.com", Period: "2025-03-01/2025-03-31", PRsCreated: 9},
+	}
+	for _, run := range records {
+		run.RecordedAt = time.Now()
+		if err := store.Record(run); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	got, err := store.Last("a@example.com", 2)
+	if err != nil {
+		t.Fatalf("Last() error = %v", err)
+	}
+	want := []struct {
+		period     string
+		prsCreated int
+	}{
+		{"2025-02-01/2025-02-28", 5},
+		{"2025-03-01/2025-03-31", 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Last() returned %d runs, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Period != w.period || got[i].PRsCreated != w.prsCreated {
+			t.Errorf("run %d = %s with %d PRs, want %s with %d", i, got[i].Period, got[i].PRsCreated, w.period, w.prsCreated)
+		}
+	}
+}
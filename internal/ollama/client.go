@@ -4,41 +4,116 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/dateparse"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/httpclient"
 	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/logger"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/redact"
 )
 
-// extractProjectFromKey extracts the project prefix from a Jira issue key
-// e.g., "CNF-18498" -> "CNF", "OCPBUGS-45703" -> "OCPBUGS"
-func extractProjectFromKey(key string) string {
-	parts := strings.Split(key, "-")
-	if len(parts) > 0 {
-		return parts[0]
-	}
-	return "UNKNOWN"
-}
-
 // Client wraps the Ollama API client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	hosts       []string
+	next        atomic.Uint64 // Round-robin position in hosts
+	httpClient  *http.Client
+	testTimeout time.Duration
+	log         logger.Logger
+	options     map[string]any
+	redactor    *redact.Redactor
+	counters    counters
 }
 
 // Config holds the configuration for Ollama client
 type Config struct {
 	URL string
+	// URLs lists several Ollama hosts to spread requests over; when set, URL is ignored
+	URLs   []string
+	Logger logger.Logger // Diagnostic logger (defaults to stderr)
+
+	// Transport for API requests (defaults to a proxy-aware transport; see httpclient.NewTransport)
+	Transport http.RoundTripper
+
+	// Timeout for generate requests (defaults to constants.OllamaTimeout). The
+	// connection test uses the shorter of this and constants.OllamaTestTimeout.
+	Timeout time.Duration
+
+	// Options are model parameters passed unchanged to every generate request,
+	// e.g. temperature, top_p, num_ctx, seed. They override the summary
+	// length's token limit.
+	Options map[string]any
+
+	// Redactor, when set (--strip-pii-from-cache), masks secrets and PII in
+	// the Jira and GitHub data of every prompt before it is sent
+	Redactor *redact.Redactor
 }
 
 // GenerateRequest represents the request structure for Ollama
 type GenerateRequest struct {
-	Model  string `json:"model"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"`
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Options map[string]any `json:"options,omitempty"` // Model parameters, see Config.Options
+	Format  string         `json:"format,omitempty"`  // "json" constrains the response to valid JSON
+}
+
+// SummaryLength controls how long the narrative summaries are
+type SummaryLength string
+
+const (
+	SummaryShort  SummaryLength = "short"
+	SummaryMedium SummaryLength = "medium"
+	SummaryLong   SummaryLength = "long"
+)
+
+// ParseSummaryLength parses a summary length; empty defaults to medium
+func ParseSummaryLength(s string) (SummaryLength, error) {
+	switch SummaryLength(strings.ToLower(strings.TrimSpace(s))) {
+	case "", SummaryMedium:
+		return SummaryMedium, nil
+	case SummaryShort:
+		return SummaryShort, nil
+	case SummaryLong:
+		return SummaryLong, nil
+	default:
+		return SummaryMedium, fmt.Errorf("invalid summary length '%s': must be short, medium, or long", s)
+	}
+}
+
+// directive returns the prompt instruction for the length (none for medium,
+// which keeps the model's default paragraph-length answer)
+func (l SummaryLength) directive() string {
+	switch l {
+	case SummaryShort:
+		return "LENGTH: Respond in at most 2 sentences. No headings or bullet points.\n\n"
+	case SummaryLong:
+		return "LENGTH: Write 3-4 detailed paragraphs, citing specific issues, pull requests and repositories as evidence.\n\n"
+	default:
+		return ""
+	}
+}
+
+// options returns the token limit hint for the length
+func (l SummaryLength) options() map[string]any {
+	switch l {
+	case SummaryShort:
+		return map[string]any{"num_predict": 200}
+	case SummaryLong:
+		return map[string]any{"num_predict": 2048}
+	default:
+		return nil
+	}
 }
 
 // GenerateResponse represents the response structure from Ollama
@@ -59,15 +134,58 @@ type SummaryRequest struct {
 	Issues        []jira.Issue
 	Format        string                // "text" or "json"
 	GitHubContext *github.GitHubContext // Optional GitHub context
+	TotalIssues   int                   // Issues found before --max-issues truncation
+	TotalPRs      int                   // PRs found before --max-prs truncation
+	GroupByRepo   bool                  // Add a per-repository PR table to the metrics
+	Sections      output.Sections       // Sections to generate (nil = all)
+	Order         []output.Section      // Order of the sections (nil = jira, github, metrics)
+	Length        SummaryLength         // Narrative length (empty = medium)
+	Persona       Persona               // Writing voice of the narratives (empty = professional)
+	Resolutions   map[string]string     // Resolution name by issue key, for resolved issues (optional)
+	Comments      int                   // Most recent comments quoted per Jira issue (0 = none)
+	GroupBy       jira.GroupBy          // How Jira issues are grouped (empty = by project)
+	ShippedOnly   bool                  // Only merged PRs and Done/Closed issues were kept (--shipped-only)
+	Excluded      []string              // What --shipped-only dropped, e.g. "3 open PRs" (optional)
 }
 
 // NewClient creates a new Ollama client
 func NewClient(config Config) *Client {
+	log := config.Logger
+	if log == nil {
+		log = logger.Default(constants.VerbosityQuiet)
+	}
+	transport := config.Transport
+	if transport == nil {
+		transport = httpclient.DefaultTransport()
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = constants.OllamaTimeout
+	}
+	testTimeout := constants.OllamaTestTimeout
+	if timeout < testTimeout {
+		testTimeout = timeout
+	}
+
+	urls := config.URLs
+	if len(urls) == 0 {
+		urls = []string{config.URL}
+	}
+	hosts := make([]string, 0, len(urls))
+	for _, url := range urls {
+		hosts = append(hosts, strings.TrimSuffix(strings.TrimSpace(url), "/"))
+	}
+
 	return &Client{
-		baseURL: strings.TrimSuffix(config.URL, "/"),
+		hosts: hosts,
 		httpClient: &http.Client{
-			Timeout: 5 * time.Minute, // Allow time for model processing
+			Timeout:   timeout, // Allow time for model processing
+			Transport: transport,
 		},
+		testTimeout: testTimeout,
+		log:         log,
+		options:     config.Options,
+		redactor:    config.Redactor,
 	}
 }
 
@@ -75,38 +193,49 @@ func NewClient(config Config) *Client {
 func (c *Client) GenerateSummary(req SummaryRequest) (string, error) {
 	var result strings.Builder
 
-	// Generate Jira summary
-	jiraSummary, err := c.generateJiraSummary(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate Jira summary: %w", err)
-	}
-
-	// Generate GitHub summary
-	githubSummary, err := c.generateGitHubSummary(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate GitHub summary: %w", err)
+	// Only call the model for the narrative sections that will be emitted
+	for _, section := range req.sectionOrder() {
+		if !req.Sections.Has(section) {
+			continue
+		}
+		switch section {
+		case output.SectionJira:
+			jiraSummary, err := c.generateJiraSummary(req)
+			if err != nil {
+				return "", fmt.Errorf("failed to generate Jira summary: %w", err)
+			}
+			result.WriteString("**JIRA PROJECT WORK SUMMARY**\n\n")
+			result.WriteString(jiraSummary)
+		case output.SectionGitHub:
+			githubSummary, err := c.generateGitHubSummary(req)
+			if err != nil {
+				return "", fmt.Errorf("failed to generate GitHub summary: %w", err)
+			}
+			result.WriteString("**GITHUB DEVELOPMENT SUMMARY**\n\n")
+			result.WriteString(githubSummary)
+		case output.SectionMetrics:
+			result.WriteString("**PERFORMANCE METRICS**\n\n")
+			result.WriteString(c.buildQuantitativeSummary(req))
+		}
+		result.WriteString("\n\n")
 	}
 
-	// Combine the results
-	result.WriteString("**JIRA PROJECT WORK SUMMARY**\n\n")
-	result.WriteString(jiraSummary)
-	result.WriteString("\n\n")
-
-	result.WriteString("**GITHUB DEVELOPMENT SUMMARY**\n\n")
-	result.WriteString(githubSummary)
-	result.WriteString("\n\n")
-
-	// Add quantitative summary
-	result.WriteString("**PERFORMANCE METRICS**\n\n")
-	result.WriteString(c.buildQuantitativeSummary(req))
+	return strings.TrimRight(result.String(), "\n") + "\n", nil
+}
 
-	return result.String(), nil
+// sectionOrder returns the order of the summary's sections, the default
+// order unless req.Order is set
+func (req SummaryRequest) sectionOrder() []output.Section {
+	if len(req.Order) == 0 {
+		return output.DefaultSectionOrder
+	}
+	return req.Order
 }
 
 // generateJiraSummary creates a focused summary of Jira work
 func (c *Client) generateJiraSummary(req SummaryRequest) (string, error) {
 	prompt := c.buildJiraPrompt(req)
-	return c.callOllama(req.Model, prompt)
+	return c.callOllamaWithOptions(req.Model, prompt, req.Length.options())
 }
 
 // generateGitHubSummary creates a focused summary of GitHub work
@@ -123,31 +252,52 @@ func (c *Client) generateGitHubSummary(req SummaryRequest) (string, error) {
 	}
 
 	prompt := c.buildGitHubPrompt(req)
-	return c.callOllama(req.Model, prompt)
+	return c.callOllamaWithOptions(req.Model, prompt, req.Length.options())
 }
 
-// hasMeaningfulGitHubActivity checks if there are meaningful GitHub contributions (PRs or issues)
+// hasMeaningfulGitHubActivity checks if there are meaningful GitHub contributions (PRs, issues or commits)
 func (c *Client) hasMeaningfulGitHubActivity(req SummaryRequest) bool {
 	if req.GitHubContext == nil || req.GitHubContext.ComprehensiveActivity == nil {
 		return false
 	}
 
 	activity := req.GitHubContext.ComprehensiveActivity
-	return len(activity.PullRequests) > 0 || len(activity.Issues) > 0
+	return len(activity.PullRequests) > 0 || len(activity.Issues) > 0 || len(activity.Commits) > 0
 }
 
 // CallOllama makes the actual API call to Ollama with a simple prompt
-// This is exported for use by other commands like highlight
+// This is exported for use by other commands like highlight. Such prompts
+// list titles and summaries only, so with a Redactor the whole prompt is redacted.
 func (c *Client) CallOllama(model, prompt string) (string, error) {
-	return c.callOllama(model, prompt)
+	return c.callOllama(model, c.redactor.String(prompt))
+}
+
+// CallOllamaJSON is CallOllama with the response constrained to JSON
+// (Ollama's "format": "json"), for prompts whose answer is parsed as JSON
+func (c *Client) CallOllamaJSON(model, prompt string) (string, error) {
+	return c.generate(model, c.redactor.String(prompt), "json", nil)
 }
 
 // callOllama makes the actual API call to Ollama
 func (c *Client) callOllama(model, prompt string) (string, error) {
+	return c.callOllamaWithOptions(model, prompt, nil)
+}
+
+// callOllamaWithOptions makes the API call with optional model parameters;
+// the client's configured options take precedence over options
+func (c *Client) callOllamaWithOptions(model, prompt string, options map[string]any) (string, error) {
+	return c.generate(model, prompt, "", options)
+}
+
+// generate sends the prompt to Ollama's generate endpoint, with the response
+// in the given format ("" for free text)
+func (c *Client) generate(model, prompt, format string, options map[string]any) (_ string, err error) {
 	ollamaReq := GenerateRequest{
-		Model:  model,
-		Prompt: prompt,
-		Stream: false,
+		Model:   model,
+		Prompt:  prompt,
+		Stream:  false,
+		Options: mergeOptions(options, c.options),
+		Format:  format,
 	}
 
 	reqBody, err := json.Marshal(ollamaReq)
@@ -155,22 +305,18 @@ func (c *Client) callOllama(model, prompt string) (string, error) {
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/api/generate", c.baseURL)
-	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
+	c.log.Tracef("----- PROMPT -----\n%s\n------------------\n", prompt)
 
-	resp, err := c.httpClient.Do(httpReq)
+	start := time.Now()
+	defer func() { c.counters.record(time.Since(start), err) }()
+	resp, url, err := c.postGenerate(reqBody, model, len(prompt))
 	if err != nil {
-		return "", fmt.Errorf("failed to send request to Ollama: %w", err)
+		return "", err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("ollama returned status %d", resp.StatusCode)
+		return "", fmt.Errorf("ollama at %s returned status %d", url, resp.StatusCode)
 	}
 
 	var ollamaResp GenerateResponse
@@ -178,9 +324,40 @@ func (c *Client) callOllama(model, prompt string) (string, error) {
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	c.log.Debugf("  ← %d in %v (%d byte response)\n", resp.StatusCode, time.Since(start).Round(time.Millisecond), len(ollamaResp.Response))
+	c.log.Tracef("----- RESPONSE -----\n%s\n--------------------\n", ollamaResp.Response)
+
 	return ollamaResp.Response, nil
 }
 
+// postGenerate sends a generate request to the next host in round-robin
+// order, failing over to the remaining hosts on connection errors. It
+// returns the response and the URL that served it.
+func (c *Client) postGenerate(reqBody []byte, model string, promptLen int) (*http.Response, string, error) {
+	first := int(c.next.Add(1)-1) % len(c.hosts)
+
+	var errs []error
+	for i := range c.hosts {
+		url := fmt.Sprintf("%s/api/generate", c.hosts[(first+i)%len(c.hosts)])
+		httpReq, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, url, fmt.Errorf("failed to create request: %w", err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		c.log.Debugf("  → POST %s (model %s, %d byte prompt)\n", url, model, promptLen)
+		resp, err := c.httpClient.Do(httpReq)
+		if err == nil {
+			return resp, url, nil
+		}
+		errs = append(errs, err)
+		if i < len(c.hosts)-1 {
+			c.log.Warnf("  ⚠ Ollama at %s unreachable, trying next host: %v\n", url, err)
+		}
+	}
+	return nil, "", fmt.Errorf("failed to send request to Ollama: %w", errors.Join(errs...))
+}
+
 // buildJiraPrompt creates a focused prompt for analyzing Jira work
 func (c *Client) buildJiraPrompt(req SummaryRequest) string {
 	var builder strings.Builder
@@ -190,20 +367,39 @@ func (c *Client) buildJiraPrompt(req SummaryRequest) string {
 		userName = req.DisplayName
 	}
 
-	fmt.Fprintf(&builder,
-		"Analyze %s's Jira project work from %s to %s. Write a professional summary of their project management and problem-solving contributions.\n\n",
-		userName, req.StartDate, req.EndDate,
-	)
+	if req.ShippedOnly {
+		fmt.Fprintf(&builder,
+			"Analyze the Jira work %s completed from %s to %s. %s\n\n",
+			userName, req.StartDate, req.EndDate, req.Persona.task("what they delivered"),
+		)
+		builder.WriteString(shippedDirective("issue below is Done or Closed"))
+	} else {
+		fmt.Fprintf(&builder,
+			"Analyze %s's Jira project work from %s to %s. %s\n\n",
+			userName, req.StartDate, req.EndDate, req.Persona.task("their project management and problem-solving contributions"),
+		)
+	}
 
 	builder.WriteString("Focus on:\n")
 	builder.WriteString("- Issues resolved and business impact\n")
 	builder.WriteString("- Project contributions across different areas\n")
 	builder.WriteString("- Technical problem-solving achievements\n")
 	builder.WriteString("- Collaboration and stakeholder engagement\n\n")
+	if len(req.Resolutions) > 0 {
+		builder.WriteString("Each resolved issue's status is followed by its resolution. Only issues resolved as Done, Fixed or similar are completed work; " +
+			"issues closed as Won't Do, Duplicate, Cannot Reproduce or Obsolete were not delivered, so do not present them as accomplishments.\n\n")
+	}
+	if req.GroupBy == jira.GroupByComponent || req.GroupBy == jira.GroupByLabel {
+		fmt.Fprintf(&builder, "The issues are grouped by %s rather than by project. Summarize the work by these themes, noting when a theme spans several projects.\n\n", req.GroupBy)
+	}
+	builder.WriteString(req.Persona.directive())
 	builder.WriteString("IMPORTANT: Do NOT include any numerical ratings, scores, or grades. Focus on qualitative analysis only.\n\n")
+	builder.WriteString(req.Length.directive())
 
-	// Add Jira issues data
-	c.addJiraData(&builder, req)
+	// Add Jira issues data; only the data is redacted, not the user's name
+	var data strings.Builder
+	c.addJiraData(&data, req)
+	builder.WriteString(c.redactor.String(data.String()))
 
 	return builder.String()
 }
@@ -217,54 +413,264 @@ func (c *Client) buildGitHubPrompt(req SummaryRequest) string {
 		userName = req.DisplayName
 	}
 
-	fmt.Fprintf(&builder,
-		"Analyze %s's GitHub development contributions from %s to %s. Write a professional summary of their technical contributions and development productivity.\n\n",
-		userName, req.StartDate, req.EndDate,
-	)
+	if req.ShippedOnly {
+		fmt.Fprintf(&builder,
+			"Analyze the GitHub development work %s shipped from %s to %s. %s\n\n",
+			userName, req.StartDate, req.EndDate, req.Persona.task("the technical contributions they delivered"),
+		)
+		builder.WriteString(shippedDirective("pull request below was merged"))
+	} else {
+		fmt.Fprintf(&builder,
+			"Analyze %s's GitHub development contributions from %s to %s. %s\n\n",
+			userName, req.StartDate, req.EndDate, req.Persona.task("their technical contributions and development productivity"),
+		)
+	}
 
 	builder.WriteString("Focus on:\n")
 	builder.WriteString("- Code contributions and technical improvements\n")
 	builder.WriteString("- Repository impact and collaboration\n")
 	builder.WriteString("- Development quality and productivity\n")
 	builder.WriteString("- Open source community engagement\n\n")
+	builder.WriteString(req.Persona.directive())
 	builder.WriteString("IMPORTANT: Do NOT include any numerical ratings, scores, or grades. Focus on qualitative analysis only.\n\n")
+	builder.WriteString(req.Length.directive())
 
-	// Add GitHub data
-	c.addGitHubData(&builder, req)
+	// Add GitHub data; only the data is redacted, not the user's name
+	var data strings.Builder
+	c.addGitHubData(&data, req)
+	builder.WriteString(c.redactor.String(data.String()))
 
 	return builder.String()
 }
 
+// shippedDirective frames a --shipped-only prompt as completed work, e.g.
+// "Every pull request below was merged. ..."
+func shippedDirective(every string) string {
+	return "Every " + every + ". Describe this delivered work in the past tense, as completed deliverables; " +
+		"do not describe anything as ongoing, in progress or planned.\n\n"
+}
+
 // buildQuantitativeSummary creates the metrics section
 func (c *Client) buildQuantitativeSummary(req SummaryRequest) string {
 	var builder strings.Builder
 
 	// Jira metrics
-	fmt.Fprintf(&builder, "**Jira Issues:** %d total\n", len(req.Issues))
+	fmt.Fprintf(&builder, "**Jira Issues:** %d total%s\n", len(req.Issues), truncationNote(len(req.Issues), req.TotalIssues))
 	if len(req.Issues) > 0 {
-		projectGroups := make(map[string]int)
+		groupCounts := make(map[string]int)
 		for _, issue := range req.Issues {
-			project := extractProjectFromKey(issue.Key)
-			projectGroups[project]++
+			for _, group := range req.GroupBy.Groups(issue) {
+				groupCounts[group]++
+			}
+		}
+		if req.GroupBy == jira.GroupByComponent || req.GroupBy == jira.GroupByLabel {
+			fmt.Fprintf(&builder, "- By %s (issues with several are counted in each):\n", req.GroupBy)
+			for _, group := range sortedKeys(groupCounts) {
+				fmt.Fprintf(&builder, "  - %s: %d issues\n", group, groupCounts[group])
+			}
+		} else {
+			for _, project := range sortedKeys(groupCounts) {
+				fmt.Fprintf(&builder, "- %s: %d issues\n", project, groupCounts[project])
+			}
+		}
+		if resolved := resolutionSummary(req.Issues, req.Resolutions); resolved != "" {
+			fmt.Fprintf(&builder, "- Resolved: %s\n", resolved)
 		}
-		for project, count := range projectGroups {
-			fmt.Fprintf(&builder, "- %s: %d issues\n", project, count)
+		if cycles := jira.AverageCycleTimeByProject(req.Issues); len(cycles) > 0 {
+			builder.WriteString("- Average cycle time (first status change to resolution):\n")
+			for _, project := range cycles {
+				fmt.Fprintf(&builder, "  - %s: %s (%d issues)\n", project.Project, formatDays(project.Average), project.Issues)
+			}
+		}
+		if inStatus := statusTimeSummary(req.Issues, rangeEnd(req.EndDate)); inStatus != "" {
+			fmt.Fprintf(&builder, "- Time in status: %s\n", inStatus)
 		}
 	}
 
 	// GitHub metrics
 	if req.GitHubContext != nil && req.GitHubContext.ComprehensiveActivity != nil {
 		activity := req.GitHubContext.ComprehensiveActivity
-		totalActivity := len(activity.PullRequests) + len(activity.Issues) + len(activity.Events)
+		// PRs both authored and referenced from Jira are counted once
+		prs := github.UniquePullRequests(req.GitHubContext)
+		totalActivity := len(prs) + len(activity.Issues) + len(activity.Events)
 		fmt.Fprintf(&builder, "\n**GitHub Contributions:** %d total\n", totalActivity)
-		fmt.Fprintf(&builder, "- Pull Requests: %d\n", len(activity.PullRequests))
+		fmt.Fprintf(&builder, "- Pull Requests: %d%s\n", len(prs), truncationNote(len(activity.PullRequests), req.TotalPRs))
+		if referencedOnly := len(prs) - len(activity.PullRequests); referencedOnly > 0 {
+			fmt.Fprintf(&builder, "  - Referenced from Jira only: %d\n", referencedOnly)
+		}
+		if sizes := sizeSummary(prs); sizes != "" {
+			fmt.Fprintf(&builder, "  - By size: %s\n", sizes)
+		}
 		fmt.Fprintf(&builder, "- Issues: %d\n", len(activity.Issues))
+		if len(activity.Commits) > 0 {
+			fmt.Fprintf(&builder, "- Commits: %d\n", len(activity.Commits))
+		}
 		fmt.Fprintf(&builder, "- Other Activities: %d\n", len(activity.Events))
 	}
 
+	if req.GroupByRepo {
+		if breakdown := github.RepoBreakdown(req.GitHubContext); len(breakdown) > 0 {
+			builder.WriteString("\n**Pull Requests by Repository:**\n\n")
+			builder.WriteString("| Repository | Opened | Merged | Additions | Deletions |\n")
+			builder.WriteString("|------------|--------|--------|-----------|-----------|\n")
+			for _, repo := range breakdown {
+				additions, deletions := "n/a", "n/a"
+				if repo.LineCounts {
+					additions = fmt.Sprintf("+%d", repo.Additions)
+					deletions = fmt.Sprintf("-%d", repo.Deletions)
+				}
+				fmt.Fprintf(&builder, "| %s | %d | %d | %s | %s |\n", repo.Repo, repo.Opened, repo.Merged, additions, deletions)
+			}
+		}
+	}
+
+	if req.ShippedOnly {
+		excluded := "nothing"
+		if len(req.Excluded) > 0 {
+			excluded = strings.Join(req.Excluded, ", ")
+		}
+		fmt.Fprintf(&builder, "\nShipped only (merged PRs, Done/Closed Jira issues); excluded: %s\n", excluded)
+	}
+
+	// Flag referenced PRs whose reviews, files or diff could not all be fetched
+	if req.GitHubContext != nil {
+		if incomplete := len(req.GitHubContext.IncompletePullRequests()); incomplete > 0 {
+			fmt.Fprintf(&builder, "\nNote: %d of %d referenced PRs had incomplete data due to rate limits or API errors; re-run later for a complete summary\n",
+				incomplete, len(req.GitHubContext.PullRequests))
+		}
+	}
+
 	return builder.String()
 }
 
+// sizeSummary describes the PR size distribution, e.g. "3 L, 8 S (2 unsized)",
+// or returns "" if no PR has size data
+func sizeSummary(prs []github.PRRecord) string {
+	counts, unknown := github.SizeDistribution(prs)
+	if unknown == len(prs) {
+		return ""
+	}
+	summary := github.FormatSizeDistribution(counts)
+	if unknown > 0 {
+		summary += fmt.Sprintf(" (%d unsized)", unknown)
+	}
+	return summary
+}
+
+// resolutionSummary counts the issues by resolution, most common first, e.g.
+// "8 Done, 2 Won't Do", or returns "" if no issue has a known resolution
+func resolutionSummary(issues []jira.Issue, resolutions map[string]string) string {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		if resolution := resolutions[issue.Key]; resolution != "" {
+			counts[resolution]++
+		}
+	}
+	names := sortedKeys(counts)
+	sort.SliceStable(names, func(i, j int) bool { return counts[names[i]] > counts[names[j]] })
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[name], name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statusTimeSummary totals the time the issues spent in each status, longest
+// first, e.g. "In Progress 12.5 days, Review 3.0 days", or returns "" if no
+// issue has a status history
+func statusTimeSummary(issues []jira.Issue, now time.Time) string {
+	totals := make(map[string]time.Duration)
+	for _, issue := range issues {
+		for status, duration := range jira.TimeInStatus(issue, now) {
+			totals[status] += duration
+		}
+	}
+	statuses := sortedKeys(totals)
+	sort.SliceStable(statuses, func(i, j int) bool { return totals[statuses[i]] > totals[statuses[j]] })
+
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s %s", status, formatDays(totals[status])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// rangeEnd returns the end of the summary range, midnight after its end date,
+// which open issues' current status is counted up to so that the prompt
+// doesn't depend on when it was built. It is the zero time, counting no
+// current status, if the end date doesn't parse.
+func rangeEnd(endDate string) time.Time {
+	end, err := dateparse.ParseDate(endDate)
+	if err != nil {
+		return time.Time{}
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+// formatDays formats a duration in days with one decimal, e.g. "2.5 days"
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1f days", d.Hours()/24)
+}
+
+// fileMetadataFiles is how many of a PR's largest files fileMetadata names
+const fileMetadataFiles = 5
+
+// fileMetadata describes a PR's changed files without their content, for PRs
+// whose code is withheld (--redact-diffs), e.g. "by type: source_code 3,
+// configuration 1; 2 test files, 0 doc files; largest: pkg/a.go modified +40/-2, ..."
+func fileMetadata(files []github.FileChange) string {
+	if len(files) == 0 {
+		return "file list not available"
+	}
+	types := make(map[string]int)
+	tests, docs := 0, 0
+	for _, file := range files {
+		types[file.FileType]++
+		if file.IsTestFile {
+			tests++
+		}
+		if file.IsDocFile {
+			docs++
+		}
+	}
+
+	largest := append([]github.FileChange(nil), files...)
+	sort.SliceStable(largest, func(i, j int) bool { return largest[i].Changes > largest[j].Changes })
+	names := make([]string, 0, fileMetadataFiles)
+	for _, file := range largest[:min(fileMetadataFiles, len(largest))] {
+		names = append(names, fmt.Sprintf("%s %s +%d/-%d", file.Filename, file.Status, file.Additions, file.Deletions))
+	}
+	return fmt.Sprintf("by type: %s; %d test files, %d doc files; largest: %s", countsByFrequency(types), tests, docs, strings.Join(names, ", "))
+}
+
+// prTitle returns the title of a deduplicated PR
+func prTitle(pr github.PRRecord) string {
+	if pr.Authored != nil {
+		return pr.Authored.Title
+	}
+	return pr.Enhanced.Title
+}
+
+// sortedKeys returns the map's keys in order, so map-grouped sections come
+// out the same on every run
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// truncationNote describes a capped result set, or returns "" if nothing was dropped
+func truncationNote(shown, total int) string {
+	if total <= shown {
+		return ""
+	}
+	return fmt.Sprintf(" (showing most recent %d of %d)", shown, total)
+}
+
 // addJiraData adds Jira issues data to the prompt builder
 func (c *Client) addJiraData(builder *strings.Builder, req SummaryRequest) {
 	builder.WriteString("JIRA ISSUES DATA:\n")
@@ -274,22 +680,48 @@ func (c *Client) addJiraData(builder *strings.Builder, req SummaryRequest) {
 		return
 	}
 
-	// Group issues by project
-	projectGroups := make(map[string][]jira.Issue)
+	// Group issues by project, component or label; an issue in several groups
+	// is detailed under its first and only named under the others
+	groups := make(map[string][]jira.Issue)
+	primary := make(map[string]string)
 	for _, issue := range req.Issues {
-		project := extractProjectFromKey(issue.Key)
-		projectGroups[project] = append(projectGroups[project], issue)
+		issueGroups := req.GroupBy.Groups(issue)
+		primary[issue.Key] = issueGroups[0]
+		for _, group := range issueGroups {
+			groups[group] = append(groups[group], issue)
+		}
 	}
 
-	// Output issues grouped by project
-	for project, issues := range projectGroups {
-		fmt.Fprintf(builder, "\n%s PROJECT (%d issues):\n", project, len(issues))
+	// Quoted comments share one budget, so heavily-discussed periods don't
+	// crowd the issues themselves out of the model's context
+	commentBudget := constants.JiraCommentTokenBudget
+	commentsOmitted := false
+
+	// Output the groups in name order so prompts are reproducible
+	for _, group := range sortedKeys(groups) {
+		issues := groups[group]
+		switch req.GroupBy {
+		case jira.GroupByComponent:
+			fmt.Fprintf(builder, "\nCOMPONENT %s (%d issues):\n", group, len(issues))
+		case jira.GroupByLabel:
+			fmt.Fprintf(builder, "\nLABEL %s (%d issues):\n", group, len(issues))
+		default:
+			fmt.Fprintf(builder, "\n%s PROJECT (%d issues):\n", group, len(issues))
+		}
 		for _, issue := range issues {
+			if primary[issue.Key] != group {
+				fmt.Fprintf(builder, "- %s: %s (detailed under %s)\n", issue.Key, issue.Summary, primary[issue.Key])
+				continue
+			}
 			issueTypeDisplay := ""
 			if issue.IssueType.Name != "" {
 				issueTypeDisplay = fmt.Sprintf(" (%s)", issue.IssueType.Name)
 			}
-			fmt.Fprintf(builder, "- %s%s: %s [%s]\n", issue.Key, issueTypeDisplay, issue.Summary, issue.Status.Name)
+			status := issue.Status.Name
+			if resolution := req.Resolutions[issue.Key]; resolution != "" {
+				status += ", resolution: " + resolution
+			}
+			fmt.Fprintf(builder, "- %s%s: %s [%s]\n", issue.Key, issueTypeDisplay, issue.Summary, status)
 			if issue.Description != "" && len(issue.Description) > 0 {
 				desc := issue.Description
 				if len(desc) > 150 {
@@ -297,8 +729,47 @@ func (c *Client) addJiraData(builder *strings.Builder, req SummaryRequest) {
 				}
 				fmt.Fprintf(builder, "  Context: %s\n", desc)
 			}
+			for _, comment := range recentComments(issue.Comments, req.Comments) {
+				line := fmt.Sprintf("  Comment (%s, %s): %s\n", comment.Author, comment.Created.Format("2006-01-02"), truncateComment(comment.Body))
+				if estimateTokens(line) > commentBudget {
+					commentsOmitted = true
+					break
+				}
+				commentBudget -= estimateTokens(line)
+				builder.WriteString(line)
+			}
 		}
 	}
+	if commentsOmitted {
+		builder.WriteString("\n(Some comments were omitted to keep the prompt within its size budget.)\n")
+	}
+}
+
+// jiraCommentLength caps each Jira comment quoted in the prompt
+const jiraCommentLength = 200
+
+// recentComments returns the issue's n most recent comments, oldest first so
+// the discussion reads in order
+func recentComments(comments []jira.Comment, n int) []jira.Comment {
+	if n <= 0 || len(comments) == 0 {
+		return nil
+	}
+	sorted := append([]jira.Comment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Created.Before(sorted[j].Created) })
+	return sorted[max(0, len(sorted)-n):]
+}
+
+// truncateComment flattens a comment to one line of at most jiraCommentLength bytes
+func truncateComment(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if len(body) <= jiraCommentLength {
+		return body
+	}
+	cut := jiraCommentLength
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut] + "..."
 }
 
 // addGitHubData adds GitHub activity data to the prompt builder
@@ -312,36 +783,48 @@ func (c *Client) addGitHubData(builder *strings.Builder, req SummaryRequest) {
 
 	activity := req.GitHubContext.ComprehensiveActivity
 
-	// Summarize PRs by repository
-	if len(activity.PullRequests) > 0 {
-		fmt.Fprintf(builder, "\nPull Requests (%d total):\n", len(activity.PullRequests))
-
-		repoGroups := make(map[string][]github.UserPullRequest)
-		for _, pr := range activity.PullRequests {
-			repoName := "unknown/repo"
-			if pr.RepositoryURL != "" {
-				parts := strings.Split(pr.RepositoryURL, "/")
-				if len(parts) >= 2 {
-					owner := parts[len(parts)-2]
-					repo := parts[len(parts)-1]
-					repoName = fmt.Sprintf("%s/%s", owner, repo)
-				}
+	// Summarize PRs by repository, counting PRs also referenced from Jira once
+	if prs := github.UniquePullRequests(req.GitHubContext); len(prs) > 0 {
+		fmt.Fprintf(builder, "\nPull Requests (%d total):\n", len(prs))
+
+		// Group by the case-insensitive key; Jira URLs may not match GitHub's casing
+		repoGroups := make(map[string][]github.PRRecord)
+		for _, pr := range prs {
+			repoGroups[pr.Key.Repo] = append(repoGroups[pr.Key.Repo], pr)
+		}
+
+		for _, repo := range sortedKeys(repoGroups) {
+			prs := repoGroups[repo]
+			counts := make(map[string]int)
+			for _, pr := range prs {
+				counts[pr.Status()]++
 			}
-			repoGroups[repoName] = append(repoGroups[repoName], pr)
+			fmt.Fprintf(builder, "- %s: %d PRs (%d merged, %d closed-unmerged, %d open)\n",
+				prs[0].RepoName(), len(prs), counts["merged"], counts["closed-unmerged"], counts["open"])
 		}
 
-		for repoName, prs := range repoGroups {
-			openCount := 0
-			closedCount := 0
+		// Point the narrative at the substantial pieces of work
+		if sizes := sizeSummary(prs); sizes != "" {
+			fmt.Fprintf(builder, "\nPR size distribution (XS to XL, by lines and files changed): %s\n", sizes)
 			for _, pr := range prs {
-				if pr.State == "open" {
-					openCount++
-				} else {
-					closedCount++
+				stats, ok := pr.Stats()
+				if !ok || (stats.Size() != github.SizeL && stats.Size() != github.SizeXL) {
+					continue
+				}
+				fmt.Fprintf(builder, "- Substantial PR [%s, +%d/-%d in %d files]: %s#%d %s\n",
+					stats.Size(), stats.Additions, stats.Deletions, stats.ChangedFiles, pr.RepoName(), pr.Key.Number, prTitle(pr))
+				if pr.Enhanced != nil && pr.Enhanced.DiffsRedacted {
+					fmt.Fprintf(builder, "  Code withheld; describe the change from its files only: %s\n", fileMetadata(pr.Enhanced.FilesChanged))
 				}
 			}
-			fmt.Fprintf(builder, "- %s: %d PRs (%d open, %d closed/merged)\n",
-				repoName, len(prs), openCount, closedCount)
+		}
+	}
+
+	// Without PRs (direct-to-main workflows), the commit messages describe the work
+	if len(activity.PullRequests) == 0 && len(activity.Commits) > 0 {
+		fmt.Fprintf(builder, "\nCommits (%d total):\n", len(activity.Commits))
+		for _, commit := range activity.Commits {
+			fmt.Fprintf(builder, "- %s: %s\n", commit.Repository.FullName, commit.Subject())
 		}
 	}
 
@@ -356,9 +839,6 @@ func (c *Client) addGitHubData(builder *strings.Builder, req SummaryRequest) {
 
 // TestConnection tests the Ollama connection by making a simple request
 func (c *Client) TestConnection(model string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
 	testReq := GenerateRequest{
 		Model:  model,
 		Prompt: "test",
@@ -370,7 +850,27 @@ func (c *Client) TestConnection(model string) error {
 		return fmt.Errorf("failed to marshal test request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/api/generate", c.baseURL)
+	// One reachable host is enough; requests fail over past the others
+	var errs []error
+	for _, host := range c.hosts {
+		err := c.testHost(host, reqBody)
+		if err == nil {
+			return nil
+		}
+		if len(c.hosts) > 1 {
+			c.log.Warnf("  ⚠ Ollama host %s failed the connection test: %v\n", host, err)
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// testHost sends the connection test request to one host
+func (c *Client) testHost(host string, reqBody []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.testTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/api/generate", host)
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return fmt.Errorf("failed to create test request: %w", err)
@@ -378,6 +878,8 @@ func (c *Client) TestConnection(model string) error {
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
+	c.log.Debugf("  → POST %s (connection test)\n", url)
+
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Ollama: %w", err)
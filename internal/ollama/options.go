@@ -0,0 +1,56 @@
+package ollama
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseModelParams parses a --model-params value such as
+// "temperature=0.2,num_ctx=8192,seed=42" into Ollama options. Numbers and
+// booleans are converted; other values are kept as strings.
+func ParseModelParams(spec string) (map[string]any, error) {
+	params := make(map[string]any)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid model parameter '%s': expected key=value", pair)
+		}
+		params[key] = parseParamValue(value)
+	}
+	return params, nil
+}
+
+// parseParamValue converts a parameter value to the JSON type Ollama expects
+func parseParamValue(value string) any {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	return value
+}
+
+// mergeOptions returns base overlaid with overrides, or nil if both are empty
+func mergeOptions(base, overrides map[string]any) map[string]any {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(map[string]any, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,60 @@
+package ollama
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Persona is the writing voice of the narrative summaries
+type Persona string
+
+const (
+	PersonaDefault  Persona = ""         // Professional third-person summary
+	PersonaEngineer Persona = "engineer" // Terse technical changelog
+	PersonaManager  Persona = "manager"  // Outcome-focused narrative
+	PersonaBrag     Persona = "brag"     // First-person brag document
+)
+
+// ParsePersona parses a persona; empty keeps the default voice
+func ParsePersona(s string) (Persona, error) {
+	switch persona := Persona(strings.ToLower(strings.TrimSpace(s))); persona {
+	case PersonaDefault, PersonaEngineer, PersonaManager, PersonaBrag:
+		return persona, nil
+	default:
+		return PersonaDefault, fmt.Errorf("invalid persona '%s': must be engineer, manager, or brag", s)
+	}
+}
+
+// task returns the sentence of a prompt's opener that asks for the summary
+// of work in the persona's voice, e.g. "Write a professional summary of
+// what they delivered."
+func (p Persona) task(work string) string {
+	switch p {
+	case PersonaEngineer:
+		return fmt.Sprintf("Write a terse technical changelog of %s.", work)
+	case PersonaManager:
+		return fmt.Sprintf("Write a narrative of %s for their manager, leading with outcomes.", work)
+	case PersonaBrag:
+		return fmt.Sprintf("Write a first-person brag document, in their own voice, about %s.", work)
+	default:
+		return fmt.Sprintf("Write a professional summary of %s.", work)
+	}
+}
+
+// directive returns the prompt instruction for the persona's voice (none for
+// the default professional summary)
+func (p Persona) directive() string {
+	switch p {
+	case PersonaEngineer:
+		return "VOICE: Write for fellow engineers, tersely and technically, like a changelog. Name the concrete components, repositories and changes; " +
+			"skip business framing, praise and filler adjectives.\n\n"
+	case PersonaManager:
+		return "VOICE: Write for a manager as a readable narrative. Lead with outcomes and their impact on the team, product and customers, " +
+			"explain technical work in plain language, and mention collaboration and any risks or blockers.\n\n"
+	case PersonaBrag:
+		return "VOICE: Write in the first person (\"I ...\") as the user's own brag document. Emphasize the impact of each piece of work and " +
+			"the problems it solved, citing specific issues and pull requests, and don't be modest.\n\n"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,49 @@
+package ollama
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+// Prompt is a prompt as it would be sent to the model, for --preview-prompt
+type Prompt struct {
+	Name string
+	Text string
+}
+
+// SummaryPrompts returns the prompts GenerateSummary would send for req,
+// without calling the model
+func (c *Client) SummaryPrompts(req SummaryRequest) []Prompt {
+	var prompts []Prompt
+	if req.Sections.Has(output.SectionJira) {
+		prompts = append(prompts, Prompt{Name: "Jira summary", Text: c.buildJiraPrompt(req)})
+	}
+	// Without meaningful activity the GitHub section is canned text, not a model call
+	if req.Sections.Has(output.SectionGitHub) && c.hasMeaningfulGitHubActivity(req) {
+		prompts = append(prompts, Prompt{Name: "GitHub summary", Text: c.buildGitHubPrompt(req)})
+	}
+	return prompts
+}
+
+// SimplePrompt returns prompt as CallOllama would send it
+func (c *Client) SimplePrompt(name, prompt string) Prompt {
+	return Prompt{Name: name, Text: c.redactor.String(prompt)}
+}
+
+// WritePrompts writes each prompt between delimiter lines naming it, with
+// its size in bytes and estimated tokens
+func WritePrompts(w io.Writer, prompts []Prompt) {
+	for _, prompt := range prompts {
+		header := fmt.Sprintf("===== PROMPT: %s (%d bytes, ~%d tokens) =====", prompt.Name, len(prompt.Text), estimateTokens(prompt.Text))
+		fmt.Fprintf(w, "%s\n%s\n%s\n\n", header, strings.TrimRight(prompt.Text, "\n"), strings.Repeat("=", len([]rune(header))))
+	}
+}
+
+// estimateTokens approximates a prompt's token count at 4 bytes per token, a
+// rule of thumb for English text; the model's tokenizer may differ
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
@@ -0,0 +1,164 @@
+package ollama
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+// statisticalTopPRs is how many of the largest PRs the statistical summary lists
+const statisticalTopPRs = 5
+
+// StatisticalSummary generates the summary without calling the model
+// (--no-llm): the narrative sections are replaced by counts and breakdowns
+// computed from the fetched data, so the same data always gives the same
+// summary. It has the same sections, headings and order as GenerateSummary.
+func (c *Client) StatisticalSummary(req SummaryRequest) string {
+	var result strings.Builder
+
+	for _, section := range req.sectionOrder() {
+		if !req.Sections.Has(section) {
+			continue
+		}
+		switch section {
+		case output.SectionJira:
+			result.WriteString("**JIRA PROJECT WORK SUMMARY**\n\n")
+			result.WriteString(jiraStatistics(req))
+		case output.SectionGitHub:
+			result.WriteString("**GITHUB DEVELOPMENT SUMMARY**\n\n")
+			result.WriteString(githubStatistics(req))
+		case output.SectionMetrics:
+			result.WriteString("**PERFORMANCE METRICS**\n\n")
+			result.WriteString(c.buildQuantitativeSummary(req))
+		}
+		result.WriteString("\n")
+	}
+
+	return strings.TrimRight(result.String(), "\n") + "\n"
+}
+
+// jiraStatistics describes the Jira issues by project, status and label
+func jiraStatistics(req SummaryRequest) string {
+	if len(req.Issues) == 0 {
+		return fmt.Sprintf("No Jira issues found from %s to %s.\n", req.StartDate, req.EndDate)
+	}
+
+	projects := make(map[string]int)
+	statuses := make(map[string]int)
+	labels := make(map[string]int)
+	resolved := 0
+	for _, issue := range req.Issues {
+		projects[jira.ProjectFromKey(issue.Key)]++
+		statuses[issue.Status.Name]++
+		for _, label := range jira.GroupByLabel.Groups(issue) {
+			labels[label]++
+		}
+		if issue.Resolved != "" {
+			resolved++
+		}
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d issues across %d projects from %s to %s, %d resolved.\n\n",
+		len(req.Issues), len(projects), req.StartDate, req.EndDate, resolved)
+	fmt.Fprintf(&builder, "- By project: %s\n", countsByFrequency(projects))
+	fmt.Fprintf(&builder, "- By status: %s\n", countsByFrequency(statuses))
+	fmt.Fprintf(&builder, "- By label: %s\n", countsByFrequency(labels))
+	return builder.String()
+}
+
+// githubStatistics describes the PRs by status and repository, their cycle
+// times and the largest PRs
+func githubStatistics(req SummaryRequest) string {
+	prs := github.UniquePullRequests(req.GitHubContext)
+	if len(prs) == 0 {
+		return fmt.Sprintf("No GitHub pull requests found from %s to %s.\n", req.StartDate, req.EndDate)
+	}
+
+	statuses := make(map[string]int)
+	repos := make(map[string]int)
+	var cycles []time.Duration
+	for _, pr := range prs {
+		statuses[pr.Status()]++
+		repos[pr.RepoName()]++
+		if cycle, ok := pr.CycleTime(); ok {
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d pull requests across %d repositories from %s to %s: %d merged, %d open, %d closed without merging.\n\n",
+		len(prs), len(repos), req.StartDate, req.EndDate, statuses["merged"], statuses["open"], statuses["closed-unmerged"])
+	fmt.Fprintf(&builder, "- By repository: %s\n", countsByFrequency(repos))
+	if len(cycles) > 0 {
+		fmt.Fprintf(&builder, "- Cycle time (opened to merged, %d PRs): median %s, average %s\n",
+			len(cycles), formatDays(medianDuration(cycles)), formatDays(averageDuration(cycles)))
+	}
+
+	if largest := largestPullRequests(prs, statisticalTopPRs); len(largest) > 0 {
+		fmt.Fprintf(&builder, "\nLargest pull requests:\n")
+		for _, pr := range largest {
+			stats, _ := pr.Stats()
+			fmt.Fprintf(&builder, "- %s#%d %s (%s, +%d/-%d in %d files) %s\n",
+				pr.RepoName(), pr.Key.Number, prTitle(pr), stats.Size(), stats.Additions, stats.Deletions, stats.ChangedFiles, pr.URL())
+		}
+	}
+	return builder.String()
+}
+
+// largestPullRequests returns up to n PRs with size data, most lines changed first
+func largestPullRequests(prs []github.PRRecord, n int) []github.PRRecord {
+	var sized []github.PRRecord
+	for _, pr := range prs {
+		if _, ok := pr.Stats(); ok {
+			sized = append(sized, pr)
+		}
+	}
+	lines := func(pr github.PRRecord) int {
+		stats, _ := pr.Stats()
+		return stats.Additions + stats.Deletions
+	}
+	sort.SliceStable(sized, func(i, j int) bool { return lines(sized[i]) > lines(sized[j]) })
+	return sized[:min(n, len(sized))]
+}
+
+// countsByFrequency formats counts most common first, ties by name, e.g.
+// "CNF 8, OCPBUGS 2", or "none" if there are no counts
+func countsByFrequency(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	names := sortedKeys(counts)
+	sort.SliceStable(names, func(i, j int) bool { return counts[names[i]] > counts[names[j]] })
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %d", name, counts[name]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// medianDuration returns the median of durations, which must not be empty
+func medianDuration(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// averageDuration returns the mean of durations, which must not be empty
+func averageDuration(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
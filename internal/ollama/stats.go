@@ -0,0 +1,46 @@
+package ollama
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats counts a client's generate requests and how long they took
+type Stats struct {
+	Requests     int64
+	Failures     int64
+	TotalLatency time.Duration
+}
+
+// AverageLatency returns the mean generate latency, or 0 without requests
+func (s Stats) AverageLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Requests)
+}
+
+// counters accumulates Stats across concurrent calls
+type counters struct {
+	requests atomic.Int64
+	failures atomic.Int64
+	latency  atomic.Int64 // Nanoseconds
+}
+
+// record counts one generate request
+func (c *counters) record(latency time.Duration, err error) {
+	c.requests.Add(1)
+	c.latency.Add(int64(latency))
+	if err != nil {
+		c.failures.Add(1)
+	}
+}
+
+// Stats returns the generate requests made by the client so far
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:     c.counters.requests.Load(),
+		Failures:     c.counters.failures.Load(),
+		TotalLatency: time.Duration(c.counters.latency.Load()),
+	}
+}
@@ -0,0 +1,77 @@
+package output
+
+import "strings"
+
+// DefaultAccomplishmentCategories are the categories accomplishments are
+// tagged with unless highlight.accomplishment_categories is set
+var DefaultAccomplishmentCategories = []string{"Feature", "Bug Fix", "Performance", "Documentation", "Infrastructure"}
+
+// UncategorizedAccomplishments is the group of accomplishments whose category
+// isn't one of the configured categories
+const UncategorizedAccomplishments = "Other"
+
+// Accomplishment is one of the top accomplishments of --list, with the
+// category the model tagged it with ("" if it wasn't tagged)
+type Accomplishment struct {
+	Text     string `json:"text"`
+	Category string `json:"category"`
+}
+
+// AccomplishmentGroup is the accomplishments of one category, with each
+// accomplishment's 1-based position in the overall priority order
+type AccomplishmentGroup struct {
+	Category        string
+	Accomplishments []Accomplishment
+	Ranks           []int
+}
+
+// MatchCategory returns the category of categories that name matches,
+// ignoring case and surrounding space, or UncategorizedAccomplishments
+func MatchCategory(name string, categories []string) string {
+	name = strings.TrimSpace(name)
+	for _, category := range categories {
+		if strings.EqualFold(name, category) {
+			return category
+		}
+	}
+	return UncategorizedAccomplishments
+}
+
+// GroupAccomplishments groups accomplishments by category, in the order of
+// categories, with uncategorized ones last. Within a group the priority order
+// is kept. It returns nil if no accomplishment has a category, so untagged
+// lists are shown as a plain numbered list.
+func GroupAccomplishments(accomplishments []Accomplishment, categories []string) []AccomplishmentGroup {
+	tagged := false
+	for _, acc := range accomplishments {
+		if acc.Category != "" {
+			tagged = true
+			break
+		}
+	}
+	if !tagged {
+		return nil
+	}
+
+	order := append(append([]string(nil), categories...), UncategorizedAccomplishments)
+	byCategory := make(map[string]*AccomplishmentGroup, len(order))
+	for i, acc := range accomplishments {
+		category := MatchCategory(acc.Category, categories)
+		group, ok := byCategory[category]
+		if !ok {
+			group = &AccomplishmentGroup{Category: category}
+			byCategory[category] = group
+		}
+		group.Accomplishments = append(group.Accomplishments, acc)
+		group.Ranks = append(group.Ranks, i+1)
+	}
+
+	var groups []AccomplishmentGroup
+	for _, category := range order {
+		if group, ok := byCategory[category]; ok {
+			groups = append(groups, *group)
+			delete(byCategory, category)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,193 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+)
+
+// RecordJSON identifies one pull request or Jira issue in the highlight JSON
+// output. Key is owner/repo#number for PRs and the issue key for Jira, so a
+// later run can match records against a saved export (see --baseline).
+type RecordJSON struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Baseline is a previously exported highlight --output json document
+type Baseline struct {
+	Path         string
+	PullRequests map[string]RecordJSON
+	Issues       map[string]RecordJSON
+}
+
+// LoadBaseline reads a highlight exported with --output json. Exports from
+// before records were included are rejected, since nothing could be matched.
+func LoadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var doc struct {
+		PullRequests *[]RecordJSON `json:"pullRequests"`
+		Issues       *[]RecordJSON `json:"issues"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("baseline %s is not a highlight --output json file: %w", path, err)
+	}
+	if doc.PullRequests == nil || doc.Issues == nil {
+		return nil, fmt.Errorf("baseline %s has no pullRequests/issues records; re-export it with this version's highlight --output json", path)
+	}
+
+	baseline := &Baseline{Path: path, PullRequests: map[string]RecordJSON{}, Issues: map[string]RecordJSON{}}
+	for _, pr := range *doc.PullRequests {
+		baseline.PullRequests[pr.Key] = pr
+	}
+	for _, issue := range *doc.Issues {
+		baseline.Issues[issue.Key] = issue
+	}
+	return baseline, nil
+}
+
+// BaselineDiff lists what is new in a highlight compared with a baseline
+type BaselineDiff struct {
+	Path               string       `json:"baseline"`
+	NewPullRequests    []RecordJSON `json:"newPullRequests"`
+	MergedPullRequests []RecordJSON `json:"mergedPullRequests"`
+	NewIssues          []RecordJSON `json:"newIssues"`
+	ResolvedIssues     []RecordJSON `json:"resolvedIssues"`
+}
+
+// Diff compares data with the baseline: PRs and issues not in the baseline
+// are new, and PRs merged or issues resolved since the baseline are listed
+// separately. Records only in the baseline are not reported.
+func (b *Baseline) Diff(data HighlightData) BaselineDiff {
+	diff := BaselineDiff{
+		Path:               b.Path,
+		NewPullRequests:    []RecordJSON{},
+		MergedPullRequests: []RecordJSON{},
+		NewIssues:          []RecordJSON{},
+		ResolvedIssues:     []RecordJSON{},
+	}
+	for _, pr := range pullRequestRecords(data) {
+		old, seen := b.PullRequests[pr.Key]
+		switch {
+		case !seen:
+			diff.NewPullRequests = append(diff.NewPullRequests, pr)
+		case pr.Status == "merged" && old.Status != "merged":
+			diff.MergedPullRequests = append(diff.MergedPullRequests, pr)
+		}
+	}
+	for _, issue := range issueRecords(data) {
+		old, seen := b.Issues[issue.Key]
+		switch {
+		case !seen:
+			diff.NewIssues = append(diff.NewIssues, issue)
+		case issue.Status == "resolved" && old.Status != "resolved":
+			diff.ResolvedIssues = append(diff.ResolvedIssues, issue)
+		}
+	}
+	return diff
+}
+
+// Empty reports whether nothing is new since the baseline
+func (d BaselineDiff) Empty() bool {
+	return len(d.NewPullRequests)+len(d.MergedPullRequests)+len(d.NewIssues)+len(d.ResolvedIssues) == 0
+}
+
+// pullRequestRecords returns the identifying record of each pull request
+func pullRequestRecords(data HighlightData) []RecordJSON {
+	records := []RecordJSON{}
+	for _, pr := range data.PullRequests {
+		records = append(records, RecordJSON{Key: pullRequestKey(pr), Title: pr.Title, Status: pr.Status(), URL: pr.HTMLURL})
+	}
+	return records
+}
+
+// issueRecords returns the identifying record of each Jira issue; the status
+// is "resolved" once the issue has a resolution date, "open" otherwise
+func issueRecords(data HighlightData) []RecordJSON {
+	records := []RecordJSON{}
+	for _, issue := range data.Issues {
+		record := RecordJSON{Key: issue.Key, Title: issue.Summary, Status: "open", URL: JiraIssueURL(data.JiraURL, issue.Key)}
+		if issue.Resolved != "" {
+			record.Status = "resolved"
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// sections pairs each list of the diff with its heading
+func (d BaselineDiff) sections() []struct {
+	heading string
+	records []RecordJSON
+} {
+	return []struct {
+		heading string
+		records []RecordJSON
+	}{
+		{"New PRs", d.NewPullRequests},
+		{"Merged since baseline", d.MergedPullRequests},
+		{"New Jira issues", d.NewIssues},
+		{"Resolved since baseline", d.ResolvedIssues},
+	}
+}
+
+func formatBaselineText(sb *strings.Builder, d *BaselineDiff) {
+	if d.Empty() {
+		fmt.Fprintf(sb, "- Nothing new since baseline %s\n", d.Path)
+		return
+	}
+	fmt.Fprintf(sb, "- Since baseline %s: %d new PRs, %d merged, %d new Jira issues, %d resolved\n",
+		d.Path, len(d.NewPullRequests), len(d.MergedPullRequests), len(d.NewIssues), len(d.ResolvedIssues))
+	for _, section := range d.sections() {
+		for _, record := range section.records {
+			fmt.Fprintf(sb, "  - %s: %s %s\n", section.heading, record.Key, record.Title)
+		}
+	}
+}
+
+func formatBaselineMarkdown(sb *strings.Builder, d *BaselineDiff) {
+	sb.WriteString("## Since Baseline\n\n")
+	if d.Empty() {
+		fmt.Fprintf(sb, "Nothing new since `%s`.\n\n", d.Path)
+		return
+	}
+	for _, section := range d.sections() {
+		if len(section.records) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "**%s**\n\n", section.heading)
+		for _, record := range section.records {
+			if record.URL != "" {
+				fmt.Fprintf(sb, "- [%s](%s) %s\n", record.Key, record.URL, escapeMarkdown(record.Title))
+			} else {
+				fmt.Fprintf(sb, "- %s %s\n", record.Key, escapeMarkdown(record.Title))
+			}
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func formatBaselineHTML(sb *strings.Builder, d *BaselineDiff) {
+	sb.WriteString("  <h2>Since Baseline</h2>\n")
+	if d.Empty() {
+		fmt.Fprintf(sb, "  <p class=\"period\">Nothing new since %s</p>\n", html.EscapeString(d.Path))
+		return
+	}
+	for _, section := range d.sections() {
+		if len(section.records) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "  <h3>%s</h3>\n  <ul>\n", section.heading)
+		for _, record := range section.records {
+			fmt.Fprintf(sb, "    <li><a href=\"%s\">%s</a> %s</li>\n", html.EscapeString(record.URL), html.EscapeString(record.Key), html.EscapeString(record.Title))
+		}
+		sb.WriteString("  </ul>\n")
+	}
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+)
+
+// Coverage describes how complete the data behind a summary is: how many
+// referenced PRs were fully enhanced, whether diffs were truncated, which
+// caps cut the data and whether the user's GitHub activity was available
+type Coverage struct {
+	PRsReferenced    int  `json:"prs_referenced"`
+	PRsEnhanced      int  `json:"prs_enhanced"`
+	PRsBudgetLimited int  `json:"prs_budget_limited"` // Fetched without reviews, files or diff (--repo-request-budget)
+	DiffsTruncated   int  `json:"diffs_truncated"`
+	GitHubActivity   bool `json:"github_activity"`
+
+	// Each cap records the items kept and found; kept == found when the
+	// cap wasn't hit
+	Issues     CoverageCap `json:"issues"`
+	PRs        CoverageCap `json:"prs"`
+	References CoverageCap `json:"references"`
+}
+
+// CoverageCap is the number of items kept under a cap out of those found
+type CoverageCap struct {
+	Kept  int `json:"kept"`
+	Found int `json:"found"`
+}
+
+// Hit reports whether the cap dropped any items
+func (c CoverageCap) Hit() bool {
+	return c.Kept < c.Found
+}
+
+// NewCoverage measures the referenced PRs and GitHub activity of ctx. Caps
+// are filled in by the caller, which applied them.
+func NewCoverage(ctx *github.GitHubContext) Coverage {
+	var coverage Coverage
+	if ctx == nil {
+		return coverage
+	}
+	for _, ref := range ctx.References {
+		if ref.Type == "pull" {
+			coverage.PRsReferenced++
+		}
+	}
+	for _, pr := range ctx.PullRequests {
+		switch {
+		case pr.BudgetLimited:
+			coverage.PRsBudgetLimited++
+		case len(pr.FetchWarnings) == 0:
+			coverage.PRsEnhanced++
+		}
+		if pr.DiffTruncated() {
+			coverage.DiffsTruncated++
+		}
+	}
+	coverage.GitHubActivity = ctx.ComprehensiveActivity != nil || ctx.GitHubUsername != ""
+	return coverage
+}
+
+// String formats the coverage as one line, e.g. "Coverage: PRs 18/20
+// enhanced, 2 basic only (--repo-request-budget), diffs truncated, GitHub
+// activity: unavailable"
+func (c Coverage) String() string {
+	var parts []string
+	if c.PRsReferenced > 0 {
+		parts = append(parts, fmt.Sprintf("PRs %d/%d enhanced", c.PRsEnhanced, c.PRsReferenced))
+	}
+	if c.PRsBudgetLimited > 0 {
+		parts = append(parts, fmt.Sprintf("%d basic only (--repo-request-budget)", c.PRsBudgetLimited))
+	}
+	switch {
+	case c.DiffsTruncated == 1:
+		parts = append(parts, "1 diff truncated")
+	case c.DiffsTruncated > 1:
+		parts = append(parts, fmt.Sprintf("%d diffs truncated", c.DiffsTruncated))
+	}
+	for _, limit := range []struct {
+		name   string
+		counts CoverageCap
+	}{
+		{name: "Jira issues", counts: c.Issues},
+		{name: "PRs", counts: c.PRs},
+		{name: "references", counts: c.References},
+	} {
+		if limit.counts.Hit() {
+			parts = append(parts, fmt.Sprintf("%s capped at %d of %d", limit.name, limit.counts.Kept, limit.counts.Found))
+		}
+	}
+	activity := "available"
+	if !c.GitHubActivity {
+		activity = "unavailable"
+	}
+	parts = append(parts, "GitHub activity: "+activity)
+	return "Coverage: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultFrontMatterTitle is the front matter title template used when
+// highlight.frontmatter_title isn't configured
+const DefaultFrontMatterTitle = "Highlights: {{.Name}}, {{.Start}} to {{.End}}"
+
+// FrontMatter is the YAML front matter prepended to markdown highlights
+// (--markdown-frontmatter), so they can be published by Hugo, Jekyll or Zola
+// as is
+type FrontMatter struct {
+	title *template.Template
+	tags  []string
+}
+
+// frontMatterFields are the values available to the title template
+type frontMatterFields struct {
+	Name  string // Display name, or the email without one
+	Email string
+	Start string // e.g. January 6, 2025
+	End   string
+	Days  int
+}
+
+// NewFrontMatter parses the title template, a text/template over .Name,
+// .Email, .Start, .End and .Days; an empty title uses DefaultFrontMatterTitle
+func NewFrontMatter(title string, tags []string) (*FrontMatter, error) {
+	if strings.TrimSpace(title) == "" {
+		title = DefaultFrontMatterTitle
+	}
+	tmpl, err := template.New("title").Option("missingkey=error").Parse(title)
+	if err != nil {
+		return nil, fmt.Errorf("invalid front matter title template: %w", err)
+	}
+	return &FrontMatter{title: tmpl, tags: tags}, nil
+}
+
+// render returns the front matter block for the highlight, dated by its end date
+func (f *FrontMatter) render(data HighlightData) (string, error) {
+	name := data.DisplayName
+	if name == "" {
+		name = data.Email
+	}
+	var title strings.Builder
+	fields := frontMatterFields{
+		Name:  name,
+		Email: data.Email,
+		Start: data.StartDate.Format("January 2, 2006"),
+		End:   data.EndDate.Format("January 2, 2006"),
+		Days:  data.Days,
+	}
+	if err := f.title.Execute(&title, fields); err != nil {
+		return "", fmt.Errorf("failed to render front matter title: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "title: %s\n", yamlString(title.String()))
+	fmt.Fprintf(&sb, "date: %s\n", data.EndDate.Format("2006-01-02"))
+	quoted := make([]string, 0, len(f.tags))
+	for _, tag := range f.tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			quoted = append(quoted, yamlString(tag))
+		}
+	}
+	fmt.Fprintf(&sb, "tags: [%s]\n", strings.Join(quoted, ", "))
+	sb.WriteString("---\n\n")
+	return sb.String(), nil
+}
+
+// yamlString quotes s as a YAML scalar; JSON strings are valid YAML
+func yamlString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,177 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+)
+
+// LeaderboardDisclaimer is printed with every leaderboard
+const LeaderboardDisclaimer = "This is a rough activity proxy based on countable events, not a performance judgment."
+
+// LeaderboardWeights are the per-event weights of the composite activity score
+type LeaderboardWeights struct {
+	PRMerged     float64
+	PROpened     float64
+	JiraResolved float64
+	Review       float64
+}
+
+// DefaultLeaderboardWeights weight merged PRs above opened ones
+var DefaultLeaderboardWeights = LeaderboardWeights{
+	PRMerged:     3,
+	PROpened:     1,
+	JiraResolved: 2,
+	Review:       1,
+}
+
+// Formula describes how the score is computed
+func (w LeaderboardWeights) Formula() string {
+	return color.Symbols(fmt.Sprintf("score = %g×PRs merged + %g×PRs opened + %g×Jira resolved + %g×reviews",
+		w.PRMerged, w.PROpened, w.JiraResolved, w.Review))
+}
+
+// Score computes the composite activity score for an entry
+func (w LeaderboardWeights) Score(e LeaderboardEntry) float64 {
+	return w.PRMerged*float64(e.PRsMerged) +
+		w.PROpened*float64(e.PRsOpened) +
+		w.JiraResolved*float64(e.JiraResolved) +
+		w.Review*float64(e.Reviews)
+}
+
+// LeaderboardEntry is one engineer's activity counts and score
+type LeaderboardEntry struct {
+	Rank         int
+	Email        string
+	PRsOpened    int
+	PRsMerged    int
+	JiraResolved int
+	Reviews      int
+	Score        float64
+}
+
+// LeaderboardData contains data for leaderboard output
+type LeaderboardData struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Weights   LeaderboardWeights
+	Entries   []LeaderboardEntry
+}
+
+// RankLeaderboard scores every entry and sorts by descending score (ties by email).
+// Tied scores share a rank.
+func RankLeaderboard(entries []LeaderboardEntry, weights LeaderboardWeights) []LeaderboardEntry {
+	ranked := make([]LeaderboardEntry, len(entries))
+	copy(ranked, entries)
+	for i := range ranked {
+		ranked[i].Score = weights.Score(ranked[i])
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Email < ranked[j].Email
+	})
+
+	for i := range ranked {
+		if i > 0 && ranked[i].Score == ranked[i-1].Score {
+			ranked[i].Rank = ranked[i-1].Rank
+		} else {
+			ranked[i].Rank = i + 1
+		}
+	}
+	return ranked
+}
+
+// FormatLeaderboard formats leaderboard data according to the specified format
+func FormatLeaderboard(data LeaderboardData, format Format) (string, error) {
+	switch format {
+	case FormatText:
+		return formatLeaderboardText(data), nil
+	case FormatMarkdown:
+		return formatLeaderboardMarkdown(data), nil
+	case FormatHTML:
+		return formatLeaderboardHTML(data), nil
+	default:
+		return "", fmt.Errorf("format '%s' is not supported for leaderboards: use text, markdown, or html", format)
+	}
+}
+
+func formatLeaderboardText(data LeaderboardData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Activity leaderboard: %s to %s\n",
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"))
+	fmt.Fprintf(&sb, "Note: %s\n", LeaderboardDisclaimer)
+	fmt.Fprintf(&sb, "%s\n\n", data.Weights.Formula())
+
+	fmt.Fprintf(&sb, "%-4s  %-32s  %6s  %6s  %6s  %7s  %7s\n", "Rank", "Engineer", "Merged", "Opened", "Jira", "Reviews", "Score")
+	sb.WriteString(strings.Repeat("-", 82) + "\n")
+	for _, e := range data.Entries {
+		fmt.Fprintf(&sb, "%-4d  %-32s  %6d  %6d  %6d  %7d  %7.1f\n",
+			e.Rank, e.Email, e.PRsMerged, e.PRsOpened, e.JiraResolved, e.Reviews, e.Score)
+	}
+
+	return sb.String()
+}
+
+func formatLeaderboardMarkdown(data LeaderboardData) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Activity Leaderboard\n\n")
+	fmt.Fprintf(&sb, "**Period:** %s to %s\n\n",
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"))
+	fmt.Fprintf(&sb, "> **Note:** %s\n>\n> `%s`\n\n", LeaderboardDisclaimer, data.Weights.Formula())
+
+	sb.WriteString("| Rank | Engineer | PRs Merged | PRs Opened | Jira Resolved | Reviews | Score |\n")
+	sb.WriteString("|------|----------|------------|------------|---------------|---------|-------|\n")
+	for _, e := range data.Entries {
+		fmt.Fprintf(&sb, "| %d | %s | %d | %d | %d | %d | %.1f |\n",
+			e.Rank, escapeMarkdown(e.Email), e.PRsMerged, e.PRsOpened, e.JiraResolved, e.Reviews, e.Score)
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func formatLeaderboardHTML(data LeaderboardData) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n")
+	sb.WriteString("  <meta charset=\"UTF-8\">\n")
+	sb.WriteString("  <title>Activity Leaderboard</title>\n")
+	sb.WriteString("  <style>\n")
+	sb.WriteString("    body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; max-width: 900px; margin: 40px auto; padding: 20px; }\n")
+	sb.WriteString("    h1 { color: #333; border-bottom: 2px solid #e74c3c; padding-bottom: 10px; }\n")
+	sb.WriteString("    table { border-collapse: collapse; width: 100%; margin: 20px 0; }\n")
+	sb.WriteString("    th, td { border: 1px solid #ddd; padding: 12px; text-align: left; }\n")
+	sb.WriteString("    th { background-color: #f4f4f4; font-weight: bold; }\n")
+	sb.WriteString("    tr:nth-child(even) { background-color: #f9f9f9; }\n")
+	sb.WriteString("    .note { background-color: #fff8e1; padding: 15px; border-radius: 5px; margin: 10px 0; }\n")
+	sb.WriteString("    .period { color: #888; font-size: 0.9em; }\n")
+	sb.WriteString("  </style>\n")
+	sb.WriteString("</head>\n<body>\n")
+
+	sb.WriteString("  <h1>Activity Leaderboard</h1>\n")
+	fmt.Fprintf(&sb, "  <p class=\"period\"><strong>Period:</strong> %s to %s</p>\n",
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"))
+	fmt.Fprintf(&sb, "  <div class=\"note\"><strong>Note:</strong> %s<br><code>%s</code></div>\n",
+		html.EscapeString(LeaderboardDisclaimer), html.EscapeString(data.Weights.Formula()))
+
+	sb.WriteString("  <table>\n")
+	sb.WriteString("    <tr><th>Rank</th><th>Engineer</th><th>PRs Merged</th><th>PRs Opened</th><th>Jira Resolved</th><th>Reviews</th><th>Score</th></tr>\n")
+	for _, e := range data.Entries {
+		fmt.Fprintf(&sb, "    <tr><td>%d</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%.1f</td></tr>\n",
+			e.Rank, html.EscapeString(e.Email), e.PRsMerged, e.PRsOpened, e.JiraResolved, e.Reviews, e.Score)
+	}
+	sb.WriteString("  </table>\n")
+
+	sb.WriteString("</body>\n</html>\n")
+
+	return sb.String()
+}
@@ -21,6 +21,7 @@ const (
 	FormatMarkdown Format = "markdown"
 	FormatHTML     Format = "html"
 	FormatCSV      Format = "csv"
+	FormatTabular  Format = "table" // Aligned terminal table; see FormatTable
 )
 
 // ParseFormat parses a format string into a Format type
@@ -36,8 +37,10 @@ func ParseFormat(s string) (Format, error) {
 		return FormatHTML, nil
 	case "csv":
 		return FormatCSV, nil
+	case "table":
+		return FormatTabular, nil
 	default:
-		return FormatText, fmt.Errorf("unknown format '%s': supported formats are text, json, markdown, html, csv", s)
+		return FormatText, fmt.Errorf("unknown format '%s': supported formats are text, json, markdown, html, csv, table", s)
 	}
 }
 
@@ -48,22 +51,46 @@ type HighlightData struct {
 	StartDate   time.Time
 	EndDate     time.Time
 	Days        int
+	JiraURL     string
 
 	// Stats
-	PRsCreated    int
-	PRsMerged     int
-	PRsOpen       int
-	JiraCreated   int
-	JiraUpdated   int
+	PRsCreated        int
+	PRsMerged         int
+	PRsOpen           int
+	PRsClosedUnmerged int
+	JiraCreated       int
+	JiraUpdated       int
+	JiraResolved      int
+	Reviews           int // PRs by others reviewed in the period (only collected for leaderboards)
+	Commits           int // Commits authored in the period (only collected with --commits)
+
+	// Whether GitHub activity was available for this period
+	GitHubAvailable bool
+	GitHubUsername  string
+	// GitHubSkipped explains why GitHub stats are missing, e.g. no token configured
+	GitHubSkipped string
 
 	// Accomplishments
-	Accomplishments []string
-	BiggestAccomplishment string
-	Why                   string
+	Accomplishments []Accomplishment
+	// AccomplishmentCategories orders the category groups of Accomplishments;
+	// DefaultAccomplishmentCategories when empty
+	AccomplishmentCategories []string
+	BiggestAccomplishment    string
+	Why                      string
+	ListCount                int
+	AccomplishmentError      string
+	// AISkipped explains why no AI summary was attempted, e.g. no Ollama URL configured
+	AISkipped string
 
 	// Raw data for detailed formats
 	PullRequests []github.UserPullRequest
 	Issues       []jira.Issue
+
+	// SinceBaseline is what is new compared with --baseline, if given
+	SinceBaseline *BaselineDiff
+
+	// FrontMatter, if set, is prepended to the markdown format (--markdown-frontmatter)
+	FrontMatter *FrontMatter
 }
 
 // FormatHighlight formats highlight data according to the specified format
@@ -74,11 +101,20 @@ func FormatHighlight(data HighlightData, format Format) (string, error) {
 	case FormatJSON:
 		return formatHighlightJSON(data)
 	case FormatMarkdown:
-		return formatHighlightMarkdown(data), nil
+		if data.FrontMatter == nil {
+			return formatHighlightMarkdown(data), nil
+		}
+		frontMatter, err := data.FrontMatter.render(data)
+		if err != nil {
+			return "", err
+		}
+		return frontMatter + formatHighlightMarkdown(data), nil
 	case FormatHTML:
 		return formatHighlightHTML(data), nil
 	case FormatCSV:
 		return formatHighlightCSV(data), nil
+	case FormatTabular:
+		return FormatTable(data, TerminalWidth()), nil
 	default:
 		return formatHighlightText(data), nil
 	}
@@ -88,26 +124,67 @@ func formatHighlightText(data HighlightData) string {
 	var sb strings.Builder
 
 	sb.WriteString("\n")
-	if data.PRsCreated > 0 {
-		fmt.Fprintf(&sb, "- Created %d PRs in the last %d days (%d merged, %d open)\n",
-			data.PRsCreated, data.Days, data.PRsMerged, data.PRsOpen)
+	if data.GitHubAvailable || data.PRsCreated > 0 {
+		fmt.Fprintf(&sb, "- Created %d PRs in the last %d days (%d merged, %d closed-unmerged, %d open)\n",
+			data.PRsCreated, data.Days, data.PRsMerged, data.PRsClosedUnmerged, data.PRsOpen)
+	} else if data.GitHubSkipped != "" {
+		fmt.Fprintf(&sb, "- GitHub stats skipped: %s\n", data.GitHubSkipped)
+	}
+	if data.Commits > 0 {
+		fmt.Fprintf(&sb, "- Authored %d commits in the last %d days\n", data.Commits, data.Days)
 	}
 	fmt.Fprintf(&sb, "- Created %d Jira stories and updated Jira %d times\n",
 		data.JiraCreated, data.JiraUpdated)
 
-	if len(data.Accomplishments) > 0 {
-		fmt.Fprintf(&sb, "- Top %d accomplishments:\n", len(data.Accomplishments))
-		for i, acc := range data.Accomplishments {
-			fmt.Fprintf(&sb, "  %d. %s\n", i+1, acc)
+	switch {
+	case data.AccomplishmentError != "" && data.ListCount > 0:
+		fmt.Fprintf(&sb, "- Top %d accomplishments: (Unable to generate: %s)\n", data.ListCount, data.AccomplishmentError)
+	case data.AccomplishmentError != "":
+		fmt.Fprintf(&sb, "- Biggest accomplishment: (Unable to generate: %s)\n", data.AccomplishmentError)
+	case len(data.Accomplishments) > 0:
+		count := data.ListCount
+		if count == 0 {
+			count = len(data.Accomplishments)
 		}
-	} else if data.BiggestAccomplishment != "" {
+		fmt.Fprintf(&sb, "- Top %d accomplishments:\n", count)
+		groups := data.accomplishmentGroups()
+		if groups == nil {
+			for i, acc := range data.Accomplishments {
+				fmt.Fprintf(&sb, "  %d. %s\n", i+1, acc.Text)
+			}
+		}
+		for _, group := range groups {
+			fmt.Fprintf(&sb, "  %s:\n", group.Category)
+			for i, acc := range group.Accomplishments {
+				fmt.Fprintf(&sb, "    %d. %s\n", group.Ranks[i], acc.Text)
+			}
+		}
+	case data.BiggestAccomplishment != "":
 		fmt.Fprintf(&sb, "- Biggest accomplishment: %s\n", data.BiggestAccomplishment)
+		if data.Why != "" {
+			fmt.Fprintf(&sb, "  - Why: %s\n", data.Why)
+		}
+	case data.AISkipped != "":
+		fmt.Fprintf(&sb, "- AI summary skipped: %s\n", data.AISkipped)
+	}
+	if data.SinceBaseline != nil {
+		formatBaselineText(&sb, data.SinceBaseline)
 	}
 	sb.WriteString("\n")
 
 	return sb.String()
 }
 
+// accomplishmentGroups groups the accomplishments by category, or returns
+// nil if they are untagged
+func (data HighlightData) accomplishmentGroups() []AccomplishmentGroup {
+	categories := data.AccomplishmentCategories
+	if len(categories) == 0 {
+		categories = DefaultAccomplishmentCategories
+	}
+	return GroupAccomplishments(data.Accomplishments, categories)
+}
+
 func formatHighlightJSON(data HighlightData) (string, error) {
 	jsonData := map[string]interface{}{
 		"email":       data.Email,
@@ -116,15 +193,31 @@ func formatHighlightJSON(data HighlightData) (string, error) {
 		"endDate":     data.EndDate.Format("2006-01-02"),
 		"days":        data.Days,
 		"stats": map[string]int{
-			"prsCreated":  data.PRsCreated,
-			"prsMerged":   data.PRsMerged,
-			"prsOpen":     data.PRsOpen,
-			"jiraCreated": data.JiraCreated,
-			"jiraUpdated": data.JiraUpdated,
+			"prsCreated":        data.PRsCreated,
+			"prsMerged":         data.PRsMerged,
+			"prsOpen":           data.PRsOpen,
+			"prsClosedUnmerged": data.PRsClosedUnmerged,
+			"jiraCreated":       data.JiraCreated,
+			"jiraUpdated":       data.JiraUpdated,
+			"commits":           data.Commits,
 		},
 		"accomplishments":       data.Accomplishments,
 		"biggestAccomplishment": data.BiggestAccomplishment,
 		"why":                   data.Why,
+		"pullRequests":          pullRequestRecords(data),
+		"issues":                issueRecords(data),
+	}
+	if data.SinceBaseline != nil {
+		jsonData["sinceBaseline"] = data.SinceBaseline
+	}
+	if data.AccomplishmentError != "" {
+		jsonData["accomplishmentError"] = data.AccomplishmentError
+	}
+	if data.GitHubSkipped != "" {
+		jsonData["githubSkipped"] = data.GitHubSkipped
+	}
+	if data.AISkipped != "" {
+		jsonData["aiSkipped"] = data.AISkipped
 	}
 
 	bytes, err := json.MarshalIndent(jsonData, "", "  ")
@@ -137,9 +230,9 @@ func formatHighlightJSON(data HighlightData) (string, error) {
 func formatHighlightMarkdown(data HighlightData) string {
 	var sb strings.Builder
 
-	name := data.DisplayName
+	name := escapeMarkdown(data.DisplayName)
 	if name == "" {
-		name = data.Email
+		name = escapeMarkdown(data.Email)
 	}
 
 	fmt.Fprintf(&sb, "# Activity Summary: %s\n\n", name)
@@ -153,28 +246,65 @@ func formatHighlightMarkdown(data HighlightData) string {
 	sb.WriteString("|--------|-------|\n")
 	fmt.Fprintf(&sb, "| Pull Requests Created | %d |\n", data.PRsCreated)
 	fmt.Fprintf(&sb, "| PRs Merged | %d |\n", data.PRsMerged)
+	fmt.Fprintf(&sb, "| PRs Closed Unmerged | %d |\n", data.PRsClosedUnmerged)
 	fmt.Fprintf(&sb, "| PRs Open | %d |\n", data.PRsOpen)
+	if data.Commits > 0 {
+		fmt.Fprintf(&sb, "| Commits Authored | %d |\n", data.Commits)
+	}
 	fmt.Fprintf(&sb, "| Jira Issues Created | %d |\n", data.JiraCreated)
 	fmt.Fprintf(&sb, "| Jira Issues Updated | %d |\n", data.JiraUpdated)
 	sb.WriteString("\n")
+	if !data.GitHubAvailable && data.GitHubSkipped != "" {
+		fmt.Fprintf(&sb, "> GitHub stats skipped: %s\n\n", escapeMarkdown(data.GitHubSkipped))
+	}
 
 	if len(data.Accomplishments) > 0 {
 		sb.WriteString("## Top Accomplishments\n\n")
-		for i, acc := range data.Accomplishments {
-			fmt.Fprintf(&sb, "%d. %s\n", i+1, acc)
+		groups := data.accomplishmentGroups()
+		if groups == nil {
+			for i, acc := range data.Accomplishments {
+				fmt.Fprintf(&sb, "%d. %s\n", i+1, escapeMarkdown(acc.Text))
+			}
+			sb.WriteString("\n")
+		}
+		for _, group := range groups {
+			fmt.Fprintf(&sb, "### %s\n\n", escapeMarkdown(group.Category))
+			for i, acc := range group.Accomplishments {
+				fmt.Fprintf(&sb, "%d. %s\n", group.Ranks[i], escapeMarkdown(acc.Text))
+			}
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\n")
 	} else if data.BiggestAccomplishment != "" {
 		sb.WriteString("## Biggest Accomplishment\n\n")
-		fmt.Fprintf(&sb, "**%s**\n\n", data.BiggestAccomplishment)
+		fmt.Fprintf(&sb, "**%s**\n\n", escapeMarkdown(data.BiggestAccomplishment))
 		if data.Why != "" {
-			fmt.Fprintf(&sb, "*%s*\n\n", data.Why)
+			fmt.Fprintf(&sb, "*%s*\n\n", escapeMarkdown(data.Why))
 		}
+	} else if data.AISkipped != "" {
+		fmt.Fprintf(&sb, "> AI summary skipped: %s\n\n", escapeMarkdown(data.AISkipped))
+	}
+	if data.SinceBaseline != nil {
+		formatBaselineMarkdown(&sb, data.SinceBaseline)
 	}
 
 	return sb.String()
 }
 
+// markdownEscaper backslash-escapes characters that markdown would treat as
+// formatting, links, HTML or table cell separators
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "#", `\#`, "|", `\|`,
+	"[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "~", `\~`,
+	"\r\n", " ", "\n", " ", "\r", " ",
+)
+
+// escapeMarkdown makes user or LLM-derived text safe to interpolate into
+// markdown, including table cells. Newlines are collapsed to spaces so the
+// text stays within its list item, emphasis or cell.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
 func formatHighlightHTML(data HighlightData) string {
 	var sb strings.Builder
 
@@ -213,18 +343,36 @@ func formatHighlightHTML(data HighlightData) string {
 	sb.WriteString("    <tr><th>Metric</th><th>Count</th></tr>\n")
 	fmt.Fprintf(&sb, "    <tr><td>Pull Requests Created</td><td>%d</td></tr>\n", data.PRsCreated)
 	fmt.Fprintf(&sb, "    <tr><td>PRs Merged</td><td>%d</td></tr>\n", data.PRsMerged)
+	fmt.Fprintf(&sb, "    <tr><td>PRs Closed Unmerged</td><td>%d</td></tr>\n", data.PRsClosedUnmerged)
 	fmt.Fprintf(&sb, "    <tr><td>PRs Open</td><td>%d</td></tr>\n", data.PRsOpen)
+	if data.Commits > 0 {
+		fmt.Fprintf(&sb, "    <tr><td>Commits Authored</td><td>%d</td></tr>\n", data.Commits)
+	}
 	fmt.Fprintf(&sb, "    <tr><td>Jira Issues Created</td><td>%d</td></tr>\n", data.JiraCreated)
 	fmt.Fprintf(&sb, "    <tr><td>Jira Issues Updated</td><td>%d</td></tr>\n", data.JiraUpdated)
 	sb.WriteString("  </table>\n")
+	if !data.GitHubAvailable && data.GitHubSkipped != "" {
+		fmt.Fprintf(&sb, "  <p class=\"period\">GitHub stats skipped: %s</p>\n", html.EscapeString(data.GitHubSkipped))
+	}
 
 	if len(data.Accomplishments) > 0 {
 		sb.WriteString("  <h2>Top Accomplishments</h2>\n")
-		sb.WriteString("  <ol>\n")
-		for _, acc := range data.Accomplishments {
-			fmt.Fprintf(&sb, "    <li>%s</li>\n", html.EscapeString(acc))
+		groups := data.accomplishmentGroups()
+		if groups == nil {
+			sb.WriteString("  <ol>\n")
+			for _, acc := range data.Accomplishments {
+				fmt.Fprintf(&sb, "    <li>%s</li>\n", html.EscapeString(acc.Text))
+			}
+			sb.WriteString("  </ol>\n")
+		}
+		for _, group := range groups {
+			fmt.Fprintf(&sb, "  <h3>%s</h3>\n", html.EscapeString(group.Category))
+			sb.WriteString("  <ol>\n")
+			for i, acc := range group.Accomplishments {
+				fmt.Fprintf(&sb, "    <li value=\"%d\">%s</li>\n", group.Ranks[i], html.EscapeString(acc.Text))
+			}
+			sb.WriteString("  </ol>\n")
 		}
-		sb.WriteString("  </ol>\n")
 	} else if data.BiggestAccomplishment != "" {
 		sb.WriteString("  <h2>Biggest Accomplishment</h2>\n")
 		sb.WriteString("  <div class=\"accomplishment\">\n")
@@ -233,6 +381,11 @@ func formatHighlightHTML(data HighlightData) string {
 			fmt.Fprintf(&sb, "    <p class=\"why\">%s</p>\n", html.EscapeString(data.Why))
 		}
 		sb.WriteString("  </div>\n")
+	} else if data.AISkipped != "" {
+		fmt.Fprintf(&sb, "  <p class=\"period\">AI summary skipped: %s</p>\n", html.EscapeString(data.AISkipped))
+	}
+	if data.SinceBaseline != nil {
+		formatBaselineHTML(&sb, data.SinceBaseline)
 	}
 
 	sb.WriteString("</body>\n</html>\n")
@@ -245,7 +398,7 @@ func formatHighlightCSV(data HighlightData) string {
 	w := csv.NewWriter(&sb)
 
 	// Write header
-	header := []string{"Email", "Name", "Start Date", "End Date", "Days", "PRs Created", "PRs Merged", "PRs Open", "Jira Created", "Jira Updated", "Biggest Accomplishment"}
+	header := []string{"Email", "Name", "Start Date", "End Date", "Days", "PRs Created", "PRs Merged", "PRs Closed Unmerged", "PRs Open", "Jira Created", "Jira Updated", "Biggest Accomplishment"}
 	_ = w.Write(header)
 
 	// Write data row
@@ -257,6 +410,7 @@ func formatHighlightCSV(data HighlightData) string {
 		fmt.Sprintf("%d", data.Days),
 		fmt.Sprintf("%d", data.PRsCreated),
 		fmt.Sprintf("%d", data.PRsMerged),
+		fmt.Sprintf("%d", data.PRsClosedUnmerged),
 		fmt.Sprintf("%d", data.PRsOpen),
 		fmt.Sprintf("%d", data.JiraCreated),
 		fmt.Sprintf("%d", data.JiraUpdated),
@@ -267,3 +421,92 @@ func formatHighlightCSV(data HighlightData) string {
 	w.Flush()
 	return sb.String()
 }
+
+// FormatHighlightCSVDetail formats one CSV row per Jira issue and per pull request
+func FormatHighlightCSVDetail(data HighlightData) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	_ = w.Write([]string{"record_type", "key", "title", "status", "type", "created", "updated", "url"})
+
+	for _, issue := range data.Issues {
+		_ = w.Write([]string{
+			"jira_issue",
+			issue.Key,
+			issue.Summary,
+			issue.Status.Name,
+			issue.IssueType.Name,
+			issue.Created,
+			issue.Updated,
+			JiraIssueURL(data.JiraURL, issue.Key),
+		})
+	}
+
+	for _, pr := range data.PullRequests {
+		_ = w.Write([]string{
+			"pull_request",
+			pullRequestKey(pr),
+			pr.Title,
+			pr.State,
+			"pull_request",
+			pr.CreatedAt,
+			pr.UpdatedAt,
+			pr.HTMLURL,
+		})
+	}
+
+	w.Flush()
+	return sb.String()
+}
+
+// JiraIssueURL returns the browse URL of a Jira issue, or "" without a Jira URL
+func JiraIssueURL(jiraURL, key string) string {
+	if jiraURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(jiraURL, "/"), key)
+}
+
+// FormatJournalDetail formats the PRs and Jira issues of a highlight as a
+// collapsible GitHub-flavored markdown <details> section for the journal
+func FormatJournalDetail(data HighlightData) string {
+	if len(data.PullRequests) == 0 && len(data.Issues) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<details>\n<summary>Activity: %d pull requests, %d Jira issues</summary>\n\n", len(data.PullRequests), len(data.Issues))
+	if len(data.PullRequests) > 0 {
+		sb.WriteString("**Pull requests**\n\n")
+		for _, pr := range data.PullRequests {
+			fmt.Fprintf(&sb, "- [%s](%s) %s (%s)\n", pullRequestKey(pr), pr.HTMLURL, escapeMarkdown(pr.Title), pr.Status())
+		}
+		sb.WriteString("\n")
+	}
+	if len(data.Issues) > 0 {
+		sb.WriteString("**Jira issues**\n\n")
+		for _, issue := range data.Issues {
+			title := escapeMarkdown(issue.Summary)
+			if issue.Status.Name != "" {
+				title += fmt.Sprintf(" (%s)", issue.Status.Name)
+			}
+			if url := JiraIssueURL(data.JiraURL, issue.Key); url != "" {
+				fmt.Fprintf(&sb, "- [%s](%s) %s\n", issue.Key, url, title)
+			} else {
+				fmt.Fprintf(&sb, "- %s %s\n", issue.Key, title)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</details>\n")
+	return sb.String()
+}
+
+// pullRequestKey returns an owner/repo#number identifier for a pull request
+func pullRequestKey(pr github.UserPullRequest) string {
+	parts := strings.Split(pr.RepositoryURL, "/")
+	if pr.RepositoryURL == "" || len(parts) < 2 {
+		return fmt.Sprintf("#%d", pr.Number)
+	}
+	return fmt.Sprintf("%s/%s#%d", parts[len(parts)-2], parts[len(parts)-1], pr.Number)
+}
@@ -0,0 +1,788 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
+)
+
+// update rewrites the golden files in testdata: go test ./internal/output -update
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenHighlights are the fixtures of TestFormatHighlightGolden: a --list
+// highlight with categorized accomplishments and a baseline, and a biggest
+// accomplishment highlight whose GitHub stats were skipped
+func goldenHighlights() map[string]HighlightData {
+	pr := func(number int, title, state, mergedAt, updated string) github.UserPullRequest {
+		return github.UserPullRequest{
+			Number: number, Title: title, State: state, UpdatedAt: updated,
+			HTMLURL:       fmt.Sprintf("https://github.com/acme/probe/pull/%d", number),
+			RepositoryURL: "https://api.github.com/repos/acme/probe",
+			PullRequest:   &github.PullRequestMeta{MergedAt: mergedAt},
+		}
+	}
+	list := HighlightData{
+		Email: "dev@example.com", DisplayName: "Dana Dev",
+		StartDate: time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, time.January, 12, 0, 0, 0, 0, time.UTC),
+		Days:      7, JiraURL: "https://issues.example.com",
+		PRsCreated: 3, PRsMerged: 1, PRsOpen: 1, PRsClosedUnmerged: 1,
+		JiraCreated: 2, JiraUpdated: 5, JiraResolved: 1, Commits: 4,
+		GitHubAvailable: true, GitHubUsername: "danadev",
+		Accomplishments: []Accomplishment{
+			{Text: "Cut probe latency by 40% with batched | pipelined requests", Category: "Performance"},
+			{Text: "Added the <probe> operator's upgrade path", Category: "Feature"},
+			{Text: "Fixed a crash on empty configs", Category: "bug fix"},
+			{Text: "Mentored two new contributors", Category: "Community"},
+		},
+		ListCount: 4,
+		PullRequests: []github.UserPullRequest{
+			pr(12, "Batch probe requests", "closed", "2025-01-08T10:00:00Z", "2025-01-08T10:00:00Z"),
+			pr(13, "Add upgrade path for the probe operator", "open", "", "2025-01-10T09:30:00Z"),
+			pr(14, "Try a new retry policy", "closed", "", "2025-01-09T16:00:00Z"),
+		},
+		Issues: []jira.Issue{
+			{Key: "CNF-101", Summary: "Probe latency regression", Status: jira.Status{Name: "Done"}, IssueType: jira.IssueType{Name: "Bug"},
+				Created: "2025-01-06T08:00:00Z", Updated: "2025-01-08T11:00:00Z", Resolved: "2025-01-08T11:00:00Z"},
+			{Key: "CNF-102", Summary: "Operator upgrade path", Status: jira.Status{Name: "In Progress"}, IssueType: jira.IssueType{Name: "Story"},
+				Created: "2025-01-07T08:00:00Z", Updated: "2025-01-10T12:00:00Z"},
+		},
+		SinceBaseline: &BaselineDiff{
+			Path:               "last-week.json",
+			NewPullRequests:    []RecordJSON{{Key: "acme/probe#13", Title: "Add upgrade path for the probe operator", Status: "open", URL: "https://github.com/acme/probe/pull/13"}},
+			MergedPullRequests: []RecordJSON{{Key: "acme/probe#12", Title: "Batch probe requests", Status: "merged", URL: "https://github.com/acme/probe/pull/12"}},
+			ResolvedIssues:     []RecordJSON{{Key: "CNF-101", Title: "Probe latency regression", Status: "resolved", URL: "https://issues.example.com/browse/CNF-101"}},
+		},
+	}
+	biggest := HighlightData{
+		Email:     "dev@example.com",
+		StartDate: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		Days:      28, JiraCreated: 1, JiraUpdated: 3,
+		GitHubSkipped:         "no GitHub token configured",
+		BiggestAccomplishment: "Shipped the *probe* dashboard",
+		Why:                   "It gave on-call engineers one view of probe health",
+		Issues: []jira.Issue{
+			{Key: "CNF-200", Summary: "Probe dashboard", Status: jira.Status{Name: "Closed"}, IssueType: jira.IssueType{Name: "Story"},
+				Created: "2025-02-03T08:00:00Z", Updated: "2025-02-20T12:00:00Z", Resolved: "2025-02-20T12:00:00Z"},
+		},
+	}
+	return map[string]HighlightData{"list": list, "biggest": biggest}
+}
+
+// TestFormatHighlightGolden compares every format of the fixtures with the
+// golden files in testdata; run with -update after an intended change
+func TestFormatHighlightGolden(t *testing.T) {
+	formats := []struct {
+		format Format
+		ext    string
+	}{
+		{FormatText, "txt"}, {FormatJSON, "json"}, {FormatMarkdown, "md"},
+		{FormatHTML, "html"}, {FormatCSV, "csv"}, {FormatTabular, "table.txt"},
+	}
+	for name, data := range goldenHighlights() {
+		for _, f := range formats {
+			t.Run(name+"/"+string(f.format), func(t *testing.T) {
+				var got string
+				if f.format == FormatTabular {
+					// FormatHighlight sizes the table to the terminal
+					got = FormatTable(data, defaultTableWidth)
+				} else {
+					var err error
+					if got, err = FormatHighlight(data, f.format); err != nil {
+						t.Fatalf("FormatHighlight() error = %v", err)
+					}
+				}
+
+				path := filepath.Join("testdata", "highlight_"+name+"."+f.ext)
+				if *update {
+					if err := os.MkdirAll("testdata", 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("reading golden file (run with -update to create it): %v", err)
+				}
+				if got != string(want) {
+					t.Errorf("output differs from %s (run with -update to accept it):\n--- got\n%s\n--- want\n%s", path, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestFormatHighlightCSVDetail(t *testing.T) {
+	data := HighlightData{
+		JiraURL: "https://issues.example.com/",
+		Issues: []jira.Issue{
+			{
+				Key:       "PROJ-1",
+				Summary:   `Fix "flaky" tests, again`,
+				Status:    jira.Status{Name: "Closed"},
+				IssueType: jira.IssueType{Name: "Bug"},
+				Created:   "2025-01-02T10:00:00.000+0000",
+				Updated:   "2025-01-03T10:00:00.000+0000",
+			},
+		},
+		PullRequests: []github.UserPullRequest{
+			{
+				Number:        42,
+				Title:         "Add feature A, B, and C",
+				State:         "open",
+				CreatedAt:     "2025-01-04T10:00:00Z",
+				UpdatedAt:     "2025-01-05T10:00:00Z",
+				HTMLURL:       "https://github.com/org/repo/pull/42",
+				RepositoryURL: "https://api.github.com/repos/org/repo",
+			},
+		},
+	}
+
+	records, err := csv.NewReader(strings.NewReader(FormatHighlightCSVDetail(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3 (header + issue + PR)", len(records))
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"header", records[0], []string{"record_type", "key", "title", "status", "type", "created", "updated", "url"}},
+		{"jira issue", records[1], []string{"jira_issue", "PROJ-1", `Fix "flaky" tests, again`, "Closed", "Bug", "2025-01-02T10:00:00.000+0000", "2025-01-03T10:00:00.000+0000", "https://issues.example.com/browse/PROJ-1"}},
+		{"pull request", records[2], []string{"pull_request", "org/repo#42", "Add feature A, B, and C", "open", "pull_request", "2025-01-04T10:00:00Z", "2025-01-05T10:00:00Z", "https://github.com/org/repo/pull/42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.Join(tt.got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatHighlightMarkdownEscaping(t *testing.T) {
+	data := HighlightData{
+		DisplayName: "jane_doe",
+		Accomplishments: []Accomplishment{
+			{Text: "Refactored A|B parser for *all* __init__ paths"},
+			{Text: "Fixed #123 in `cmd` [docs]\nand <script> ~~cleanup~~"},
+		},
+	}
+
+	got, err := FormatHighlight(data, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("FormatHighlight() error = %v", err)
+	}
+	for _, want := range []string{
+		"# Activity Summary: jane\\_doe\n",
+		"1. Refactored A\\|B parser for \\*all\\* \\_\\_init\\_\\_ paths\n",
+		"2. Fixed \\#123 in \\`cmd\\` \\[docs\\] and \\<script\\> \\~\\~cleanup\\~\\~\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+
+	data.Accomplishments = nil
+	data.BiggestAccomplishment = "Split the A|B pipeline"
+	data.Why = "It unblocks *partners*"
+	got, _ = FormatHighlight(data, FormatMarkdown)
+	if !strings.Contains(got, "**Split the A\\|B pipeline**") || !strings.Contains(got, "*It unblocks \\*partners\\**") {
+		t.Errorf("biggest accomplishment not escaped:\n%s", got)
+	}
+}
+
+func TestFormatHighlightMarkdownFrontMatter(t *testing.T) {
+	frontMatter, err := NewFrontMatter("", []string{"weekly", " perf\"dive "})
+	if err != nil {
+		t.Fatalf("NewFrontMatter() error = %v", err)
+	}
+	data := HighlightData{
+		Email:       "jane@example.com",
+		DisplayName: "Jane Doe",
+		StartDate:   time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC),
+		FrontMatter: frontMatter,
+	}
+
+	got, err := FormatHighlight(data, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("FormatHighlight() error = %v", err)
+	}
+	want := "---\ntitle: \"Highlights: Jane Doe, January 6, 2025 to January 12, 2025\"\ndate: 2025-01-12\ntags: [\"weekly\", \"perf\\\"dive\"]\n---\n\n# Activity Summary: Jane Doe\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("markdown starts with:\n%s\nwant:\n%s", got[:min(len(got), len(want))], want)
+	}
+
+	// Only the markdown format gets front matter
+	if text, _ := FormatHighlight(data, FormatText); strings.Contains(text, "title:") {
+		t.Errorf("text output has front matter:\n%s", text)
+	}
+
+	custom, err := NewFrontMatter("{{.Days}} days of {{.Email}}", nil)
+	if err != nil {
+		t.Fatalf("NewFrontMatter() error = %v", err)
+	}
+	data.Days, data.FrontMatter = 7, custom
+	if got, _ = FormatHighlight(data, FormatMarkdown); !strings.Contains(got, "title: \"7 days of jane@example.com\"\n") || !strings.Contains(got, "tags: []\n") {
+		t.Errorf("custom title not rendered:\n%s", got)
+	}
+	if _, err := NewFrontMatter("{{.Name", nil); err == nil {
+		t.Error("NewFrontMatter() accepted an unterminated template")
+	}
+}
+
+func TestFormatHighlightTextSkippedIntegrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    HighlightData
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "not configured",
+			data:    HighlightData{Days: 7, GitHubSkipped: "no token configured", AISkipped: "no Ollama URL configured"},
+			want:    []string{"- GitHub stats skipped: no token configured\n", "- AI summary skipped: no Ollama URL configured\n"},
+			notWant: []string{"Created 0 PRs"},
+		},
+		{
+			name:    "configured with no activity",
+			data:    HighlightData{Days: 7, GitHubAvailable: true, AccomplishmentError: "no activity"},
+			want:    []string{"- Created 0 PRs in the last 7 days"},
+			notWant: []string{"skipped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatHighlight(tt.data, FormatText)
+			if err != nil {
+				t.Fatalf("FormatHighlight() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output unexpectedly contains %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRankLeaderboard(t *testing.T) {
+	entries := []LeaderboardEntry{
+		{Email: "a@example.com", PRsOpened: 4, PRsMerged: 1},             // 3 + 4 = 7
+		{Email: "b@example.com", PRsOpened: 2, PRsMerged: 2, Reviews: 1}, // 6 + 2 + 1 = 9
+		{Email: "c@example.com", JiraResolved: 3, Reviews: 1},            // 6 + 1 = 7
+		{Email: "d@example.com"},                                         // 0
+	}
+
+	ranked := RankLeaderboard(entries, DefaultLeaderboardWeights)
+
+	tests := []struct {
+		email string
+		rank  int
+		score float64
+	}{
+		{"b@example.com", 1, 9},
+		{"a@example.com", 2, 7},
+		{"c@example.com", 2, 7},
+		{"d@example.com", 4, 0},
+	}
+
+	if len(ranked) != len(tests) {
+		t.Fatalf("got %d entries, want %d", len(ranked), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got := ranked[i]
+			if got.Email != tt.email || got.Rank != tt.rank || got.Score != tt.score {
+				t.Errorf("position %d = %s rank %d score %g, want %s rank %d score %g",
+					i, got.Email, got.Rank, got.Score, tt.email, tt.rank, tt.score)
+			}
+		})
+	}
+}
+
+func TestFormatSummaryJSONFetchWarnings(t *testing.T) {
+	ctx := &github.GitHubContext{
+		PullRequests: []github.PullRequest{
+			{Title: "Complete", HTMLURL: "https://github.com/o/r/pull/1"},
+			{Title: "Partial", HTMLURL: "https://github.com/o/r/pull/2", FetchWarnings: []string{"diff: rate limited"}},
+		},
+	}
+
+	formatted, err := FormatSummaryJSON(SummaryData{
+		User:          "user@example.com",
+		FetchWarnings: FetchWarningsFromContext(ctx),
+	})
+	if err != nil {
+		t.Fatalf("FormatSummaryJSON() error = %v", err)
+	}
+
+	var decoded SummaryData
+	if err := json.Unmarshal([]byte(formatted), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded.IncompletePRs != 1 || len(decoded.FetchWarnings) != 1 {
+		t.Fatalf("got %d incomplete PRs, %d warnings; want 1, 1", decoded.IncompletePRs, len(decoded.FetchWarnings))
+	}
+	if got := decoded.FetchWarnings[0]; got.URL != "https://github.com/o/r/pull/2" || got.Warnings[0] != "diff: rate limited" {
+		t.Errorf("unexpected warning %+v", got)
+	}
+
+	// No warnings still yields an empty array, not null
+	formatted, err = FormatSummaryJSON(SummaryData{User: "user@example.com"})
+	if err != nil {
+		t.Fatalf("FormatSummaryJSON() error = %v", err)
+	}
+	if !strings.Contains(formatted, `"fetch_warnings": []`) {
+		t.Errorf("expected empty fetch_warnings array, got:\n%s", formatted)
+	}
+}
+
+func TestJSONSchemaMatchesOutput(t *testing.T) {
+	record := RecordJSON{Key: "o/r#1", Title: "Fix", Status: "merged", URL: "https://github.com/o/r/pull/1"}
+	highlight, err := FormatHighlight(HighlightData{
+		Accomplishments:     []Accomplishment{{Text: "a", Category: "Feature"}},
+		AccomplishmentError: "timeout",
+		GitHubSkipped:       "no token configured",
+		AISkipped:           "no Ollama URL configured",
+		JiraURL:             "https://jira.example.com",
+		PullRequests:        []github.UserPullRequest{{Number: 1, HTMLURL: "https://github.com/o/r/pull/1"}},
+		Issues:              []jira.Issue{{Key: "CNF-1"}},
+		SinceBaseline: &BaselineDiff{
+			NewPullRequests:    []RecordJSON{record},
+			MergedPullRequests: []RecordJSON{record},
+			NewIssues:          []RecordJSON{record},
+			ResolvedIssues:     []RecordJSON{record},
+		},
+	}, FormatJSON)
+	if err != nil {
+		t.Fatalf("FormatHighlight() error = %v", err)
+	}
+	summary, err := FormatSummaryJSON(SummaryData{
+		DisplayName:   "Jane",
+		FetchWarnings: []FetchWarning{{Warnings: []string{"diff: boom"}}},
+		Repositories:  []github.RepoActivity{{Repo: "o/r", Opened: 1}},
+	})
+	if err != nil {
+		t.Fatalf("FormatSummaryJSON() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		schema string
+		doc    string
+	}{
+		{name: "summary", schema: SchemaSummary, doc: summary},
+		{name: "highlight", schema: SchemaHighlight, doc: highlight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := JSONSchema(tt.schema, "v1.2.3")
+			if err != nil {
+				t.Fatalf("JSONSchema() error = %v", err)
+			}
+			var schema, doc map[string]interface{}
+			if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+				t.Fatalf("schema is not valid JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatalf("output is not valid JSON: %v", err)
+			}
+			if id := schema["$id"].(string); !strings.Contains(id, "/v1.2.3/") {
+				t.Errorf("$id %q does not include the version", id)
+			}
+			assertSchemaKeys(t, tt.name, schema, doc)
+		})
+	}
+}
+
+// assertSchemaKeys checks that every key in doc is declared in schema and every
+// declared key appears in doc, recursing into objects and arrays of objects
+func assertSchemaKeys(t *testing.T, path string, schema map[string]interface{}, doc map[string]interface{}) {
+	t.Helper()
+	props, _ := schema["properties"].(map[string]interface{})
+	for key := range doc {
+		if _, ok := props[key]; !ok {
+			t.Errorf("%s.%s is in the output but not the schema", path, key)
+		}
+	}
+	for key, prop := range props {
+		value, ok := doc[key]
+		if !ok {
+			t.Errorf("%s.%s is in the schema but not the output", path, key)
+			continue
+		}
+		propSchema := prop.(map[string]interface{})
+		switch v := value.(type) {
+		case map[string]interface{}:
+			assertSchemaKeys(t, path+"."+key, propSchema, v)
+		case []interface{}:
+			items, _ := propSchema["items"].(map[string]interface{})
+			if obj, isObj := firstObject(v); isObj && items != nil {
+				assertSchemaKeys(t, path+"."+key+"[]", items, obj)
+			}
+		}
+	}
+}
+
+func firstObject(values []interface{}) (map[string]interface{}, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+	obj, ok := values[0].(map[string]interface{})
+	return obj, ok
+}
+
+func TestParseSections(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []Section
+		wantErr bool
+	}{
+		{spec: "all", want: AllSections},
+		{spec: "summary", want: []Section{SectionJira, SectionGitHub}},
+		{spec: "summary, metrics", want: []Section{SectionJira, SectionGitHub, SectionMetrics}},
+		{spec: "REFERENCES", want: []Section{SectionReferences}},
+		{spec: "", wantErr: true},
+		{spec: "jira,bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseSections(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSections(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, s := range AllSections {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == s
+				}
+				if got.Has(s) != want {
+					t.Errorf("ParseSections(%q).Has(%s) = %v, want %v", tt.spec, s, got.Has(s), want)
+				}
+			}
+		})
+	}
+}
+
+func TestBaselineDiff(t *testing.T) {
+	pr := func(number int, state, mergedAt string) github.UserPullRequest {
+		return github.UserPullRequest{Number: number, State: state, RepositoryURL: "https://api.github.com/repos/o/r", PullRequest: &github.PullRequestMeta{MergedAt: mergedAt}}
+	}
+	previous := HighlightData{
+		PullRequests: []github.UserPullRequest{pr(1, "open", ""), pr(2, "open", "")},
+		Issues:       []jira.Issue{{Key: "CNF-1"}, {Key: "CNF-2", Resolved: "2025-01-03T10:00:00.000+0000"}},
+	}
+	exported, err := FormatHighlight(previous, FormatJSON)
+	if err != nil {
+		t.Fatalf("FormatHighlight() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "last-week.json")
+	if err := os.WriteFile(path, []byte(exported), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+	current := HighlightData{
+		PullRequests: []github.UserPullRequest{pr(1, "closed", "2025-01-08T10:00:00Z"), pr(2, "open", ""), pr(3, "open", "")},
+		Issues:       []jira.Issue{{Key: "CNF-1", Resolved: "2025-01-09T10:00:00.000+0000"}, {Key: "CNF-2", Resolved: "2025-01-03T10:00:00.000+0000"}, {Key: "CNF-3"}},
+	}
+	diff := baseline.Diff(current)
+
+	keys := func(records []RecordJSON) string {
+		var out []string
+		for _, r := range records {
+			out = append(out, r.Key)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := keys(diff.NewPullRequests); got != "o/r#3" {
+		t.Errorf("NewPullRequests = %s, want o/r#3", got)
+	}
+	if got := keys(diff.MergedPullRequests); got != "o/r#1" {
+		t.Errorf("MergedPullRequests = %s, want o/r#1", got)
+	}
+	if got := keys(diff.NewIssues); got != "CNF-3" {
+		t.Errorf("NewIssues = %s, want CNF-3", got)
+	}
+	if got := keys(diff.ResolvedIssues); got != "CNF-1" {
+		t.Errorf("ResolvedIssues = %s, want CNF-1", got)
+	}
+
+	if empty := baseline.Diff(previous); !empty.Empty() {
+		t.Errorf("Diff(baseline itself) = %+v, want nothing new", empty)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"email": "a@b.c"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Error("LoadBaseline() of an export without records succeeded, want error")
+	}
+}
+
+func TestFormatJournalDetail(t *testing.T) {
+	if got := FormatJournalDetail(HighlightData{}); got != "" {
+		t.Errorf("FormatJournalDetail() with no activity = %q, want empty", got)
+	}
+
+	got := FormatJournalDetail(HighlightData{
+		JiraURL:      "https://issues.example.com/",
+		PullRequests: []github.UserPullRequest{{Number: 7, Title: "Fix *flaky* test", State: "open", HTMLURL: "https://github.com/o/r/pull/7", RepositoryURL: "https://api.github.com/repos/o/r"}},
+		Issues:       []jira.Issue{{Key: "CNF-1", Summary: "Tune latency", Status: jira.Status{Name: "Done"}}},
+	})
+	for _, want := range []string{
+		"<details>\n<summary>Activity: 1 pull requests, 1 Jira issues</summary>\n\n",
+		"- [o/r#7](https://github.com/o/r/pull/7) Fix \\*flaky\\* test (open)\n",
+		"- [CNF-1](https://issues.example.com/browse/CNF-1) Tune latency (Done)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatJournalDetail() missing %q:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(got, "</details>\n") {
+		t.Errorf("FormatJournalDetail() does not close the details section:\n%s", got)
+	}
+}
+
+func TestFormatTable(t *testing.T) {
+	data := HighlightData{
+		Email: "me@example.com",
+		PullRequests: []github.UserPullRequest{
+			{Number: 7, RepositoryURL: "https://api.github.com/repos/o/r", Title: "Add a pretty terminal table format for quick interactive viewing", State: "open", UpdatedAt: "2025-01-04T09:00:00Z"},
+		},
+		Issues: []jira.Issue{
+			{Key: "CNF-18498", Summary: "Short\ttitle", Status: jira.Status{Name: "In Progress"}, Updated: "2025-01-03T10:00:00Z"},
+		},
+	}
+
+	got := FormatTable(data, 70)
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[2], "TYPE  ID") {
+		t.Fatalf("FormatTable() =\n%s\nwant a summary line, a blank line, a header and 2 rows", got)
+	}
+	for _, line := range lines[2:] {
+		if n := len([]rune(line)); n > 70 {
+			t.Errorf("row %q is %d columns, want at most 70", line, n)
+		}
+	}
+	for _, want := range []string{"PR    o/r#7      Add a pretty terminal", "…  open", "2025-01-04", "Jira  CNF-18498  Short title", "In Progress"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatTable() missing %q:\n%s", want, got)
+		}
+	}
+
+	if empty := FormatTable(HighlightData{Email: "me@example.com"}, 70); !strings.Contains(empty, "No pull requests or Jira issues") {
+		t.Errorf("FormatTable() without activity = %q", empty)
+	}
+}
+
+func TestASCIIModeOutput(t *testing.T) {
+	color.SetASCII(true)
+	defer color.SetASCII(false)
+
+	data := HighlightData{PullRequests: []github.UserPullRequest{
+		{Number: 7, RepositoryURL: "https://api.github.com/repos/o/r", Title: "Add a pretty terminal table format for quick interactive viewing", State: "open"},
+	}}
+	table := FormatTable(data, 70)
+	if strings.Contains(table, "…") || !strings.Contains(table, "...  open") {
+		t.Errorf("FormatTable() in ASCII mode =\n%s\nwant titles cut with \"...\"", table)
+	}
+	for _, line := range strings.Split(strings.TrimRight(table, "\n"), "\n")[2:] {
+		if n := len(line); n > 70 {
+			t.Errorf("row %q is %d columns, want at most 70", line, n)
+		}
+	}
+
+	if got, want := DefaultLeaderboardWeights.Formula(), "score = 3xPRs merged + 1xPRs opened + 2xJira resolved + 1xreviews"; got != want {
+		t.Errorf("Formula() in ASCII mode = %q, want %q", got, want)
+	}
+}
+
+func TestFormatHighlightGroupsAccomplishmentsByCategory(t *testing.T) {
+	data := HighlightData{
+		Days:      7,
+		ListCount: 3,
+		Accomplishments: []Accomplishment{
+			{Text: "Fixed the flaky upgrade", Category: "Bug Fix"},
+			{Text: "Shipped the OAuth flow", Category: "Feature"},
+			{Text: "Rewrote the release notes", Category: "Other"},
+			{Text: "Patched the leak", Category: "Bug Fix"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "text",
+			format: FormatText,
+			want:   "  Feature:\n    2. Shipped the OAuth flow\n  Bug Fix:\n    1. Fixed the flaky upgrade\n    4. Patched the leak\n  Other:\n    3. Rewrote the release notes\n",
+		},
+		{
+			name:   "markdown",
+			format: FormatMarkdown,
+			want:   "## Top Accomplishments\n\n### Feature\n\n2. Shipped the OAuth flow\n\n### Bug Fix\n\n1. Fixed the flaky upgrade\n4. Patched the leak\n\n### Other\n\n3. Rewrote the release notes\n",
+		},
+		{
+			name:   "html",
+			format: FormatHTML,
+			want:   "  <h3>Bug Fix</h3>\n  <ol>\n    <li value=\"1\">Fixed the flaky upgrade</li>\n    <li value=\"4\">Patched the leak</li>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatHighlight(data, tt.format)
+			if err != nil {
+				t.Fatalf("FormatHighlight() error = %v", err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("FormatHighlight() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	data.AccomplishmentCategories = []string{"Bug Fix", "Feature"}
+	got, _ := FormatHighlight(data, FormatText)
+	if strings.Index(got, "Bug Fix:") > strings.Index(got, "Feature:") {
+		t.Errorf("FormatHighlight() = %q, want the configured category order", got)
+	}
+
+	data.Accomplishments = []Accomplishment{{Text: "Untagged"}}
+	got, _ = FormatHighlight(data, FormatText)
+	if !strings.Contains(got, "  1. Untagged\n") || strings.Contains(got, "Other:") {
+		t.Errorf("FormatHighlight() = %q, want untagged accomplishments as a plain list", got)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	ctx := &github.GitHubContext{
+		References: []github.GitHubReference{{Type: "pull"}, {Type: "pull"}, {Type: "pull"}, {Type: "pull"}, {Type: "issues"}},
+		PullRequests: []github.PullRequest{
+			{Number: 1},
+			{Number: 2, CodeDiff: "diff --git a/x b/x\n... (truncated: showing 1KB of 2KB diff across 1 files)"},
+			{Number: 3, FetchWarnings: []string{"reviews: rate limited"}},
+			{Number: 4, BudgetLimited: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		coverage Coverage
+		want     string
+	}{
+		{
+			name:     "referenced PRs",
+			coverage: NewCoverage(ctx),
+			want:     "Coverage: PRs 2/4 enhanced, 1 basic only (--repo-request-budget), 1 diff truncated, GitHub activity: unavailable",
+		},
+		{
+			name: "caps hit",
+			coverage: Coverage{
+				GitHubActivity: true,
+				Issues:         CoverageCap{Kept: 50, Found: 80},
+				PRs:            CoverageCap{Kept: 10, Found: 10},
+				References:     CoverageCap{Kept: 20, Found: 25},
+			},
+			want: "Coverage: Jira issues capped at 50 of 80, references capped at 20 of 25, GitHub activity: available",
+		},
+		{
+			name:     "no data",
+			coverage: NewCoverage(nil),
+			want:     "Coverage: GitHub activity: unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.coverage.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSectionOrder(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []Section
+		wantErr bool
+	}{
+		{spec: "", want: []Section{SectionJira, SectionGitHub, SectionMetrics}},
+		{spec: "github,jira,metrics", want: []Section{SectionGitHub, SectionJira, SectionMetrics}},
+		{spec: " GitHub ", want: []Section{SectionGitHub, SectionJira, SectionMetrics}},
+		{spec: "metrics,github", want: []Section{SectionMetrics, SectionGitHub, SectionJira}},
+		{spec: "github,references", wantErr: true},
+		{spec: "jira,jira", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseSectionOrder(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSectionOrder(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseSectionOrder(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTrendsTextAlignsColumns(t *testing.T) {
+	data := TrendsData{Email: "dev@example.com", Runs: []metrics.Run{
+		{Period: "2025-01-01/2025-01-31", PRsCreated: 12, PRsMerged: 1234567890, JiraCreated: 3, JiraUpdated: 4},
+		{Period: "2025-02-01/2025-02-28", PRsMerged: 7},
+	}}
+	got, err := FormatTrends(data, FormatText)
+	if err != nil {
+		t.Fatalf("FormatTrends() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(got), "\n")[2:]
+	want := []string{
+		"Period                 PRs Opened  PRs Merged  Jira Created  Jira Updated",
+		"-------------------------------------------------------------------------",
+		"2025-01-01/2025-01-31          12  1234567890             3             4",
+		"2025-02-01/2025-02-28           0           7             0             0",
+	}
+	if !slices.Equal(lines, want) {
+		t.Errorf("table =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
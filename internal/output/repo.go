@@ -0,0 +1,141 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+)
+
+// RepoData contains data for repository activity output
+type RepoData struct {
+	Repo         string
+	StartDate    time.Time
+	EndDate      time.Time
+	PullRequests []github.UserPullRequest
+	Issues       []github.UserIssue
+	Contributors []github.Contributor
+	Summary      string
+	SummaryError string
+}
+
+// FormatRepo formats repository activity according to the specified format
+func FormatRepo(data RepoData, format Format) (string, error) {
+	switch format {
+	case FormatText:
+		return formatRepoText(data), nil
+	case FormatJSON:
+		return formatRepoJSON(data)
+	case FormatMarkdown:
+		return formatRepoMarkdown(data), nil
+	case FormatCSV:
+		return formatRepoCSV(data), nil
+	default:
+		return "", fmt.Errorf("format '%s' is not supported for repository activity: use text, json, markdown, or csv", format)
+	}
+}
+
+func formatRepoText(data RepoData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Repository activity: %s (%s to %s)\n\n", data.Repo,
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"))
+	fmt.Fprintf(&sb, "PRs merged: %d\n", len(data.PullRequests))
+	fmt.Fprintf(&sb, "Issues closed: %d\n", len(data.Issues))
+	fmt.Fprintf(&sb, "Contributors: %d\n", len(data.Contributors))
+
+	if data.Summary != "" {
+		fmt.Fprintf(&sb, "\nSummary:\n%s\n", data.Summary)
+	} else if data.SummaryError != "" {
+		fmt.Fprintf(&sb, "\nSummary unavailable: %s\n", data.SummaryError)
+	}
+
+	if len(data.Contributors) > 0 {
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "%-32s  %10s  %15s\n", "Contributor", "PRs merged", "Issues reported")
+		sb.WriteString(strings.Repeat("-", 61) + "\n")
+		for _, c := range data.Contributors {
+			fmt.Fprintf(&sb, "%-32s  %10d  %15d\n", c.Login, c.PRsMerged, c.IssuesReported)
+		}
+	}
+
+	return sb.String()
+}
+
+func formatRepoJSON(data RepoData) (string, error) {
+	jsonData := map[string]interface{}{
+		"repo":      data.Repo,
+		"startDate": data.StartDate.Format("2006-01-02"),
+		"endDate":   data.EndDate.Format("2006-01-02"),
+		"stats": map[string]int{
+			"prsMerged":    len(data.PullRequests),
+			"issuesClosed": len(data.Issues),
+			"contributors": len(data.Contributors),
+		},
+		"contributors": data.Contributors,
+		"pullRequests": data.PullRequests,
+		"issues":       data.Issues,
+		"summary":      data.Summary,
+	}
+	if data.SummaryError != "" {
+		jsonData["summaryError"] = data.SummaryError
+	}
+
+	bytes, err := json.MarshalIndent(jsonData, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func formatRepoMarkdown(data RepoData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# Repository Activity: %s\n\n", escapeMarkdown(data.Repo))
+	fmt.Fprintf(&sb, "**Period:** %s to %s\n\n",
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"))
+	fmt.Fprintf(&sb, "- PRs merged: %d\n- Issues closed: %d\n- Contributors: %d\n\n",
+		len(data.PullRequests), len(data.Issues), len(data.Contributors))
+
+	if data.Summary != "" {
+		sb.WriteString("## Summary\n\n")
+		fmt.Fprintf(&sb, "%s\n\n", escapeMarkdown(data.Summary))
+	}
+
+	if len(data.Contributors) > 0 {
+		sb.WriteString("## Contributors\n\n")
+		sb.WriteString("| Contributor | PRs Merged | Issues Reported |\n")
+		sb.WriteString("|-------------|------------|-----------------|\n")
+		for _, c := range data.Contributors {
+			fmt.Fprintf(&sb, "| %s | %d | %d |\n", escapeMarkdown(c.Login), c.PRsMerged, c.IssuesReported)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatRepoCSV emits one row per contributor
+func formatRepoCSV(data RepoData) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	_ = w.Write([]string{"repo", "start_date", "end_date", "contributor", "prs_merged", "issues_reported"})
+	for _, c := range data.Contributors {
+		_ = w.Write([]string{
+			data.Repo,
+			data.StartDate.Format("2006-01-02"),
+			data.EndDate.Format("2006-01-02"),
+			c.Login,
+			strconv.Itoa(c.PRsMerged),
+			strconv.Itoa(c.IssuesReported),
+		})
+	}
+
+	w.Flush()
+	return sb.String()
+}
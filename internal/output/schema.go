@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SchemaDraft is the JSON Schema dialect used for the output schemas
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema names accepted by JSONSchema
+const (
+	SchemaSummary   = "summary"
+	SchemaHighlight = "highlight"
+)
+
+// SchemaID returns the versioned $id of a schema, so consumers can tell which
+// perfdive release a document's shape belongs to
+func SchemaID(name, version string) string {
+	return fmt.Sprintf("https://github.com/redhat-best-practices-for-k8s/perfdive/schemas/%s/%s.json", version, name)
+}
+
+// JSONSchema returns the indented JSON Schema for the named output document.
+// The schemas are maintained alongside SummaryData and formatHighlightJSON;
+// TestJSONSchemaMatchesOutput fails if the two drift apart.
+func JSONSchema(name, version string) (string, error) {
+	var schema map[string]interface{}
+	switch name {
+	case SchemaSummary:
+		schema = summarySchema()
+	case SchemaHighlight:
+		schema = highlightSchema()
+	default:
+		return "", fmt.Errorf("unknown schema '%s': supported schemas are %s, %s", name, SchemaSummary, SchemaHighlight)
+	}
+	schema["$schema"] = SchemaDraft
+	schema["$id"] = SchemaID(name, version)
+
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schema); err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func summarySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"title":       "perfdive summary",
+		"description": "Output of 'perfdive <email> <start> <end> <model> --output json'",
+		"type":        "object",
+		"required":    []string{"user", "period", "summary", "total_issues", "jira_role", "incomplete_prs", "fetch_warnings", "coverage"},
+		"properties": map[string]interface{}{
+			"user":           schemaType("string", "Email address the summary was generated for"),
+			"display_name":   schemaType("string", "Display name from Jira, when known"),
+			"period":         schemaType("string", "Date range as 'MM-DD-YYYY to MM-DD-YYYY'"),
+			"summary":        schemaType("string", "AI-generated summary text"),
+			"total_issues":   schemaType("integer", "Jira issues found before any --max-issues cap"),
+			"jira_role":      schemaType("string", "How the user relates to the Jira issues: assignee, reporter, or contributor"),
+			"incomplete_prs": schemaType("integer", "Referenced PRs whose enhanced context could not be fully fetched"),
+			"fetch_warnings": map[string]interface{}{
+				"type":        "array",
+				"description": "Per-PR enhanced-context fetch failures",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"url", "title", "warnings"},
+					"properties": map[string]interface{}{
+						"url":      schemaType("string", "Pull request URL"),
+						"title":    schemaType("string", "Pull request title"),
+						"warnings": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					},
+					"additionalProperties": false,
+				},
+			},
+			"coverage": map[string]interface{}{
+				"type":        "object",
+				"description": "How complete the data behind the summary is",
+				"required":    []string{"prs_referenced", "prs_enhanced", "prs_budget_limited", "diffs_truncated", "github_activity", "issues", "prs", "references"},
+				"properties": map[string]interface{}{
+					"prs_referenced":     schemaType("integer", "PRs referenced from the Jira issues and fetched, leaving out those beyond --max-references or dropped by --shipped-only"),
+					"prs_enhanced":       schemaType("integer", "Of those, PRs whose reviews, files and diff were all fetched"),
+					"prs_budget_limited": schemaType("integer", "Of those, PRs fetched without reviews, files or diff under --repo-request-budget"),
+					"diffs_truncated":    schemaType("integer", "PR diffs cut to api.diff_size_limit"),
+					"github_activity":    schemaType("boolean", "Whether the user's GitHub activity was available"),
+					"issues":             coverageCapSchema("Jira issues kept under --max-issues, of those found"),
+					"prs":                coverageCapSchema("Authored PRs kept under --max-prs, of those found"),
+					"references":         coverageCapSchema("GitHub references fetched under --max-references, of those found"),
+				},
+				"additionalProperties": false,
+			},
+			"repositories": map[string]interface{}{
+				"type":        "array",
+				"description": "Per-repository PR breakdown, most active first (only with --group-by-repo)",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"repo", "prs_opened", "prs_merged", "additions", "deletions", "line_counts"},
+					"properties": map[string]interface{}{
+						"repo":        schemaType("string", "Repository as owner/repo"),
+						"prs_opened":  schemaType("integer", "PRs opened in the period"),
+						"prs_merged":  schemaType("integer", "Of those, PRs merged"),
+						"additions":   schemaType("integer", "Lines added, from PRs with enhanced data"),
+						"deletions":   schemaType("integer", "Lines deleted, from PRs with enhanced data"),
+						"line_counts": schemaType("boolean", "Whether any PR in the repo had line counts available"),
+					},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// coverageCapSchema describes a CoverageCap
+func coverageCapSchema(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "object",
+		"description": description,
+		"required":    []string{"kept", "found"},
+		"properties": map[string]interface{}{
+			"kept":  schemaType("integer", "Items kept"),
+			"found": schemaType("integer", "Items found before the cap"),
+		},
+		"additionalProperties": false,
+	}
+}
+
+// recordSchema describes a RecordJSON
+func recordSchema(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": description,
+		"items": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"key", "title", "status"},
+			"properties": map[string]interface{}{
+				"key":    schemaType("string", "owner/repo#number for pull requests, the issue key for Jira"),
+				"title":  schemaType("string", "PR title or Jira summary"),
+				"status": schemaType("string", "PRs: open, merged or closed-unmerged; Jira: open or resolved"),
+				"url":    schemaType("string", "Link to the PR or issue, when known"),
+			},
+			"additionalProperties": false,
+		},
+	}
+}
+
+func highlightSchema() map[string]interface{} {
+	stats := map[string]interface{}{}
+	for _, name := range []string{"prsCreated", "prsMerged", "prsClosedUnmerged", "prsOpen", "jiraCreated", "jiraUpdated", "commits"} {
+		stats[name] = map[string]interface{}{"type": "integer", "minimum": 0}
+	}
+
+	return map[string]interface{}{
+		"title":       "perfdive highlight",
+		"description": "Output of 'perfdive highlight <email> --output json'",
+		"type":        "object",
+		"required":    []string{"email", "displayName", "startDate", "endDate", "days", "stats", "accomplishments", "biggestAccomplishment", "why", "pullRequests", "issues"},
+		"properties": map[string]interface{}{
+			"email":       schemaType("string", "Email address the highlight was generated for"),
+			"displayName": schemaType("string", "Display name from Jira, when known"),
+			"startDate":   map[string]interface{}{"type": "string", "format": "date"},
+			"endDate":     map[string]interface{}{"type": "string", "format": "date"},
+			"days":        schemaType("integer", "Length of the period in days"),
+			"stats": map[string]interface{}{
+				"type":                 "object",
+				"properties":           stats,
+				"required":             []string{"prsCreated", "prsMerged", "prsClosedUnmerged", "prsOpen", "jiraCreated", "jiraUpdated", "commits"},
+				"additionalProperties": false,
+			},
+			"accomplishments": map[string]interface{}{
+				"type":        []string{"array", "null"},
+				"description": "Top accomplishments when --list is used, most significant first",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"text", "category"},
+					"properties": map[string]interface{}{
+						"text":     schemaType("string", "The accomplishment"),
+						"category": schemaType("string", "Category the model tagged it with, e.g. Feature or Bug Fix; Other if it matched none, empty if untagged"),
+					},
+					"additionalProperties": false,
+				},
+			},
+			"biggestAccomplishment": schemaType("string", "Single biggest accomplishment"),
+			"why":                   schemaType("string", "Why the biggest accomplishment matters"),
+			"accomplishmentError":   schemaType("string", "Why accomplishments could not be generated, if they failed"),
+			"githubSkipped":         schemaType("string", "Why GitHub stats are missing, if GitHub was not configured or unavailable"),
+			"aiSkipped":             schemaType("string", "Why no AI summary was attempted, if Ollama was not configured"),
+			"pullRequests":          recordSchema("Pull requests in the period, matched by key against a --baseline export"),
+			"issues":                recordSchema("Jira issues in the period, matched by key against a --baseline export"),
+			"sinceBaseline": map[string]interface{}{
+				"type":        "object",
+				"description": "What is new compared with --baseline (only with --baseline)",
+				"required":    []string{"baseline", "newPullRequests", "mergedPullRequests", "newIssues", "resolvedIssues"},
+				"properties": map[string]interface{}{
+					"baseline":           schemaType("string", "Path of the baseline export"),
+					"newPullRequests":    recordSchema("PRs not in the baseline"),
+					"mergedPullRequests": recordSchema("PRs merged since the baseline"),
+					"newIssues":          recordSchema("Jira issues not in the baseline"),
+					"resolvedIssues":     recordSchema("Jira issues resolved since the baseline"),
+				},
+				"additionalProperties": false,
+			},
+		},
+		"additionalProperties": false,
+	}
+}
+
+func schemaType(typ, description string) map[string]interface{} {
+	return map[string]interface{}{"type": typ, "description": description}
+}
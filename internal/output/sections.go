@@ -0,0 +1,90 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section is one part of the main summary output
+type Section string
+
+const (
+	SectionJira       Section = "jira"
+	SectionGitHub     Section = "github"
+	SectionMetrics    Section = "metrics"
+	SectionReferences Section = "references"
+)
+
+// AllSections lists every section in output order
+var AllSections = []Section{SectionJira, SectionGitHub, SectionMetrics, SectionReferences}
+
+// DefaultSectionOrder is the order of the summary's sections unless
+// --section-order is given
+var DefaultSectionOrder = []Section{SectionJira, SectionGitHub, SectionMetrics}
+
+// Sections is the set of summary sections to emit; a nil set emits everything
+type Sections map[Section]bool
+
+// ParseSections parses a comma-separated section list. "all" selects every
+// section and "summary" is shorthand for the two AI narratives (jira,github).
+func ParseSections(spec string) (Sections, error) {
+	sections := Sections{}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "all":
+			for _, s := range AllSections {
+				sections[s] = true
+			}
+		case "summary":
+			sections[SectionJira] = true
+			sections[SectionGitHub] = true
+		case string(SectionJira), string(SectionGitHub), string(SectionMetrics), string(SectionReferences):
+			sections[Section(name)] = true
+		default:
+			return nil, fmt.Errorf("unknown section '%s': supported sections are jira, github, metrics, references, summary, all", name)
+		}
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no sections selected")
+	}
+	return sections, nil
+}
+
+// Has reports whether a section should be emitted
+func (s Sections) Has(section Section) bool {
+	return s == nil || s[section]
+}
+
+// ParseSectionOrder parses a comma-separated order of the summary sections
+// (jira, github, metrics), e.g. "github,jira,metrics". Sections left out
+// follow in their default order; "" is the default order.
+func ParseSectionOrder(spec string) ([]Section, error) {
+	var order []Section
+	seen := make(map[Section]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		section := Section(name)
+		switch section {
+		case SectionJira, SectionGitHub, SectionMetrics:
+		default:
+			return nil, fmt.Errorf("unknown section '%s' in section order: supported sections are jira, github, metrics", name)
+		}
+		if seen[section] {
+			return nil, fmt.Errorf("section '%s' appears twice in section order", name)
+		}
+		seen[section] = true
+		order = append(order, section)
+	}
+	for _, section := range DefaultSectionOrder {
+		if !seen[section] {
+			order = append(order, section)
+		}
+	}
+	return order, nil
+}
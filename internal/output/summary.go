@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+)
+
+// SummaryData contains data for the main summary command's JSON output
+type SummaryData struct {
+	User          string         `json:"user"`
+	DisplayName   string         `json:"display_name,omitempty"`
+	Period        string         `json:"period"`
+	Summary       string         `json:"summary"`
+	TotalIssues   int            `json:"total_issues"`
+	JiraRole      string         `json:"jira_role"`
+	IncompletePRs int            `json:"incomplete_prs"`
+	FetchWarnings []FetchWarning `json:"fetch_warnings"`
+	Coverage      Coverage       `json:"coverage"`
+
+	// Repositories is the per-repository PR breakdown (only with --group-by-repo)
+	Repositories []github.RepoActivity `json:"repositories,omitempty"`
+}
+
+// FetchWarning lists the enhanced-context fetches that failed for one pull request
+type FetchWarning struct {
+	URL      string   `json:"url"`
+	Title    string   `json:"title"`
+	Warnings []string `json:"warnings"`
+}
+
+// FetchWarningsFromContext collects the fetch warnings of every incomplete referenced PR
+func FetchWarningsFromContext(ctx *github.GitHubContext) []FetchWarning {
+	warnings := []FetchWarning{}
+	if ctx == nil {
+		return warnings
+	}
+	for _, pr := range ctx.IncompletePullRequests() {
+		warnings = append(warnings, FetchWarning{
+			URL:      pr.HTMLURL,
+			Title:    pr.Title,
+			Warnings: pr.FetchWarnings,
+		})
+	}
+	return warnings
+}
+
+// FormatSummaryJSON formats summary data as indented JSON
+func FormatSummaryJSON(data SummaryData) (string, error) {
+	if data.FetchWarnings == nil {
+		data.FetchWarnings = []FetchWarning{}
+	}
+	data.IncompletePRs = len(data.FetchWarnings)
+
+	output, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal summary JSON: %w", err)
+	}
+	return string(output) + "\n", nil
+}
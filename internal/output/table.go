@@ -0,0 +1,111 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+)
+
+// defaultTableWidth is used when the terminal width can't be detected, e.g.
+// when output is redirected and COLUMNS is unset
+const defaultTableWidth = 120
+
+// minTitleWidth keeps titles readable on very narrow terminals; rows wrap instead
+const minTitleWidth = 20
+
+// tableColumnGap is the padding between table columns
+const tableColumnGap = 2
+
+// tableRow is one PR or Jira issue in the table
+type tableRow struct {
+	kind, id, title, status, date string
+}
+
+// FormatTable formats the highlight's pull requests and Jira issues as an
+// aligned table for reading in a terminal, truncating titles so each row fits
+// in width columns
+func FormatTable(data HighlightData, width int) string {
+	var sb strings.Builder
+
+	name := data.DisplayName
+	if name == "" {
+		name = data.Email
+	}
+	fmt.Fprintf(&sb, "%s, %s to %s: %d pull requests, %d Jira issues\n\n", name,
+		data.StartDate.Format("January 2, 2006"), data.EndDate.Format("January 2, 2006"), len(data.PullRequests), len(data.Issues))
+
+	rows := make([]tableRow, 0, len(data.PullRequests)+len(data.Issues))
+	for _, pr := range data.PullRequests {
+		rows = append(rows, tableRow{kind: "PR", id: pullRequestKey(pr), title: pr.Title, status: pr.Status(), date: tableDate(pr.UpdatedAt)})
+	}
+	for _, issue := range data.Issues {
+		rows = append(rows, tableRow{kind: "Jira", id: issue.Key, title: issue.Summary, status: issue.Status.Name, date: tableDate(issue.Updated)})
+	}
+	if len(rows) == 0 {
+		sb.WriteString("No pull requests or Jira issues in this period.\n")
+		return sb.String()
+	}
+
+	header := tableRow{kind: "TYPE", id: "ID", title: "TITLE", status: "STATUS", date: "DATE"}
+	titleWidth := width - tableColumnGap*4
+	for _, column := range []func(tableRow) string{
+		func(r tableRow) string { return r.kind },
+		func(r tableRow) string { return r.id },
+		func(r tableRow) string { return r.status },
+		func(r tableRow) string { return r.date },
+	} {
+		widest := utf8.RuneCountInString(column(header))
+		for _, row := range rows {
+			widest = max(widest, utf8.RuneCountInString(column(row)))
+		}
+		titleWidth -= widest
+	}
+	titleWidth = max(titleWidth, minTitleWidth)
+
+	w := tabwriter.NewWriter(&sb, 0, 0, tableColumnGap, ' ', 0)
+	for _, row := range append([]tableRow{header}, rows...) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.kind, row.id, truncateTitle(row.title, titleWidth), row.status, row.date)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+// truncateTitle shortens s to at most width runes, ending in "…" if cut.
+// Tabs and newlines are replaced so they can't break the table layout.
+func truncateTitle(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	ellipsis := color.Symbols("…")
+	return strings.TrimRight(string(runes[:max(width-utf8.RuneCountInString(ellipsis), 0)]), " ") + ellipsis
+}
+
+// tableDate shortens an RFC 3339 timestamp to its date
+func tableDate(value string) string {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return value
+}
+
+// TerminalWidth returns the width of the terminal on stdout, falling back to
+// $COLUMNS and then defaultTableWidth when stdout is not a terminal
+func TerminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultTableWidth
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
+)
+
+// MonthlyData contains a per-month activity time series for one user
+type MonthlyData struct {
+	Email     string
+	StartDate time.Time
+	EndDate   time.Time
+	Months    []metrics.MonthlyCounts
+}
+
+// FormatMonthly formats a monthly time series as CSV (one row per month) or JSON
+func FormatMonthly(data MonthlyData, format Format) (string, error) {
+	switch format {
+	case FormatCSV:
+		return formatMonthlyCSV(data), nil
+	case FormatJSON:
+		return formatMonthlyJSON(data)
+	default:
+		return "", fmt.Errorf("format '%s' is not supported for monthly time series: use csv or json", format)
+	}
+}
+
+func formatMonthlyCSV(data MonthlyData) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	_ = w.Write([]string{"Month", "PRs Created", "PRs Merged", "Jira Created", "Jira Resolved"})
+	for _, m := range data.Months {
+		_ = w.Write([]string{
+			m.Month,
+			fmt.Sprintf("%d", m.PRsCreated),
+			fmt.Sprintf("%d", m.PRsMerged),
+			fmt.Sprintf("%d", m.JiraCreated),
+			fmt.Sprintf("%d", m.JiraResolved),
+		})
+	}
+
+	w.Flush()
+	return sb.String()
+}
+
+func formatMonthlyJSON(data MonthlyData) (string, error) {
+	months := data.Months
+	if months == nil {
+		months = []metrics.MonthlyCounts{}
+	}
+	jsonData := map[string]interface{}{
+		"email":     data.Email,
+		"startDate": data.StartDate.Format("2006-01-02"),
+		"endDate":   data.EndDate.Format("2006-01-02"),
+		"months":    months,
+	}
+
+	bytes, err := json.MarshalIndent(jsonData, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
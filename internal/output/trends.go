@@ -0,0 +1,101 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/metrics"
+)
+
+// TrendsData contains a user's recorded runs, oldest first
+type TrendsData struct {
+	Email string
+	Runs  []metrics.Run
+}
+
+// FormatTrends formats recorded run metrics according to the specified format
+func FormatTrends(data TrendsData, format Format) (string, error) {
+	switch format {
+	case FormatText:
+		return formatTrendsText(data), nil
+	case FormatJSON:
+		return formatTrendsJSON(data)
+	case FormatCSV:
+		return formatTrendsCSV(data), nil
+	default:
+		return "", fmt.Errorf("format '%s' is not supported for trends: use text, json, or csv", format)
+	}
+}
+
+func formatTrendsText(data TrendsData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Activity trends for %s\n\n", data.Email)
+	if len(data.Runs) == 0 {
+		sb.WriteString("No runs recorded yet. Run perfdive or perfdive highlight with --record-metrics first.\n")
+		return sb.String()
+	}
+
+	// Each count column is as wide as its header, the period column as its widest value
+	headers := []string{"PRs Opened", "PRs Merged", "Jira Created", "Jira Updated"}
+	periodWidth := utf8.RuneCountInString("Period")
+	for _, run := range data.Runs {
+		periodWidth = max(periodWidth, utf8.RuneCountInString(run.Period))
+	}
+	tableWidth := periodWidth
+	fmt.Fprintf(&sb, "%-*s", periodWidth, "Period")
+	for _, header := range headers {
+		fmt.Fprintf(&sb, "  %s", header)
+		tableWidth += 2 + len(header)
+	}
+	sb.WriteString("\n" + strings.Repeat("-", tableWidth) + "\n")
+	for _, run := range data.Runs {
+		fmt.Fprintf(&sb, "%-*s", periodWidth, run.Period)
+		for i, count := range []int{run.PRsCreated, run.PRsMerged, run.JiraCreated, run.JiraUpdated} {
+			fmt.Fprintf(&sb, "  %*d", len(headers[i]), count)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func formatTrendsJSON(data TrendsData) (string, error) {
+	runs := data.Runs
+	if runs == nil {
+		runs = []metrics.Run{}
+	}
+	jsonData := map[string]interface{}{
+		"email": data.Email,
+		"runs":  runs,
+	}
+
+	bytes, err := json.MarshalIndent(jsonData, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func formatTrendsCSV(data TrendsData) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	_ = w.Write([]string{"Period", "PRs Created", "PRs Merged", "Jira Created", "Jira Updated", "Recorded At"})
+	for _, run := range data.Runs {
+		_ = w.Write([]string{
+			run.Period,
+			fmt.Sprintf("%d", run.PRsCreated),
+			fmt.Sprintf("%d", run.PRsMerged),
+			fmt.Sprintf("%d", run.JiraCreated),
+			fmt.Sprintf("%d", run.JiraUpdated),
+			run.RecordedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	w.Flush()
+	return sb.String()
+}
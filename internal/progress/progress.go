@@ -7,36 +7,58 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/color"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
 )
 
 // Spinner provides an animated spinner for long-running operations
 type Spinner struct {
-	mu       sync.Mutex
-	message  string
-	frames   []string
-	current  int
-	running  bool
-	done     chan bool
-	writer   io.Writer
-	verbose  bool
+	mu      sync.Mutex
+	message string
+	frames  []string
+	current int
+	running bool
+	done    chan bool
+	writer  io.Writer
+	level   int
+	palette color.Palette
 }
 
 var defaultFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
-// NewSpinner creates a new spinner with the given message
-func NewSpinner(message string, verbose bool) *Spinner {
+// asciiFrames replace defaultFrames in ASCII mode (--ascii)
+var asciiFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerFrames returns the spinner frames for the current symbol set
+func spinnerFrames() []string {
+	if color.ASCII() {
+		return asciiFrames
+	}
+	return defaultFrames
+}
+
+// NewSpinner creates a new spinner with the given message.
+// The spinner is only shown at verbosity level 1 (-v) or higher.
+func NewSpinner(message string, level int) *Spinner {
 	return &Spinner{
 		message: message,
-		frames:  defaultFrames,
+		frames:  spinnerFrames(),
 		done:    make(chan bool),
-		writer:  os.Stdout,
-		verbose: verbose,
+		writer:  os.Stderr,
+		level:   level,
+		palette: color.For(os.Stderr),
 	}
 }
 
+// enabled reports whether the spinner should render output
+func (s *Spinner) enabled() bool {
+	return s.level >= constants.VerbosityProgress
+}
+
 // Start begins the spinner animation
 func (s *Spinner) Start() {
-	if !s.verbose {
+	if !s.enabled() {
 		return
 	}
 
@@ -70,7 +92,7 @@ func (s *Spinner) Start() {
 
 // Stop halts the spinner and clears the line
 func (s *Spinner) Stop() {
-	if !s.verbose {
+	if !s.enabled() {
 		return
 	}
 
@@ -91,16 +113,16 @@ func (s *Spinner) Stop() {
 // Success stops the spinner and prints a success message
 func (s *Spinner) Success(message string) {
 	s.Stop()
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "✓ %s\n", message)
+	if s.enabled() {
+		_, _ = fmt.Fprintf(s.writer, "%s %s\n", s.palette.Success("✓"), message)
 	}
 }
 
 // Fail stops the spinner and prints a failure message
 func (s *Spinner) Fail(message string) {
 	s.Stop()
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "✗ %s\n", message)
+	if s.enabled() {
+		_, _ = fmt.Fprintf(s.writer, "%s %s\n", s.palette.Error("✗"), message)
 	}
 }
 
@@ -116,29 +138,37 @@ type Progress struct {
 	total   int
 	current int
 	message string
-	verbose bool
+	level   int
 	writer  io.Writer
+	palette color.Palette
 	mu      sync.Mutex
 }
 
-// NewProgress creates a new progress tracker
-func NewProgress(total int, message string, verbose bool) *Progress {
+// NewProgress creates a new progress tracker.
+// Progress is only rendered at verbosity level 1 (-v) or higher.
+func NewProgress(total int, message string, level int) *Progress {
 	return &Progress{
 		total:   total,
 		current: 0,
 		message: message,
-		verbose: verbose,
-		writer:  os.Stdout,
+		level:   level,
+		writer:  os.Stderr,
+		palette: color.For(os.Stderr),
 	}
 }
 
+// enabled reports whether the progress tracker should render output
+func (p *Progress) enabled() bool {
+	return p.level >= constants.VerbosityProgress
+}
+
 // Increment advances the progress by one
 func (p *Progress) Increment() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	p.current++
-	if p.verbose {
+	if p.enabled() {
 		p.render()
 	}
 }
@@ -149,7 +179,7 @@ func (p *Progress) SetCurrent(current int) {
 	defer p.mu.Unlock()
 
 	p.current = current
-	if p.verbose {
+	if p.enabled() {
 		p.render()
 	}
 }
@@ -157,7 +187,7 @@ func (p *Progress) SetCurrent(current int) {
 // render displays the current progress
 func (p *Progress) render() {
 	if p.total <= 0 {
-		_, _ = fmt.Fprintf(p.writer, "\r→ %s (%d)...", p.message, p.current)
+		_, _ = fmt.Fprintf(p.writer, "\r%s %s (%d)...", color.Symbols("→"), p.message, p.current)
 		return
 	}
 
@@ -165,63 +195,88 @@ func (p *Progress) render() {
 	barWidth := 20
 	filled := int(float64(barWidth) * float64(p.current) / float64(p.total))
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-	_, _ = fmt.Fprintf(p.writer, "\r→ %s [%s] %d/%d (%.0f%%)", p.message, bar, p.current, p.total, percentage)
+	full, empty := "█", "░"
+	if color.ASCII() {
+		full, empty = "#", "."
+	}
+	bar := strings.Repeat(full, filled) + strings.Repeat(empty, barWidth-filled)
+	_, _ = fmt.Fprintf(p.writer, "\r%s %s [%s] %d/%d (%.0f%%)", color.Symbols("→"), p.message, bar, p.current, p.total, percentage)
 }
 
 // Done completes the progress and prints a final message
 func (p *Progress) Done(message string) {
-	if p.verbose {
+	if p.enabled() {
 		_, _ = fmt.Fprintf(p.writer, "\r%s\r", strings.Repeat(" ", 60))
-		_, _ = fmt.Fprintf(p.writer, "✓ %s\n", message)
+		_, _ = fmt.Fprintf(p.writer, "%s %s\n", p.palette.Success("✓"), message)
 	}
 }
 
 // StatusLine provides a simple status line that can be updated
 type StatusLine struct {
-	verbose bool
+	level   int
 	writer  io.Writer
+	palette color.Palette
 }
 
-// NewStatusLine creates a new status line
-func NewStatusLine(verbose bool) *StatusLine {
+// NewStatusLine creates a new status line for the given verbosity level
+func NewStatusLine(level int) *StatusLine {
 	return &StatusLine{
-		verbose: verbose,
-		writer:  os.Stdout,
+		level:   level,
+		writer:  os.Stderr,
+		palette: color.For(os.Stderr),
 	}
 }
 
+// enabled reports whether messages at the given level should be printed
+func (s *StatusLine) enabled(level int) bool {
+	return s.level >= level
+}
+
 // Print prints a status message with an arrow
 func (s *StatusLine) Print(format string, args ...any) {
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "→ "+format+"\n", args...)
+	if s.enabled(constants.VerbosityProgress) {
+		_, _ = fmt.Fprintf(s.writer, color.Symbols("→ ")+format+"\n", args...)
 	}
 }
 
 // Success prints a success message with a checkmark
 func (s *StatusLine) Success(format string, args ...any) {
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "  ✓ "+format+"\n", args...)
+	if s.enabled(constants.VerbosityProgress) {
+		_, _ = fmt.Fprintf(s.writer, "  "+s.palette.Success("✓")+" "+format+"\n", args...)
 	}
 }
 
 // Info prints an info message
 func (s *StatusLine) Info(format string, args ...any) {
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "  ℹ "+format+"\n", args...)
+	if s.enabled(constants.VerbosityProgress) {
+		_, _ = fmt.Fprintf(s.writer, "  "+s.palette.Info("ℹ")+" "+format+"\n", args...)
 	}
 }
 
 // Warn prints a warning message
 func (s *StatusLine) Warn(format string, args ...any) {
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "  ⚠ "+format+"\n", args...)
+	if s.enabled(constants.VerbosityProgress) {
+		_, _ = fmt.Fprintf(s.writer, "  "+s.palette.Warning("⚠")+" "+format+"\n", args...)
 	}
 }
 
 // Error prints an error message
 func (s *StatusLine) Error(format string, args ...any) {
-	if s.verbose {
-		_, _ = fmt.Fprintf(s.writer, "  ✗ "+format+"\n", args...)
+	if s.enabled(constants.VerbosityProgress) {
+		_, _ = fmt.Fprintf(s.writer, "  "+s.palette.Error("✗")+" "+format+"\n", args...)
+	}
+}
+
+// Debug prints per-request detail, shown at verbosity level 2 (-vv) or higher
+func (s *StatusLine) Debug(format string, args ...any) {
+	if s.enabled(constants.VerbosityRequests) {
+		_, _ = fmt.Fprintf(s.writer, color.Symbols("    · ")+format+"\n", args...)
+	}
+}
+
+// Trace prints full request/response detail, shown at verbosity level 3 (-vvv)
+func (s *StatusLine) Trace(format string, args ...any) {
+	if s.enabled(constants.VerbosityBodies) {
+		_, _ = fmt.Fprintf(s.writer, format+"\n", args...)
 	}
 }
@@ -0,0 +1,68 @@
+// Package redact masks secrets and personal data in fetched PR, issue and
+// diff text before it is cached or sent to the LLM
+package redact
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Rule is a named pattern whose matches are replaced by [REDACTED:name]
+type Rule struct {
+	Name    string
+	Pattern string
+}
+
+// DefaultRules cover the secrets most often committed by accident and email
+// addresses. More specific patterns come first, so a token is labeled as
+// such rather than by a broader rule.
+var DefaultRules = []Rule{
+	{Name: "private-key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+	{Name: "github-token", Pattern: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`},
+	{Name: "aws-access-key", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "aws-secret-key", Pattern: `(?i)aws_secret_access_key\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}["']?`},
+	{Name: "slack-token", Pattern: `\bxox[abprs]-[A-Za-z0-9-]{10,}\b`},
+	{Name: "bearer-token", Pattern: `(?i)\bbearer\s+[A-Za-z0-9._~+/-]{20,}=*`},
+	{Name: "email", Pattern: `\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`},
+}
+
+type compiledRule struct {
+	replacement string
+	re          *regexp.Regexp
+}
+
+// Redactor applies redaction rules to text. A nil *Redactor leaves text
+// unchanged, so clients can hold one unconditionally.
+type Redactor struct {
+	rules []compiledRule
+}
+
+// New creates a redactor with DefaultRules followed by the custom patterns
+// (config redact.patterns), which are labeled "custom"
+func New(custom []string) (*Redactor, error) {
+	rules := append([]Rule(nil), DefaultRules...)
+	for _, pattern := range custom {
+		rules = append(rules, Rule{Name: "custom", Pattern: pattern})
+	}
+
+	r := &Redactor{}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern '%s': %w", rule.Pattern, err)
+		}
+		r.rules = append(r.rules, compiledRule{replacement: "[REDACTED:" + rule.Name + "]", re: re})
+	}
+	return r, nil
+}
+
+// String returns s with every rule's matches replaced
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.re.ReplaceAllLiteralString(s, rule.replacement)
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+package runstate
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/constants"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/output"
+)
+
+// State checkpoints the per-email results of a multi-user run so it can be resumed
+type State struct {
+	ID        string                          `json:"id"`
+	StartDate string                          `json:"start_date"`
+	EndDate   string                          `json:"end_date"`
+	Emails    []string                        `json:"emails"`
+	Results   map[string]output.HighlightData `json:"results"`
+	Updated   time.Time                       `json:"updated"`
+
+	path string
+}
+
+// RunID derives a stable run identifier from the command and its inputs, so
+// re-running the same command with --resume finds the same state file
+func RunID(command string, emails []string, startDate, endDate string, listCount int) string {
+	key := fmt.Sprintf("%s|%s|%s|%s|%d", command, strings.Join(emails, ","), startDate, endDate, listCount)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))[:12]
+}
+
+// Open returns the state for a run. When resume is true an existing state file
+// is loaded; otherwise (or if none exists) a fresh state is started.
+func Open(id string, emails []string, startDate, endDate string, resume bool) (*State, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	runsDir := filepath.Join(homeDir, constants.CacheBaseDir, constants.RunsSubDir)
+	if err := os.MkdirAll(runsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create runs directory: %w", err)
+	}
+
+	state := &State{
+		ID:        id,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Emails:    emails,
+		Results:   make(map[string]output.HighlightData),
+		path:      filepath.Join(runsDir, id+".json"),
+	}
+	if !resume {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(state.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse run state %s: %w", state.path, err)
+	}
+	if state.Results == nil {
+		state.Results = make(map[string]output.HighlightData)
+	}
+	return state, nil
+}
+
+// Path returns the location of the state file
+func (s *State) Path() string {
+	return s.path
+}
+
+// Done reports whether a result has already been recorded for email
+func (s *State) Done(email string) bool {
+	_, ok := s.Results[email]
+	return ok
+}
+
+// Record stores the result for email and immediately persists the state
+func (s *State) Record(email string, data output.HighlightData) error {
+	s.Results[email] = data
+	s.Updated = time.Now()
+	return s.save()
+}
+
+// Remove deletes the state file (called once every email has completed)
+func (s *State) Remove() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// save writes the state atomically so an interrupted run never leaves a truncated file
+func (s *State) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
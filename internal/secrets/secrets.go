@@ -0,0 +1,106 @@
+// Package secrets resolves credentials such as API tokens from a configurable
+// backend, so they don't have to be stored in plaintext config
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by a provider that has no value for a key
+var ErrNotFound = errors.New("secret not found")
+
+// Provider fetches secrets by config key, e.g. "jira.token"
+type Provider interface {
+	GetSecret(key string) (string, error)
+}
+
+// Config holds settings shared by the built-in providers
+type Config struct {
+	// Dir is the directory read by the file provider
+	Dir string
+	// EnvPrefix is prepended to environment variable names by the env provider
+	EnvPrefix string
+}
+
+// Factory creates a provider from its config
+type Factory func(cfg Config) (Provider, error)
+
+var factories = map[string]Factory{
+	"env":  func(cfg Config) (Provider, error) { return NewEnvProvider(cfg.EnvPrefix), nil },
+	"file": func(cfg Config) (Provider, error) { return NewFileProvider(cfg.Dir) },
+}
+
+// Register adds a named provider, e.g. a Vault or AWS Secrets Manager backend
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// New creates the named provider
+func New(name string, cfg Config) (Provider, error) {
+	factory, ok := factories[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(factories))
+		for n := range factories {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown secrets provider '%s': supported providers are %s", name, strings.Join(names, ", "))
+	}
+	return factory(cfg)
+}
+
+// EnvProvider reads secrets from environment variables. The key "jira.token"
+// with prefix "PERFDIVE_" is read from PERFDIVE_JIRA_TOKEN.
+type EnvProvider struct {
+	prefix string
+}
+
+// NewEnvProvider creates an environment variable provider
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{prefix: prefix}
+}
+
+// GetSecret returns the value of the key's environment variable
+func (p *EnvProvider) GetSecret(key string) (string, error) {
+	name := p.prefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	if value := os.Getenv(name); value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("%w: %s is not set", ErrNotFound, name)
+}
+
+// FileProvider reads each secret from a file named after its key, e.g.
+// <dir>/jira.token, as with Kubernetes or Docker secret mounts
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a file provider reading from dir
+func NewFileProvider(dir string) (*FileProvider, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file secrets provider requires a directory (secrets.dir)")
+	}
+	return &FileProvider{dir: dir}, nil
+}
+
+// GetSecret returns the trimmed contents of the key's file
+func (p *FileProvider) GetSecret(key string) (string, error) {
+	path := filepath.Join(p.dir, filepath.Base(key))
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s does not exist", ErrNotFound, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", path, err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNotFound, path)
+	}
+	return value, nil
+}
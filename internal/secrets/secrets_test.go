@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "jira.token"), []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PERFDIVE_JIRA_TOKEN", "env-token")
+	t.Setenv("PERFDIVE_GITHUB_TOKEN", "")
+
+	tests := []struct {
+		provider string
+		key      string
+		want     string
+		wantErr  error
+	}{
+		{provider: "env", key: "jira.token", want: "env-token"},
+		{provider: "env", key: "github.token", wantErr: ErrNotFound},
+		{provider: "file", key: "jira.token", want: "file-token"},
+		{provider: "file", key: "github.token", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.key, func(t *testing.T) {
+			p, err := New(tt.provider, Config{Dir: dir, EnvPrefix: "PERFDIVE_"})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			got, err := p.GetSecret(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewUnknownProvider(t *testing.T) {
+	if _, err := New("vault", Config{}); err == nil {
+		t.Error("New(vault) succeeded without a registered vault provider")
+	}
+}
@@ -0,0 +1,220 @@
+// Package tui is an interactive terminal browser for fetched Jira issues and
+// GitHub pull requests
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+)
+
+// Config holds the data shown by the browser
+type Config struct {
+	Title        string
+	Issues       []jira.Issue
+	PullRequests []github.UserPullRequest
+	// Summarize generates the AI summary; nil disables the summary action
+	Summarize func() (string, error)
+}
+
+// item is one row of the list: a Jira issue or a pull request
+type item struct {
+	label  string
+	detail string
+}
+
+// summaryMsg carries the result of a Summarize call
+type summaryMsg struct {
+	summary string
+	err     error
+}
+
+// Model is the bubbletea model for the browser
+type Model struct {
+	title      string
+	items      []item
+	summarize  func() (string, error)
+	cursor     int
+	offset     int
+	expanded   bool
+	height     int
+	summary    string
+	status     string
+	generating bool
+}
+
+// New builds the browser model, listing Jira issues before pull requests
+func New(config Config) Model {
+	m := Model{title: config.Title, summarize: config.Summarize, height: 24}
+	for _, issue := range config.Issues {
+		m.items = append(m.items, issueItem(issue))
+	}
+	for _, pr := range config.PullRequests {
+		m.items = append(m.items, prItem(pr))
+	}
+	return m
+}
+
+// Run starts the browser on the terminal's alternate screen
+func Run(config Config) error {
+	_, err := tea.NewProgram(New(config), tea.WithAltScreen()).Run()
+	return err
+}
+
+// Init implements tea.Model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case summaryMsg:
+		m.generating = false
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Summary failed: %v", msg.err)
+		} else {
+			m.summary = msg.summary
+			m.status = ""
+		}
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "j", "down":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "g", "home":
+			m.cursor = 0
+		case "G", "end":
+			if len(m.items) > 0 {
+				m.cursor = len(m.items) - 1
+			}
+		case "enter", " ":
+			m.expanded = !m.expanded
+		case "s":
+			if m.summarize == nil {
+				m.status = "AI summary unavailable: no Ollama URL configured"
+				return m, nil
+			}
+			if m.generating {
+				return m, nil
+			}
+			m.generating = true
+			m.status = "Generating AI summary..."
+			summarize := m.summarize
+			return m, func() tea.Msg {
+				summary, err := summarize()
+				return summaryMsg{summary: summary, err: err}
+			}
+		}
+	}
+	m.offset = scrollOffset(m.cursor, m.offset, m.listHeight())
+	return m, nil
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", m.title)
+
+	if len(m.items) == 0 {
+		b.WriteString("  No Jira issues or pull requests in this period\n")
+	}
+	end := min(m.offset+m.listHeight(), len(m.items))
+	for i := m.offset; i < end; i++ {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, m.items[i].label)
+	}
+
+	if m.expanded && m.cursor < len(m.items) {
+		fmt.Fprintf(&b, "\n%s\n", m.items[m.cursor].detail)
+	}
+	if m.summary != "" {
+		fmt.Fprintf(&b, "\nAI summary:\n%s\n", m.summary)
+	}
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+
+	b.WriteString("\nj/k: move  enter: expand  s: generate/refresh AI summary  q: quit\n")
+	return b.String()
+}
+
+// listHeight is the number of list rows that fit alongside the header, help
+// and a detail pane
+func (m Model) listHeight() int {
+	return max(m.height/2, 5)
+}
+
+// scrollOffset keeps the cursor within the visible window of rows
+func scrollOffset(cursor, offset, rows int) int {
+	if cursor < offset {
+		return cursor
+	}
+	if cursor >= offset+rows {
+		return cursor - rows + 1
+	}
+	return offset
+}
+
+// issueItem describes a Jira issue for the list and detail pane
+func issueItem(issue jira.Issue) item {
+	detail := []string{
+		fmt.Sprintf("%s: %s", issue.Key, issue.Summary),
+		fmt.Sprintf("Type: %s  Status: %s  Priority: %s", issue.IssueType.Name, issue.Status.Name, issue.Priority.Name),
+		fmt.Sprintf("Created: %s  Updated: %s", issue.Created, issue.Updated),
+	}
+	if issue.Resolved != "" {
+		detail = append(detail, fmt.Sprintf("Resolved: %s", issue.Resolved))
+	}
+	if issue.Description != "" {
+		detail = append(detail, "", truncate(issue.Description, 600))
+	}
+	return item{
+		label:  fmt.Sprintf("[Jira] %s %s (%s)", issue.Key, issue.Summary, issue.Status.Name),
+		detail: strings.Join(detail, "\n"),
+	}
+}
+
+// prItem describes a pull request for the list and detail pane
+func prItem(pr github.UserPullRequest) item {
+	detail := []string{
+		fmt.Sprintf("%s#%d: %s", pr.RepoName(), pr.Number, pr.Title),
+		fmt.Sprintf("Status: %s  Created: %s", pr.Status(), pr.CreatedAt),
+		pr.HTMLURL,
+	}
+	if pr.Stats != nil {
+		detail = append(detail, fmt.Sprintf("Size: %s (+%d/-%d in %d files)", pr.Stats.Size(), pr.Stats.Additions, pr.Stats.Deletions, pr.Stats.ChangedFiles))
+	}
+	if pr.Body != "" {
+		detail = append(detail, "", truncate(pr.Body, 600))
+	}
+	return item{
+		label:  fmt.Sprintf("[PR] %s#%d %s (%s)", pr.RepoName(), pr.Number, pr.Title, pr.Status()),
+		detail: strings.Join(detail, "\n"),
+	}
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n]) + "..."
+}
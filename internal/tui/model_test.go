@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/github"
+	"github.com/redhat-best-practices-for-k8s/perfdive/internal/jira"
+)
+
+func press(t *testing.T, m Model, key string) (Model, tea.Cmd) {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestModelNavigation(t *testing.T) {
+	m := New(Config{
+		Title:  "Activity",
+		Issues: []jira.Issue{{Key: "CNF-1", Summary: "Fix probe", Description: "Readiness probe flaps"}},
+		PullRequests: []github.UserPullRequest{
+			{Number: 7, Title: "Add retries", RepositoryURL: "https://api.github.com/repos/org/repo", State: "open"},
+		},
+	})
+
+	tests := []struct {
+		key        string
+		wantCursor int
+	}{
+		{"k", 0}, // already at the top
+		{"j", 1},
+		{"j", 1}, // already at the bottom
+		{"k", 0},
+	}
+	for _, tt := range tests {
+		m, _ = press(t, m, tt.key)
+		if m.cursor != tt.wantCursor {
+			t.Errorf("after %q: cursor = %d, want %d", tt.key, m.cursor, tt.wantCursor)
+		}
+	}
+
+	if strings.Contains(m.View(), "Readiness probe flaps") {
+		t.Error("detail pane shown before expanding")
+	}
+	m, _ = press(t, m, "enter")
+	if view := m.View(); !strings.Contains(view, "Readiness probe flaps") || !strings.Contains(view, "[PR] org/repo#7 Add retries") {
+		t.Errorf("expanded view missing issue detail or PR row:\n%s", view)
+	}
+}
+
+func TestModelSummary(t *testing.T) {
+	m := New(Config{Title: "Activity"})
+	m, cmd := press(t, m, "s")
+	if cmd != nil || !strings.Contains(m.status, "unavailable") {
+		t.Errorf("summary without Summarize: status %q, cmd %v", m.status, cmd)
+	}
+
+	calls := 0
+	m = New(Config{Title: "Activity", Summarize: func() (string, error) {
+		calls++
+		if calls > 1 {
+			return "", errors.New("ollama down")
+		}
+		return "Shipped retries", nil
+	}})
+	for _, want := range []string{"Shipped retries", "Summary failed: ollama down"} {
+		m, cmd = press(t, m, "s")
+		if cmd == nil || !m.generating {
+			t.Fatal("summary action did not start generation")
+		}
+		updated, _ := m.Update(cmd())
+		m = updated.(Model)
+		if !strings.Contains(m.View(), want) {
+			t.Errorf("view missing %q:\n%s", want, m.View())
+		}
+	}
+	// A failed refresh keeps the previous summary
+	if m.summary != "Shipped retries" {
+		t.Errorf("summary = %q, want the previous summary kept", m.summary)
+	}
+}
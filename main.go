@@ -4,6 +4,13 @@ import (
 	"github.com/redhat-best-practices-for-k8s/perfdive/cmd"
 )
 
+// Set at build time via -ldflags (see Makefile)
+var (
+	Version   = "dev"
+	BuildTime = "unknown"
+)
+
 func main() {
+	cmd.SetVersionInfo(Version, BuildTime)
 	cmd.Execute()
 }